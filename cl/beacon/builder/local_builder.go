@@ -0,0 +1,291 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/phase1/execution_client"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/node/gointerfaces/typesproto"
+)
+
+const (
+	// submissionMargin - bid is submitted this long before start of proposal slot: EL builds block until then
+	submissionMargin = time.Second
+	// assembleTimeout - how long to wait for EL to return built block
+	assembleTimeout = 2 * time.Second
+)
+
+// ForkchoiceReader - source of safe and finalized execution blocks for forkchoiceUpdated of block building
+type ForkchoiceReader interface {
+	JustifiedCheckpoint() solid.Checkpoint
+	FinalizedCheckpoint() solid.Checkpoint
+	GetEth1Hash(eth2Root common.Hash) common.Hash
+}
+
+// LocalBuilder - builder mode: for every payload_attributes event builds block by local EL with proposer's fee recipient,
+// and submits it as signed bid to relays at which proposer of slot is registered.
+// Bid value is block value to fee recipient, as computed by EL.
+type LocalBuilder struct {
+	beaconConfig *clparams.BeaconChainConfig
+	engine       execution_client.ExecutionEngine
+	forkchoice   ForkchoiceReader
+	relays       []*RelayClient
+	secretKey    *bls.PrivateKey
+	publicKey    common.Bytes48
+	domain       []byte
+	logger       log.Logger
+}
+
+func NewLocalBuilder(beaconConfig *clparams.BeaconChainConfig, engine execution_client.ExecutionEngine, forkchoice ForkchoiceReader,
+	relayUrls []string, secretKey []byte, logger log.Logger) (*LocalBuilder, error) {
+	if len(relayUrls) == 0 {
+		return nil, errors.New("no relays to submit blocks to")
+	}
+	key, err := bls.NewPrivateKeyFromBytes(secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("builder secret key: %w", err)
+	}
+	domain, err := builderDomain(beaconConfig)
+	if err != nil {
+		return nil, err
+	}
+	b := &LocalBuilder{
+		beaconConfig: beaconConfig,
+		engine:       engine,
+		forkchoice:   forkchoice,
+		secretKey:    key,
+		publicKey:    common.Bytes48(bls.CompressPublicKey(key.PublicKey())),
+		domain:       domain,
+		logger:       logger,
+	}
+	for _, relayUrl := range relayUrls {
+		relay, err := NewRelayClient(relayUrl)
+		if err != nil {
+			return nil, fmt.Errorf("relay %s: %w", relayUrl, err)
+		}
+		b.relays = append(b.relays, relay)
+	}
+	return b, nil
+}
+
+// Start - listens to payload_attributes events until ctx is done
+func (b *LocalBuilder) Start(ctx context.Context, emitters *beaconevents.EventEmitter) {
+	b.logger.Info("[Builder] started", "pubkey", b.publicKey, "relays", len(b.relays))
+	eventCh := make(chan *beaconevents.EventStream, 128)
+	sub := emitters.State().Subscribe(eventCh)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-sub.Err():
+			if err != nil {
+				b.logger.Warn("[Builder] events subscription failed", "err", err)
+			}
+			return
+		case event := <-eventCh:
+			if event.Event != beaconevents.StatePayloadAttributes {
+				continue
+			}
+			attributes, ok := event.Data.(*beaconevents.PayloadAttributesData)
+			if !ok {
+				continue
+			}
+			// EL builds block during most of the slot: don't block events
+			go func() {
+				if err := b.OnPayloadAttributes(ctx, attributes); err != nil {
+					b.logger.Warn("[Builder] failed to build block", "slot", attributes.Data.ProposalSlot, "err", err)
+				}
+			}()
+		}
+	}
+}
+
+// OnPayloadAttributes - builds block for proposal slot of event and submits it to relays at which proposer is registered
+func (b *LocalBuilder) OnPayloadAttributes(ctx context.Context, event *beaconevents.PayloadAttributesData) error {
+	slot := event.Data.ProposalSlot
+	relays, registration := b.registeredRelays(ctx, slot)
+	if registration == nil {
+		b.logger.Debug("[Builder] proposer is not registered at relays", "slot", slot)
+		return nil
+	}
+
+	attributes := event.Data.PayloadAttributes
+	attributes.SuggestedFeeRecipient = registration.Message.FeeRecipient
+	head := event.Data.ParentBlockHash
+	finalized := b.forkchoice.GetEth1Hash(b.forkchoice.FinalizedCheckpoint().Root)
+	if finalized == (common.Hash{}) {
+		finalized = head
+	}
+	safe := b.forkchoice.GetEth1Hash(b.forkchoice.JustifiedCheckpoint().Root)
+	if safe == (common.Hash{}) {
+		safe = head
+	}
+	payloadID, err := b.engine.ForkChoiceUpdate(ctx, finalized, safe, head, &attributes)
+	if err != nil {
+		return fmt.Errorf("forkchoice update: %w", err)
+	}
+	if payloadID == nil {
+		return errors.New("EL didn't start block building")
+	}
+
+	submitAt := time.Unix(int64(attributes.Timestamp), 0).Add(-submissionMargin)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(submitAt)):
+	}
+	payload, blobsBundle, requestsBundle, blockValue, err := b.assembledBlock(ctx, payloadID)
+	if err != nil {
+		return err
+	}
+
+	version := b.beaconConfig.GetCurrentStateVersion(slot / b.beaconConfig.SlotsPerEpoch)
+	value := new(uint256.Int)
+	if blockValue != nil {
+		if overflow := value.SetFromBig(blockValue); overflow {
+			return fmt.Errorf("block value overflow: %s", blockValue)
+		}
+	}
+	req := &SubmitBlockRequest{
+		Message: &BidTrace{
+			Slot:                 slot,
+			ParentHash:           payload.ParentHash,
+			BlockHash:            payload.BlockHash,
+			BuilderPubkey:        b.publicKey,
+			ProposerPubkey:       registration.Message.PubKey,
+			ProposerFeeRecipient: registration.Message.FeeRecipient,
+			GasLimit:             payload.GasLimit,
+			GasUsed:              payload.GasUsed,
+			Value:                value,
+		},
+		ExecutionPayload: payload,
+	}
+	if version.AfterOrEqual(clparams.DenebVersion) {
+		req.BlobsBundle = blobsBundle
+	}
+	if version.AfterOrEqual(clparams.ElectraVersion) {
+		if req.ExecutionRequests, err = b.executionRequests(requestsBundle, version); err != nil {
+			return err
+		}
+	}
+	if req.Signature, err = signBid(req.Message, b.secretKey, b.domain); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, relay := range relays {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := relay.SubmitBlock(ctx, version, req); err != nil {
+				b.logger.Warn("[Builder] relay rejected block", "relay", relay, "slot", slot, "err", err)
+				return
+			}
+			b.logger.Info("[Builder] submitted block", "relay", relay, "slot", slot, "hash", payload.BlockHash, "value", value.Dec())
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// registeredRelays - relays at which proposer of slot is registered, and its registration
+func (b *LocalBuilder) registeredRelays(ctx context.Context, slot uint64) ([]*RelayClient, *cltypes.ValidatorRegistration) {
+	var relays []*RelayClient
+	var registration *cltypes.ValidatorRegistration
+	for _, relay := range b.relays {
+		duties, err := relay.GetValidators(ctx)
+		if err != nil {
+			b.logger.Debug("[Builder] failed to get validators from relay", "relay", relay, "err", err)
+			continue
+		}
+		for _, duty := range duties {
+			if duty.Slot != slot || duty.Entry == nil {
+				continue
+			}
+			if registration == nil {
+				registration = duty.Entry
+			}
+			// same proposer may be registered with other fee recipient at other relay: build one block for first one
+			if duty.Entry.Message.FeeRecipient == registration.Message.FeeRecipient {
+				relays = append(relays, relay)
+			}
+			break
+		}
+	}
+	return relays, registration
+}
+
+func (b *LocalBuilder) assembledBlock(ctx context.Context, payloadID []byte) (*cltypes.Eth1Block, *engine_types.BlobsBundle, *typesproto.RequestsBundle, *big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, assembleTimeout)
+	defer cancel()
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		payload, blobsBundle, requestsBundle, blockValue, err := b.engine.GetAssembledBlock(ctx, payloadID)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("get assembled block: %w", err)
+		}
+		if payload != nil {
+			return payload, blobsBundle, requestsBundle, blockValue, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, nil, nil, fmt.Errorf("get assembled block: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *LocalBuilder) executionRequests(bundle *typesproto.RequestsBundle, version clparams.StateVersion) (*cltypes.ExecutionRequests, error) {
+	requests := cltypes.NewExecutionRequests(b.beaconConfig)
+	for _, request := range bundle.GetRequests() {
+		if len(request) == 0 {
+			continue
+		}
+		var err error
+		switch request[0] {
+		case types.DepositRequestType:
+			err = requests.Deposits.DecodeSSZ(request[1:], int(version))
+		case types.WithdrawalRequestType:
+			err = requests.Withdrawals.DecodeSSZ(request[1:], int(version))
+		case types.ConsolidationRequestType:
+			err = requests.Consolidations.DecodeSSZ(request[1:], int(version))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode execution request of type %d: %w", request[0], err)
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/phase1/execution_client"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+)
+
+type fakeForkchoice struct {
+	justified, finalized common.Hash
+}
+
+func (f fakeForkchoice) JustifiedCheckpoint() solid.Checkpoint {
+	return solid.Checkpoint{Root: common.Hash{0xaa}}
+}
+
+func (f fakeForkchoice) FinalizedCheckpoint() solid.Checkpoint {
+	return solid.Checkpoint{Root: common.Hash{0xbb}}
+}
+
+func (f fakeForkchoice) GetEth1Hash(eth2Root common.Hash) common.Hash {
+	switch eth2Root {
+	case common.Hash{0xaa}:
+		return f.justified
+	case common.Hash{0xbb}:
+		return f.finalized
+	}
+	return common.Hash{}
+}
+
+func TestLocalBuilder(t *testing.T) {
+	beaconConfig := clparams.MainnetBeaconConfig
+	slot := beaconConfig.ElectraForkEpoch*beaconConfig.SlotsPerEpoch + 3
+
+	relay, err := NewMockRelay(&beaconConfig)
+	require.NoError(t, err)
+	defer relay.Close()
+	proposerKey, err := bls.GenerateKey()
+	require.NoError(t, err)
+	proposerPubKey := common.Bytes48(bls.CompressPublicKey(proposerKey.PublicKey()))
+	feeRecipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	relay.AddDuty(ProposerDuty{Slot: slot, ValidatorIndex: 7, Entry: &cltypes.ValidatorRegistration{
+		Message: cltypes.ValidatorRegistrationMessage{FeeRecipient: feeRecipient, GasLimit: "36000000", Timestamp: "1", PubKey: proposerPubKey},
+	}})
+
+	builderKey, err := bls.GenerateKey()
+	require.NoError(t, err)
+	forkchoice := fakeForkchoice{justified: common.Hash{0x0a}, finalized: common.Hash{0x0b}}
+	parentHash := common.Hash{0x01}
+	payloadID := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+	payload := cltypes.NewEth1Block(clparams.ElectraVersion, &beaconConfig)
+	payload.ParentHash = parentHash
+	payload.BlockHash = common.Hash{0x02}
+	payload.FeeRecipient = feeRecipient
+	payload.GasLimit = 36_000_000
+	payload.GasUsed = 21_000
+
+	ctrl := gomock.NewController(t)
+	engine := execution_client.NewMockExecutionEngine(ctrl)
+	gomock.InOrder(
+		engine.EXPECT().ForkChoiceUpdate(gomock.Any(), forkchoice.finalized, forkchoice.justified, parentHash, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ common.Hash, attributes *engine_types.PayloadAttributes) ([]byte, error) {
+				require.Equal(t, feeRecipient, attributes.SuggestedFeeRecipient)
+				return payloadID, nil
+			}),
+		// block is not ready yet
+		engine.EXPECT().GetAssembledBlock(gomock.Any(), payloadID).Return(nil, nil, nil, nil, nil),
+		engine.EXPECT().GetAssembledBlock(gomock.Any(), payloadID).Return(payload, &engine_types.BlobsBundle{}, nil, big.NewInt(12345), nil),
+	)
+
+	b, err := NewLocalBuilder(&beaconConfig, engine, forkchoice, []string{relay.URL()}, builderKey.Bytes(), log.New())
+	require.NoError(t, err)
+	event := &beaconevents.PayloadAttributesData{
+		Version: "electra",
+		Data: beaconevents.PayloadAttributesContent{
+			ProposalSlot:      slot,
+			ParentBlockHash:   parentHash,
+			PayloadAttributes: engine_types.PayloadAttributes{Timestamp: 1}, // slot is in the past: submit at once
+		},
+	}
+	require.NoError(t, b.OnPayloadAttributes(context.Background(), event))
+
+	submissions := relay.Submissions()
+	require.Len(t, submissions, 1)
+	bid := submissions[0].Message
+	require.Equal(t, "electra", submissions[0].Version)
+	require.Equal(t, slot, bid.Slot)
+	require.Equal(t, payload.BlockHash, bid.BlockHash)
+	require.Equal(t, proposerPubKey, bid.ProposerPubkey)
+	require.Equal(t, common.Bytes48(bls.CompressPublicKey(builderKey.PublicKey())), bid.BuilderPubkey)
+	require.Equal(t, uint64(12345), bid.Value.Uint64())
+	require.NotNil(t, submissions[0].BlobsBundle)
+	require.NotEmpty(t, submissions[0].ExecutionRequests)
+
+	// no proposer registered at relay for next slot: nothing to build
+	event.Data.ProposalSlot++
+	require.NoError(t, b.OnPayloadAttributes(context.Background(), event))
+	require.Len(t, relay.Submissions(), 1)
+}
+
+func TestMockRelayRejectsBadSignature(t *testing.T) {
+	beaconConfig := clparams.MainnetBeaconConfig
+	relay, err := NewMockRelay(&beaconConfig)
+	require.NoError(t, err)
+	defer relay.Close()
+	key, err := bls.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := bls.GenerateKey()
+	require.NoError(t, err)
+	relay.AddDuty(ProposerDuty{Slot: 1, Entry: &cltypes.ValidatorRegistration{}})
+
+	client, err := NewRelayClient(relay.URL())
+	require.NoError(t, err)
+	duties, err := client.GetValidators(context.Background())
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+
+	req := &SubmitBlockRequest{
+		Message:          &BidTrace{Slot: 1, BuilderPubkey: common.Bytes48(bls.CompressPublicKey(key.PublicKey())), Value: new(uint256.Int)},
+		ExecutionPayload: cltypes.NewEth1Block(clparams.DenebVersion, &beaconConfig),
+	}
+	domain, err := builderDomain(&beaconConfig)
+	require.NoError(t, err)
+	req.Signature, err = signBid(req.Message, otherKey, domain)
+	require.NoError(t, err)
+	require.Error(t, client.SubmitBlock(context.Background(), clparams.DenebVersion, req))
+
+	req.Signature, err = signBid(req.Message, key, domain)
+	require.NoError(t, err)
+	require.NoError(t, client.SubmitBlock(context.Background(), clparams.DenebVersion, req))
+	require.Len(t, relay.Submissions(), 1)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+)
+
+// MockRelaySubmission - block submission accepted by MockRelay
+type MockRelaySubmission struct {
+	Version           string
+	Message           *BidTrace                 `json:"message"`
+	ExecutionPayload  json.RawMessage           `json:"execution_payload"`
+	BlobsBundle       *engine_types.BlobsBundle `json:"blobs_bundle"`
+	ExecutionRequests json.RawMessage           `json:"execution_requests"`
+	Signature         common.Bytes96            `json:"signature"`
+}
+
+// MockRelay - local relay for tests of builder mode: serves proposer duties and accepts block submissions
+// which are correctly signed and pay to fee recipient of registered proposer.
+type MockRelay struct {
+	server *httptest.Server
+	domain []byte
+
+	lock        sync.Mutex
+	duties      []ProposerDuty
+	submissions []*MockRelaySubmission
+}
+
+func NewMockRelay(beaconConfig *clparams.BeaconChainConfig) (*MockRelay, error) {
+	domain, err := builderDomain(beaconConfig)
+	if err != nil {
+		return nil, err
+	}
+	m := &MockRelay{domain: domain}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /relay/v1/builder/validators", m.getValidators)
+	mux.HandleFunc("POST /relay/v1/builder/blocks", m.submitBlock)
+	m.server = httptest.NewServer(mux)
+	return m, nil
+}
+
+func (m *MockRelay) URL() string {
+	return m.server.URL
+}
+
+func (m *MockRelay) Close() {
+	m.server.Close()
+}
+
+func (m *MockRelay) AddDuty(duty ProposerDuty) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.duties = append(m.duties, duty)
+}
+
+func (m *MockRelay) Submissions() []*MockRelaySubmission {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*MockRelaySubmission{}, m.submissions...)
+}
+
+func (m *MockRelay) getValidators(w http.ResponseWriter, _ *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.duties); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (m *MockRelay) submitBlock(w http.ResponseWriter, r *http.Request) {
+	submission := &MockRelaySubmission{Version: r.Header.Get("Eth-Consensus-Version")}
+	if err := json.NewDecoder(r.Body).Decode(submission); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := m.validate(submission); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.submissions = append(m.submissions, submission)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *MockRelay) validate(submission *MockRelaySubmission) error {
+	bid := submission.Message
+	if bid == nil || bid.Value == nil {
+		return errors.New("missing bid")
+	}
+	ok, err := verifyBid(bid, submission.Signature, m.domain)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+
+	var payload struct {
+		ParentHash   common.Hash    `json:"parent_hash"`
+		BlockHash    common.Hash    `json:"block_hash"`
+		FeeRecipient common.Address `json:"fee_recipient"`
+	}
+	if err := json.Unmarshal(submission.ExecutionPayload, &payload); err != nil {
+		return err
+	}
+	if payload.BlockHash != bid.BlockHash || payload.ParentHash != bid.ParentHash {
+		return errors.New("bid doesn't match payload")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, duty := range m.duties {
+		if duty.Slot != bid.Slot {
+			continue
+		}
+		if duty.Entry.Message.PubKey != bid.ProposerPubkey || duty.Entry.Message.FeeRecipient != bid.ProposerFeeRecipient {
+			return errors.New("bid doesn't match registration of proposer")
+		}
+		if payload.FeeRecipient != bid.ProposerFeeRecipient {
+			return errors.New("block doesn't pay to proposer fee recipient")
+		}
+		return nil
+	}
+	return fmt.Errorf("no proposer registered for slot %d", bid.Slot)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+// RelayClient - builder side of relay API
+// ref: https://flashbots.github.io/relay-specs/
+type RelayClient struct {
+	httpClient *http.Client
+	url        *url.URL
+}
+
+func NewRelayClient(baseUrl string) (*RelayClient, error) {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &RelayClient{httpClient: &http.Client{Timeout: 5 * time.Second}, url: u}, nil
+}
+
+func (r *RelayClient) String() string {
+	return r.url.Redacted()
+}
+
+// GetValidators - proposers of current and next epoch, registered at relay
+func (r *RelayClient) GetValidators(ctx context.Context) ([]ProposerDuty, error) {
+	url := r.url.JoinPath("/relay/v1/builder/validators").String()
+	duties, err := httpCall(ctx, r.httpClient, http.MethodGet, url, nil, nil, []ProposerDuty{})
+	if errors.Is(err, ErrNoContent) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return *duties, nil
+}
+
+func (r *RelayClient) SubmitBlock(ctx context.Context, version clparams.StateVersion, req *SubmitBlockRequest) error {
+	url := r.url.JoinPath("/relay/v1/builder/blocks").String()
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Eth-Consensus-Version": version.String(),
+	}
+	_, err = httpCall(ctx, r.httpClient, http.MethodPost, url, headers, bytes.NewBuffer(payload), json.RawMessage{})
+	if errors.Is(err, ErrNoContent) {
+		return nil
+	}
+	return err
+}
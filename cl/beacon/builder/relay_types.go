@@ -0,0 +1,91 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/fork"
+	"github.com/erigontech/erigon/cl/merkle_tree"
+	"github.com/erigontech/erigon/cl/utils"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+)
+
+// BidTrace - bid of builder for slot, signed by builder
+// ref: https://flashbots.github.io/relay-specs/#/Builder/submitBlock
+type BidTrace struct {
+	Slot                 uint64         `json:"slot,string"`
+	ParentHash           common.Hash    `json:"parent_hash"`
+	BlockHash            common.Hash    `json:"block_hash"`
+	BuilderPubkey        common.Bytes48 `json:"builder_pubkey"`
+	ProposerPubkey       common.Bytes48 `json:"proposer_pubkey"`
+	ProposerFeeRecipient common.Address `json:"proposer_fee_recipient"`
+	GasLimit             uint64         `json:"gas_limit,string"`
+	GasUsed              uint64         `json:"gas_used,string"`
+	Value                *uint256.Int   `json:"value"` // in wei, paid to proposer fee recipient
+}
+
+func (b *BidTrace) HashSSZ() ([32]byte, error) {
+	value := b.Value.Bytes32()
+	slices.Reverse(value[:]) // ssz uint256 is little-endian
+	return merkle_tree.HashTreeRoot(b.Slot, b.ParentHash[:], b.BlockHash[:], b.BuilderPubkey[:], b.ProposerPubkey[:],
+		b.ProposerFeeRecipient[:], b.GasLimit, b.GasUsed, value[:])
+}
+
+// SubmitBlockRequest - ref: https://flashbots.github.io/relay-specs/#/Builder/submitBlock
+type SubmitBlockRequest struct {
+	Message           *BidTrace                  `json:"message"`
+	ExecutionPayload  *cltypes.Eth1Block         `json:"execution_payload"`
+	BlobsBundle       *engine_types.BlobsBundle  `json:"blobs_bundle,omitempty"`       // since deneb
+	ExecutionRequests *cltypes.ExecutionRequests `json:"execution_requests,omitempty"` // since electra
+	Signature         common.Bytes96             `json:"signature"`
+}
+
+// ProposerDuty - validator registered at relay, which proposes block at slot
+// ref: https://flashbots.github.io/relay-specs/#/Builder/getValidators
+type ProposerDuty struct {
+	Slot           uint64                         `json:"slot,string"`
+	ValidatorIndex uint64                         `json:"validator_index,string"`
+	Entry          *cltypes.ValidatorRegistration `json:"entry"`
+}
+
+// builderDomain - signature domain of builder bids: DOMAIN_APPLICATION_BUILDER on genesis fork version, not bound to any chain state
+func builderDomain(beaconConfig *clparams.BeaconChainConfig) ([]byte, error) {
+	return fork.ComputeDomain(beaconConfig.DomainApplicationBuilder[:], utils.Uint32ToBytes4(uint32(beaconConfig.GenesisForkVersion)), [32]byte{})
+}
+
+func signBid(bid *BidTrace, secretKey *bls.PrivateKey, domain []byte) (common.Bytes96, error) {
+	signingRoot, err := fork.ComputeSigningRoot(bid, domain)
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	return common.Bytes96(secretKey.Sign(signingRoot[:]).Bytes()), nil
+}
+
+func verifyBid(bid *BidTrace, signature common.Bytes96, domain []byte) (bool, error) {
+	signingRoot, err := fork.ComputeSigningRoot(bid, domain)
+	if err != nil {
+		return false, err
+	}
+	return bls.Verify(signature[:], signingRoot[:], bid.BuilderPubkey[:])
+}
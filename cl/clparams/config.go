@@ -58,6 +58,10 @@ type CaplinConfig struct {
 	// CaplinMeVRelayUrl is optional and is used to connect to the external builder service.
 	// If it's set, the node will start in builder mode
 	MevRelayUrl string
+	// BuilderRelayUrls is optional: if set, the node acts as block builder and submits its blocks to these relays
+	BuilderRelayUrls []string
+	// BuilderSecretKeyPath is path to file with hex-encoded BLS secret key which signs builder bids
+	BuilderSecretKeyPath string
 	// EnableValidatorMonitor is used to enable the validator monitor metrics and corresponding logs
 	EnableValidatorMonitor bool
 
@@ -971,11 +975,16 @@ func mainnetConfig() BeaconChainConfig {
 }
 
 func CustomConfig(configFile string) (BeaconChainConfig, NetworkConfig, error) {
-	networkConfig, beaconCfg := GetConfigsByNetwork(chainspec.MainnetChainID)
 	b, err := os.ReadFile(configFile) // just pass the file name
 	if err != nil {
 		return BeaconChainConfig{}, NetworkConfig{}, err
 	}
+	return ParseCustomConfig(b)
+}
+
+// ParseCustomConfig parses a YAML beacon config, applied on top of the mainnet one.
+func ParseCustomConfig(b []byte) (BeaconChainConfig, NetworkConfig, error) {
+	networkConfig, beaconCfg := GetConfigsByNetwork(chainspec.MainnetChainID)
 
 	// setup beacon chain config
 	if err := yaml.Unmarshal(b, &beaconCfg); err != nil {
@@ -1362,9 +1371,37 @@ func GetConfigsByNetworkName(net string) (*NetworkConfig, *BeaconChainConfig, Ne
 		networkCfg, beaconCfg := GetConfigsByNetwork(chainspec.HoodiChainID)
 		return networkCfg, beaconCfg, chainspec.HoodiChainID, nil
 	default:
+		if id, ok := registeredNetworks[net]; ok {
+			networkCfg, beaconCfg := GetConfigsByNetwork(id)
+			return networkCfg, beaconCfg, id, nil
+		}
 		return nil, nil, chainspec.MainnetChainID, errors.New("chain not found")
 	}
 }
+
+// registeredNetworks maps the names of networks registered at runtime to their id
+var registeredNetworks = map[string]NetworkType{}
+
+// RegisterNetwork registers the beacon and network configs of a network defined
+// at runtime (e.g. loaded from a chain bundle), so it's supported by the embedded
+// CL like the built-in ones. If the name or id already exist, they are overwritten.
+func RegisterNetwork(name string, id NetworkType, networkCfg NetworkConfig, beaconCfg BeaconChainConfig, checkpointSyncEndpoints []string) {
+	registeredNetworks[name] = id
+	NetworkConfigs[id] = networkCfg
+	BeaconConfigs[id] = beaconCfg
+	if len(checkpointSyncEndpoints) > 0 {
+		CheckpointSyncEndpoints[id] = checkpointSyncEndpoints
+	}
+}
+
+func isRegisteredNetwork(id uint64) bool {
+	for _, registered := range registeredNetworks {
+		if uint64(registered) == id {
+			return true
+		}
+	}
+	return false
+}
 func GetAllCheckpointSyncEndpoints(net NetworkType) []string {
 	shuffle := func(urls []string) []string {
 		if len(urls) <= 1 {
@@ -1420,7 +1457,8 @@ func EmbeddedSupported(id uint64) bool {
 		id == chainspec.SepoliaChainID ||
 		id == chainspec.GnosisChainID ||
 		id == chainspec.ChiadoChainID ||
-		id == chainspec.HoodiChainID
+		id == chainspec.HoodiChainID ||
+		isRegisteredNetwork(id)
 }
 
 func SupportBackfilling(networkId uint64) bool {
@@ -1428,7 +1466,8 @@ func SupportBackfilling(networkId uint64) bool {
 		networkId == chainspec.SepoliaChainID ||
 		networkId == chainspec.GnosisChainID ||
 		networkId == chainspec.ChiadoChainID ||
-		networkId == chainspec.HoodiChainID
+		networkId == chainspec.HoodiChainID ||
+		isRegisteredNetwork(networkId)
 }
 
 func EpochToPaths(slot uint64, config *BeaconChainConfig, suffix string) (string, string) {
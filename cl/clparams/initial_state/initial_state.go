@@ -85,15 +85,32 @@ func GetGenesisState(network clparams.NetworkType) (*state.CachingBeaconState, e
 			return nil, err
 		}
 	default:
-		return nil, nil
+		encodedState, ok := registeredGenesisStates[network]
+		if !ok {
+			return nil, nil
+		}
+		if err := returnState.DecodeSSZ(encodedState, int(config.GetCurrentStateVersion(config.GenesisEpoch))); err != nil {
+			return nil, err
+		}
 	}
 	return returnState, nil
 }
 
+// registeredGenesisStates holds the SSZ encoded genesis states of the networks registered at runtime
+var registeredGenesisStates = map[clparams.NetworkType][]byte{}
+
+// RegisterGenesisState registers the SSZ encoded genesis state of a network defined
+// at runtime (e.g. loaded from a chain bundle); its beacon config must be registered
+// with clparams.RegisterNetwork.
+func RegisterGenesisState(network clparams.NetworkType, encodedState []byte) {
+	registeredGenesisStates[network] = encodedState
+}
+
 func IsGenesisStateSupported(network clparams.NetworkType) bool {
 	return network == chainspec.MainnetChainID ||
 		network == chainspec.SepoliaChainID ||
 		network == chainspec.GnosisChainID ||
 		network == chainspec.ChiadoChainID ||
-		network == chainspec.HoodiChainID
+		network == chainspec.HoodiChainID ||
+		registeredGenesisStates[network] != nil
 }
@@ -0,0 +1,55 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cl/antiquary/tests"
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+	"github.com/erigontech/erigon/common"
+)
+
+func TestEmitNextPayloadAttributesEvent(t *testing.T) {
+	_, _, s := tests.GetCapellaRandom()
+	headRoot, err := s.BlockRoot()
+	require.NoError(t, err)
+
+	cfg := &Cfg{
+		beaconCfg: s.BeaconConfig(),
+		ethClock:  eth_clock.NewEthereumClock(s.GenesisTime(), s.GenesisValidatorsRoot(), s.BeaconConfig()),
+		emitter:   beaconevents.NewEventEmitter(),
+	}
+	ch := make(chan *beaconevents.EventStream, 1)
+	sub := cfg.emitter.State().Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, emitNextPaylodAttributesEvent(cfg, s.Slot(), headRoot, s))
+
+	event := <-ch
+	require.Equal(t, beaconevents.StatePayloadAttributes, event.Event)
+	data := event.Data.(*beaconevents.PayloadAttributesData).Data
+	header := s.LatestExecutionPayloadHeader()
+	require.NotEqual(t, header.StateRoot, header.BlockHash)
+	require.Equal(t, header.BlockHash, data.ParentBlockHash)
+	require.Equal(t, header.BlockNumber, data.ParentBlockNumber)
+	require.Equal(t, common.Hash(headRoot), data.ParentBlockRoot)
+	require.Equal(t, s.Slot()+1, data.ProposalSlot)
+}
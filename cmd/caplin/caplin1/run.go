@@ -23,6 +23,7 @@ import (
 	"math"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/spf13/afero"
@@ -33,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/cl/antiquary"
 	"github.com/erigontech/erigon/cl/beacon"
 	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/builder"
 	"github.com/erigontech/erigon/cl/beacon/handler"
 	"github.com/erigontech/erigon/cl/beacon/synced_data"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -65,6 +67,7 @@ import (
 	"github.com/erigontech/erigon/cl/validator/committee_subscription"
 	"github.com/erigontech/erigon/cl/validator/sync_contribution_pool"
 	"github.com/erigontech/erigon/cl/validator/validator_params"
+	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/common/dir"
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/db/datadir"
@@ -461,6 +464,18 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 		log.Info("Beacon API started", "addr", config.BeaconAPIRouter.Address)
 	}
 
+	if len(config.BuilderRelayUrls) > 0 {
+		secretKey, err := os.ReadFile(config.BuilderSecretKeyPath)
+		if err != nil {
+			return fmt.Errorf("reading builder secret key: %w", err)
+		}
+		localBuilder, err := builder.NewLocalBuilder(beaconConfig, engine, forkChoice, config.BuilderRelayUrls, common.FromHex(strings.TrimSpace(string(secretKey))), logger)
+		if err != nil {
+			return err
+		}
+		go localBuilder.Start(ctx, emitters)
+	}
+
 	stageCfg := stages.ClStagesCfg(
 		beaconRpc,
 		antiq,
@@ -57,7 +57,9 @@ import (
 	"github.com/erigontech/erigon/db/kv/dbcfg"
 	"github.com/erigontech/erigon/db/kv/mdbx"
 	"github.com/erigontech/erigon/db/snapcfg"
+	"github.com/erigontech/erigon/db/snapstore"
 	"github.com/erigontech/erigon/db/version"
+	chainbundle "github.com/erigontech/erigon/execution/chain/bundle"
 	chainspec "github.com/erigontech/erigon/execution/chain/spec"
 	"github.com/erigontech/erigon/node/debug"
 	"github.com/erigontech/erigon/node/gointerfaces/downloaderproto"
@@ -106,6 +108,8 @@ var (
 	seedbox              bool
 	dbWritemap           bool
 	all                  bool
+	sharedStoreDir       string
+	sharedStoreMode      string
 )
 
 func init() {
@@ -130,6 +134,8 @@ func init() {
 	rootCmd.Flags().BoolVar(&disableIPV4, "downloader.disable.ipv4", utils.DisableIPV4.Value, utils.DisableIPV4.Usage)
 	rootCmd.Flags().BoolVar(&seedbox, "seedbox", false, "Turns downloader into independent (doesn't need Erigon) software which discover/download/seed new files - useful for Erigon network, and can work on very cheap hardware. It will: 1) download .torrent from webseed 2) download new files after upgrade 3) we planing add discovery of new files soon")
 	rootCmd.Flags().BoolVar(&dbWritemap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
+	rootCmd.Flags().StringVar(&sharedStoreDir, utils.SnapSharedStoreFlag.Name, "", utils.SnapSharedStoreFlag.Usage)
+	rootCmd.Flags().StringVar(&sharedStoreMode, utils.SnapSharedStoreModeFlag.Name, utils.SnapSharedStoreModeFlag.Value, utils.SnapSharedStoreModeFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&verify, "verify", false, utils.DownloaderVerifyFlag.Usage)
 	rootCmd.PersistentFlags().StringVar(&_verifyFiles, "verify.files", "", "Limit list of files to verify")
 	rootCmd.PersistentFlags().BoolVar(&verifyFailfast, "verify.failfast", false, "Stop on first found error. Report it and exit")
@@ -198,11 +204,13 @@ var rootCmd = &cobra.Command{
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		debug.Exit()
 	},
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
 		if cmd.Name() != "torrent_cat" {
 			logger = debug.SetupCobra(cmd, "downloader")
 			logger.Info("Build info", "git_branch", version.GitBranch, "git_tag", version.GitTag, "git_commit", version.GitCommit)
 		}
+		chain, err = chainbundle.Resolve(chain, log.Root())
+		return err
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := Downloader(cmd.Context(), logger); err != nil {
@@ -273,6 +281,8 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 		downloadercfg.NewCfgOpts{
 			DownloadRateLimit: downloadRate.TorrentRateLimit(),
 			UploadRateLimit:   uploadRate.TorrentRateLimit(),
+			SharedStoreDir:    sharedStoreDir,
+			SharedStoreMode:   snapstore.Mode(sharedStoreMode),
 		},
 	)
 	if err != nil {
@@ -188,6 +188,7 @@ func init() {
 		&disasmCommand,
 		&runCommand,
 		&blockTestCommand,
+		&statelessCommand,
 		&stateTestCommand,
 		&stateTransitionCommand,
 	}
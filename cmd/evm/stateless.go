@@ -0,0 +1,116 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/protocol/rules/ethash"
+	"github.com/erigontech/erigon/execution/protocol/rules/merge"
+	"github.com/erigontech/erigon/execution/rlp"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+var statelessChainFlag = cli.StringFlag{
+	Name:  "chain",
+	Usage: "name of the chain the block belongs to, unless the input has a \"config\"",
+	Value: "mainnet",
+}
+
+var statelessCommand = cli.Command{
+	Action:    statelessCmd,
+	Name:      "stateless",
+	Usage:     "verifies a block statelessly against its execution witness",
+	ArgsUsage: "<file>",
+	Description: `The file holds a JSON object with the RLP encoded block ("block", as returned by
+debug_getRawBlock), its witness ("witness", as returned by debug_executionWitness) and
+optionally the chain config ("config"). The block is executed against the pre-state in the
+witness, without a database, and the resulting state root is checked against the block's.`,
+	Flags: []cli.Flag{
+		&statelessChainFlag,
+		&VerbosityFlag,
+	},
+}
+
+type statelessInput struct {
+	Block   hexutil.Bytes                `json:"block"`
+	Witness *stagedsync.ExecutionWitness `json:"witness"`
+	Config  *chain.Config                `json:"config,omitempty"`
+}
+
+func statelessCmd(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if len(path) == 0 {
+		return errors.New("path argument required")
+	}
+
+	if ctx.Int(VerbosityFlag.Name) > 0 {
+		log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(ctx.Int(VerbosityFlag.Name)), log.StderrHandler))
+	} else {
+		log.Root().SetHandler(log.LvlFilterHandler(log.LvlError, log.StderrHandler))
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var input statelessInput
+	if err := json.Unmarshal(src, &input); err != nil {
+		return fmt.Errorf("failed unmarshaling %s: %w", path, err)
+	}
+	if input.Witness == nil {
+		return errors.New("input has no witness")
+	}
+
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(input.Block, block); err != nil {
+		return fmt.Errorf("failed decoding block: %w", err)
+	}
+
+	chainConfig := input.Config
+	if chainConfig == nil {
+		spec, err := chainspec.ChainSpecByName(ctx.String(statelessChainFlag.Name))
+		if err != nil {
+			return err
+		}
+		chainConfig = spec.Config
+	}
+
+	// Merge engine can be used for pre-merge blocks as well, as it
+	// redirects to the ethash engine based on the block number
+	engine := merge.New(ethash.NewFaker())
+
+	root, err := stagedsync.ExecuteBlockWithExecutionWitness(chainConfig, engine, block, input.Witness, log.Root())
+	if err != nil {
+		return fmt.Errorf("block %d: %w", block.NumberU64(), err)
+	}
+	if root != block.Root() {
+		return fmt.Errorf("block %d: state root mismatch: got %x, expected %x", block.NumberU64(), root, block.Root())
+	}
+	fmt.Printf("block %d (%x) verified, state root %x\n", block.NumberU64(), block.Hash(), root)
+	return nil
+}
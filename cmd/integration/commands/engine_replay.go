@@ -0,0 +1,91 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/execution/engineapi"
+	"github.com/erigontech/erigon/execution/engineapi/engine_recorder"
+	"github.com/erigontech/erigon/node/debug"
+)
+
+var replayKeepTiming bool
+
+func init() {
+	withDataDir(cmdEngineReplay)
+	withFile(cmdEngineReplay)
+
+	cmdEngineReplay.Flags().StringVar(&engineEndpoint, "engineendpoint", "", "engine API endpoint")
+	must(cmdEngineReplay.MarkFlagRequired("engineendpoint"))
+	cmdEngineReplay.Flags().BoolVar(&replayKeepTiming, "timing", false, "wait between calls as long as CL did during recording")
+
+	rootCmd.AddCommand(cmdEngineReplay)
+}
+
+var cmdEngineReplay = &cobra.Command{
+	Use:   "engine_replay",
+	Short: "Replay Engine API calls recorded by --authrpc.record and compare responses",
+	Long: `Sends Engine API calls recorded by 'erigon --authrpc.record=<file>' to Erigon at --engineendpoint,
+one by one in recorded order, and reports responses which differ from recorded ones.
+
+Start Erigon with --externalcl on a fresh datadir of the same chain; jwt secret is read from --datadir.
+`,
+	Example: "integration engine_replay --datadir=<fresh_datadir> --engineendpoint=http://localhost:8551 --file=engine.rec.gz",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+		dirs := datadir.New(datadirCli)
+
+		jwtSecret, err := cli.ObtainJWTSecret(&httpcfg.HttpCfg{JWTSecretPath: filepath.Join(dirs.DataDir, "jwt.hex")}, logger)
+		if err != nil {
+			return fmt.Errorf("reading jwt secret: %w", err)
+		}
+		client, err := engineapi.DialJsonRpcClient(engineEndpoint, jwtSecret, logger)
+		if err != nil {
+			return fmt.Errorf("connecting to engine API endpoint: %w", err)
+		}
+		reader, err := engine_recorder.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		res, err := engine_recorder.Replay(ctx, reader, client, replayKeepTiming, logger)
+		for _, m := range res.Mismatches {
+			fmt.Printf("call %d %s:\n\texpected: %s\n\tactual:   %s\n", m.Index, m.Method, m.Expected, m.Actual)
+		}
+		if res.Truncated {
+			logger.Warn("recording ends with partially written call: skipped it")
+		}
+		logger.Info("Replay done", "calls", res.Calls, "mismatches", len(res.Mismatches))
+		if err != nil {
+			return err
+		}
+		if len(res.Mismatches) > 0 {
+			return fmt.Errorf("%d of %d responses differ from recorded", len(res.Mismatches), res.Calls)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/dbcfg"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/node/debug"
+	"github.com/erigontech/erigon/node/shards"
+)
+
+var (
+	execDiffFrom   uint64
+	execDiffTo     uint64
+	dumpVersionMap bool
+)
+
+func init() {
+	withConfig(cmdExecDiff)
+	withDataDir(cmdExecDiff)
+	withChain(cmdExecDiff)
+	withHeimdall(cmdExecDiff)
+	withBatchSize(cmdExecDiff)
+	withWorkers(cmdExecDiff)
+	cmdExecDiff.Flags().Uint64Var(&execDiffFrom, "from", 0, "block from which to execute, the block after the executed state if 0")
+	cmdExecDiff.Flags().Uint64Var(&execDiffTo, "to", 0, "block up to which to execute, --from if 0")
+	cmdExecDiff.Flags().BoolVar(&dumpVersionMap, "dump_versionmap", false, "dump the parallel execution's version map entries of the diverging accounts")
+	rootCmd.AddCommand(cmdExecDiff)
+}
+
+var cmdExecDiff = &cobra.Command{
+	Use:     "exec_diff",
+	Short:   "Executes a range of blocks with the serial and the parallel executor, without committing, and reports the first transaction they differ on",
+	Example: "go run ./cmd/integration exec_diff --datadir=... --chain=... --from=100 --to=200 --exec.workers=8",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		db, err := openDB(dbCfg(dbcfg.ChainDB, chaindata), true, chain, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+
+		defer func(t time.Time) { logger.Info("total", "took", time.Since(t)) }(time.Now())
+
+		if err := execDiff(db, cmd.Context(), logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func execDiff(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error {
+	dirs := datadir.New(datadirCli)
+	_, engine, vmConfig, sync := newSync(ctx, db, nil /* miningConfig */, logger)
+	must(sync.SetCurrentStage(stages.Execution))
+
+	var batchSize datasize.ByteSize
+	must(batchSize.UnmarshalText([]byte(batchSizeStr)))
+
+	s := stage(sync, nil, db, stages.Execution)
+	from, to := execDiffFrom, execDiffTo
+	if from == 0 {
+		from = s.BlockNumber + 1
+	}
+	if to == 0 {
+		to = from
+	}
+	logger.Info("Executing serial and parallel", "from", from, "to", to, "workers", syncCfg.ExecWorkerCount)
+
+	chainConfig, pm := fromdb.ChainConfig(db), fromdb.PruneMode(db)
+	genesis := readGenesis(chain)
+	br, _ := blocksIO(db, logger)
+	cfg := stagedsync.StageExecuteBlocksCfg(db, pm, batchSize, chainConfig, engine, vmConfig, shards.NewNotifications(nil),
+		/*stateStream=*/ false,
+		/*badBlockHalt=*/ true,
+		dirs, br, nil, genesis, syncCfg, nil /*experimentalBAL=*/, false)
+
+	divergence, err := stagedsync.ExecDiff(ctx, db, sync, cfg, syncCfg.ExecWorkerCount, from, to, dumpVersionMap, logger)
+	if err != nil {
+		return err
+	}
+	if divergence == nil {
+		fmt.Printf("serial and parallel execution of blocks %d-%d agree\n", from, to)
+		return nil
+	}
+	divergence.Dump(os.Stdout, dumpVersionMap)
+	return nil
+}
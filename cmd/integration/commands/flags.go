@@ -43,6 +43,7 @@ var (
 	txtrace                      bool   // Whether to trace the execution (should only be used together with `block`)
 	chain                        string // Which chain to use (mainnet, sepolia, etc.)
 	outputCsvFile                string
+	otsEventsFile                string
 
 	startTxNum uint64
 
@@ -162,6 +163,10 @@ func withTxTrace(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&txtrace, "txtrace", false, "enable tracing of transactions")
 }
 
+func withOtsEvents(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&otsEventsFile, utils.OtsV2EventsFlag.Name, "", utils.OtsV2EventsFlag.Usage)
+}
+
 func withChain(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&chain, "chain", "", "pick a chain to assume (mainnet, sepolia, etc.)")
 	must(cmd.MarkFlagRequired("chain"))
@@ -0,0 +1,165 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/dbcfg"
+	"github.com/erigontech/erigon/execution/exec"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/tracing/tracers/native"
+	"github.com/erigontech/erigon/node/debug"
+)
+
+var (
+	profileGasFrom  uint64
+	profileGasTo    uint64
+	profileGasTop   int
+	profileGasPprof string
+)
+
+func init() {
+	withConfig(cmdProfileGas)
+	withDataDir(cmdProfileGas)
+	withChain(cmdProfileGas)
+	withHeimdall(cmdProfileGas)
+	withWorkers(cmdProfileGas)
+	cmdProfileGas.Flags().Uint64Var(&profileGasFrom, "from", 0, "first block to profile, --to if 0")
+	cmdProfileGas.Flags().Uint64Var(&profileGasTo, "to", 0, "last block to profile, the last executed block if 0")
+	cmdProfileGas.Flags().IntVar(&profileGasTop, "top", 20, "entries of each list of the report, all if 0")
+	cmdProfileGas.Flags().StringVar(&profileGasPprof, "pprof", "", "file to write the gas of the call stacks to, in the pprof format")
+	rootCmd.AddCommand(cmdProfileGas)
+}
+
+var cmdProfileGas = &cobra.Command{
+	Use:     "profile_gas",
+	Short:   "Re-executes a range of blocks and reports the contracts, selectors, opcodes and precompiles which used the most gas",
+	Example: "go run ./cmd/integration profile_gas --datadir=... --chain=... --from=100 --to=200 --pprof=gas.pb.gz",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		db, err := openDB(dbCfg(dbcfg.ChainDB, chaindata), true, chain, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+
+		defer func(t time.Time) { logger.Info("total", "took", time.Since(t)) }(time.Now())
+
+		if err := profileGas(db, cmd.Context(), logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func profileGas(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error {
+	dirs := datadir.New(datadirCli)
+	_, engine, _, _ := newSync(ctx, db, nil /* miningConfig */, logger)
+	br, _ := blocksIO(db, logger)
+
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	from, to := profileGasFrom, profileGasTo
+	if to == 0 {
+		if to, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+			return err
+		}
+	}
+	if from == 0 {
+		from = to
+	}
+	logger.Info("Profiling gas", "from", from, "to", to, "workers", syncCfg.ExecWorkerCount)
+
+	profile, err := exec.ProfileGas(ctx, from, to, tx, exec.ExecArgs{
+		ChainDB:     db,
+		Genesis:     readGenesis(chain),
+		BlockReader: br,
+		Engine:      engine,
+		Dirs:        dirs,
+		ChainConfig: fromdb.ChainConfig(db),
+		Workers:     syncCfg.ExecWorkerCount,
+	}, logger)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("blocks %d-%d\n", from, to)
+	if err := printGasProfile(os.Stdout, profile.Top(profileGasTop)); err != nil {
+		return err
+	}
+	if profileGasPprof == "" {
+		return nil
+	}
+	f, err := os.Create(profileGasPprof)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := profile.WritePprof(f); err != nil {
+		return err
+	}
+	fmt.Printf("call stacks written to %s, see `go tool pprof -http=: %s`\n", profileGasPprof, profileGasPprof)
+	return nil
+}
+
+func printGasProfile(out io.Writer, r *native.GasProfileReport) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "txns\t%d\ngas\t%d\n", r.Txs, r.Gas)
+	share := func(gas uint64) string {
+		if r.Gas == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", 100*float64(gas)/float64(r.Gas))
+	}
+	list := func(title string, entries []*native.GasProfileEntry, name func(*native.GasProfileEntry) string) {
+		fmt.Fprintf(w, "\n%s\tgas\tshare\tcount\n", title)
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d\n", name(e), e.Gas, share(e.Gas), e.Count)
+		}
+	}
+	address := func(e *native.GasProfileEntry) string { return e.Contract.Hex() }
+	list("contract", r.Contracts, address)
+	list("selector", r.Selectors, func(e *native.GasProfileEntry) string {
+		if e.Selector == "" {
+			return e.Contract.Hex()
+		}
+		return e.Contract.Hex() + ":" + e.Selector
+	})
+	list("opcode", r.Opcodes, func(e *native.GasProfileEntry) string { return e.Opcode })
+	list("precompile", r.Precompiles, address)
+	return w.Flush()
+}
@@ -82,6 +82,7 @@ import (
 	"github.com/erigontech/erigon/node/nodecfg"
 	"github.com/erigontech/erigon/node/rulesconfig"
 	"github.com/erigontech/erigon/node/shards"
+	"github.com/erigontech/erigon/ots/events"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/p2p/sentry/sentry_multi_client"
@@ -206,6 +207,9 @@ var cmdStageCustomTrace = &cobra.Command{
 	Short: "",
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := debug.SetupCobra(cmd, "integration")
+		if len(stagedsync.NewProduce(strings.Split(domain, ",")).CustomTraces) > 0 {
+			statecfg.EnableCustomTraces() // before the aggregator is opened, on the first run the db isn't marked yet
+		}
 		db, err := openDB(dbCfg(dbcfg.ChainDB, chaindata), true, chain, logger)
 		if err != nil {
 			logger.Error("Opening DB", "error", err)
@@ -487,6 +491,20 @@ var cmdResetOtsWithdrawals = &cobra.Command{
 	Run:   runResetStage(stages.OtsWithdrawals, "", nil),
 }
 
+var cmdResetOtsEvent = &cobra.Command{
+	Use:   "reset_ots_event <name>",
+	Short: "Reset a registered event indexer",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		def, err := otsEvent(args[0])
+		if err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+		runResetStage(def.StageID(), "", nil)(cmd, args)
+	},
+}
+
 func runUnwindStage(stg stages.SyncStage, mainBucket, counterBucket string, attrs *roaring64.Bitmap) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
@@ -659,6 +677,29 @@ var cmdUnwindOtsWithdrawals = &cobra.Command{
 	),
 }
 
+var cmdUnwindOtsEvent = &cobra.Command{
+	Use:   "unwind_ots_event <name>",
+	Short: "Unwind a registered event indexer",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		def, err := otsEvent(args[0])
+		if err != nil {
+			log.Error("Error", "err", err)
+			return
+		}
+		runUnwindLogStage(def.StageID(), stagedsync.NewEventIndexerUnwinder(def))(cmd, args)
+	},
+}
+
+// otsEvent registers the event indexers like a node with --experimental.ots2
+// does, and returns the one with the given name.
+func otsEvent(name string) (*events.Definition, error) {
+	if err := events.Enable(otsEventsFile); err != nil {
+		return nil, err
+	}
+	return events.Get(name)
+}
+
 var cmdPrintStages = &cobra.Command{
 	Use:   "print_stages",
 	Short: "",
@@ -714,15 +755,30 @@ var cmdPrintTableSizes = &cobra.Command{
 			return
 		}
 
+		var retention statecfg.Retentions
+		if err := db.View(cmd.Context(), func(tx kv.Tx) (err error) {
+			retention, err = statecfg.ReadRetentions(tx)
+			return err
+		}); err != nil {
+			logger.Error("error while reading retention", "err", err)
+			return
+		}
+
 		var sb strings.Builder
 		sb.WriteString("Table")
 		sb.WriteRune(',')
 		sb.WriteString("Size")
+		sb.WriteRune(',')
+		sb.WriteString("Retention")
 		sb.WriteRune('\n')
 		for _, t := range tableSizes {
 			sb.WriteString(t.Name)
 			sb.WriteRune(',')
 			sb.WriteString(common.ByteCount(t.Size))
+			sb.WriteRune(',')
+			if r, ok := retention.OfTable(t.Name); ok {
+				sb.WriteString(r.String())
+			}
 			sb.WriteRune('\n')
 		}
 
@@ -1026,6 +1082,10 @@ func init() {
 	withDataDir(cmdResetOtsWithdrawals)
 	rootCmd.AddCommand(cmdResetOtsWithdrawals)
 
+	withDataDir(cmdResetOtsEvent)
+	withOtsEvents(cmdResetOtsEvent)
+	rootCmd.AddCommand(cmdResetOtsEvent)
+
 	withDataDir(cmdUnwindOtsAllContracts)
 	withChain(cmdUnwindOtsAllContracts)
 	withUnwind(cmdUnwindOtsAllContracts)
@@ -1081,6 +1141,12 @@ func init() {
 	withUnwind(cmdUnwindOtsWithdrawals)
 	rootCmd.AddCommand(cmdUnwindOtsWithdrawals)
 
+	withDataDir(cmdUnwindOtsEvent)
+	withChain(cmdUnwindOtsEvent)
+	withUnwind(cmdUnwindOtsEvent)
+	withOtsEvents(cmdUnwindOtsEvent)
+	rootCmd.AddCommand(cmdUnwindOtsEvent)
+
 	withDataDir(cmdPrintMigrations)
 	rootCmd.AddCommand(cmdPrintMigrations)
 
@@ -33,6 +33,8 @@ type HttpCfg struct {
 	WithDatadir              bool // Erigon's database can be read by separated processes on same machine - in read-only mode - with full support of transactions. It will share same "OS PageCache" with Erigon process.
 	DataDir                  string
 	Dirs                     datadir.Dirs
+	SnapshotOnly             bool   // serve RPC from snapshot files only: without Erigon, chaindata and execution
+	Chain                    string // used by SnapshotOnly: there is no chaindata to read chain config from
 	AuthRpcHTTPListenAddress string
 	TLSCertfile              string
 	TLSCACert                string
@@ -104,6 +106,7 @@ type HttpCfg struct {
 	MaxGetProofRewindBlockCount int  //Max GetProof rewind block count
 	// Ots API
 	OtsMaxPageSize uint64
+	OtsEventsFile  string // extra event indexers of ots2 API, see events.LoadFile
 
 	RPCSlowLogThreshold time.Duration
 
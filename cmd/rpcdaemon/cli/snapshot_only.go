@@ -0,0 +1,204 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcservices"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/dbcfg"
+	"github.com/erigontech/erigon/db/kv/kvcache"
+	kv2 "github.com/erigontech/erigon/db/kv/mdbx"
+	"github.com/erigontech/erigon/db/kv/temporal"
+	"github.com/erigontech/erigon/db/rawdb"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/db/snapshotsync/freezeblocks"
+	dbstate "github.com/erigontech/erigon/db/state"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/execution/protocol/rules/aura"
+	"github.com/erigontech/erigon/execution/protocol/rules/ethash"
+	"github.com/erigontech/erigon/execution/protocol/rules/merge"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// snapshotOnlyDisabledAPIs - namespaces which need running Erigon (txpool, p2p)
+var snapshotOnlyDisabledAPIs = []string{"txpool", "admin"}
+
+// snapshotOnlyServices - services of rpcdaemon which serves RPC from snapshot files only: without Erigon and without chaindata.
+// Chain config is taken from `--chain`. Chaindata is replaced by in-memory db, which has only chain config and head markers.
+// Head is the last block which is fully covered by both block files and state files - newer blocks are not served.
+func snapshotOnlyServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger) (
+	db kv.TemporalRoDB, eth rpchelper.ApiBackend, stateCache kvcache.Cache, blockReader services.FullBlockReader,
+	engine rules.EngineReader, ff *rpchelper.Filters, err error) {
+	if !cfg.WithDatadir {
+		return nil, nil, nil, nil, nil, nil, errors.New("--snapshot-only requires --datadir")
+	}
+	spec, err := chainspec.ChainSpecByName(cfg.Chain)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, fmt.Errorf("--snapshot-only requires --chain: %w", err)
+	}
+	cc := spec.Config
+	if cc.Bor != nil {
+		return nil, nil, nil, nil, nil, nil, fmt.Errorf("--snapshot-only is not supported for chain %s", cc.ChainName)
+	}
+	ok, err := dbstate.CheckSaltFilesExist(cfg.Dirs)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+	if !ok {
+		return nil, nil, nil, nil, nil, nil, dbstate.ErrCannotStartWithoutSaltFiles
+	}
+	if err := dbstate.CheckSnapshotsCompatibility(cfg.Dirs); err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	cfg.Snap.ChainName = cc.ChainName
+	allSnapshots := freezeblocks.NewRoSnapshots(cfg.Snap, cfg.Dirs.Snap, logger)
+	allBorSnapshots := heimdall.NewRoSnapshots(cfg.Snap, cfg.Dirs.Snap, logger)
+	allSnapshots.DownloadComplete()
+	allBorSnapshots.DownloadComplete()
+	if err := allSnapshots.OpenFolder(); err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+	allSnapshots.LogStat("snapshot-only")
+	br := freezeblocks.NewBlockReader(allSnapshots, allBorSnapshots)
+	if br.FrozenBlocks() == 0 {
+		return nil, nil, nil, nil, nil, nil, fmt.Errorf("no block snapshot files in %s", cfg.Dirs.Snap)
+	}
+
+	rawDB, err := kv2.New(dbcfg.ChainDB, logger).InMem(nil, cfg.Dirs.Tmp).Open(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+	agg, err := dbstate.New(cfg.Dirs).Logger(logger).StepSize(cfg.ErigonDBStepSize).StepsInFrozenFile(cfg.ErigonDBStepsInFrozenFile).Open(ctx, rawDB)
+	if err != nil {
+		rawDB.Close()
+		return nil, nil, nil, nil, nil, nil, fmt.Errorf("create aggregator: %w", err)
+	}
+	if err := agg.OpenFolder(); err != nil {
+		agg.Close()
+		rawDB.Close()
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	head, err := initSnapshotOnlyChainDB(ctx, rawDB, br, agg, spec)
+	if err != nil {
+		agg.Close()
+		rawDB.Close()
+		return nil, nil, nil, nil, nil, nil, err
+	}
+	logger.Info("[rpc] snapshot-only mode", "chain", cc.ChainName, "head", head, "frozenBlocks", br.FrozenBlocks(), "stateTxNum", agg.EndTxNumMinimax())
+
+	if db, err = temporal.New(rawDB, agg); err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	if cc.Aura != nil {
+		consensusDB, err := kv2.New(dbcfg.ConsensusDB, logger).InMem(nil, cfg.Dirs.Tmp).Open(ctx)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, err
+		}
+		if engine, err = aura.NewAuRa(cc.Aura, consensusDB); err != nil {
+			return nil, nil, nil, nil, nil, nil, err
+		}
+	} else {
+		engine = ethash.NewFaker()
+	}
+	if cc.TerminalTotalDifficulty != nil {
+		engine = merge.New(engine.(rules.Engine)) // the Merge
+	}
+
+	cfg.API = slices.DeleteFunc(cfg.API, func(api string) bool {
+		if slices.Contains(snapshotOnlyDisabledAPIs, api) {
+			logger.Warn("[rpc] api is not available in snapshot-only mode", "api", api)
+			return true
+		}
+		return false
+	})
+
+	eth = rpcservices.NewSnapshotOnlyBackend(cc.ChainID.Uint64(), head, br)
+	ff = rpchelper.New(ctx, cfg.RpcFiltersConfig, eth, nil, nil, func() {}, logger)
+	return db, eth, kvcache.NewDummy(), br, engine, ff, nil
+}
+
+// initSnapshotOnlyChainDB - writes into empty chaindata: chain config, and head/safe/finalized markers pointing to the last block covered by files.
+// Files of state domains end at step boundary (not at block boundary): latest state in files may include part of block `head+1`.
+// To serve `latest` consistently - Execution progress is set to `head+1`: then state of `head` is read from history (as of end of block), not from latest domain values.
+func initSnapshotOnlyChainDB(ctx context.Context, rawDB kv.RwDB, br *freezeblocks.BlockReader, agg *dbstate.Aggregator, spec chainspec.Spec) (head uint64, err error) {
+	err = rawDB.Update(ctx, func(tx kv.RwTx) error {
+		genesisHash, ok, err := br.CanonicalHash(ctx, tx, 0)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("genesis block not found in snapshot files")
+		}
+		if genesisHash != spec.GenesisHash {
+			return fmt.Errorf("snapshot files genesis %x doesn't match chain %s genesis %x", genesisHash, spec.Config.ChainName, spec.GenesisHash)
+		}
+
+		stateEnd := agg.EndTxNumMinimax()
+		if stateEnd == 0 {
+			return errors.New("no state snapshot files")
+		}
+		head = br.FrozenBlocks() // last block in files
+		blockNum, ok, err := br.TxnumReader(ctx).FindBlockNum(tx, stateEnd)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if blockNum == 0 {
+				return errors.New("state snapshot files don't cover any block")
+			}
+			head = min(head, blockNum-1)
+		}
+		headHash, ok, err := br.CanonicalHash(ctx, tx, head)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("block %d not found in snapshot files", head)
+		}
+
+		if err := rawdb.WriteCanonicalHash(tx, genesisHash, 0); err != nil {
+			return err
+		}
+		if err := rawdb.WriteChainConfig(tx, genesisHash, spec.Config); err != nil {
+			return err
+		}
+		if err := rawdb.WriteCanonicalHash(tx, headHash, head); err != nil {
+			return err
+		}
+		if err := rawdb.WriteHeaderNumber(tx, headHash, head); err != nil {
+			return err
+		}
+		rawdb.WriteForkchoiceHead(tx, headHash)
+		rawdb.WriteForkchoiceSafe(tx, headHash)
+		rawdb.WriteForkchoiceFinalized(tx, headHash)
+		return stages.SaveStageProgress(tx, stages.Execution, head+1)
+	})
+	return head, err
+}
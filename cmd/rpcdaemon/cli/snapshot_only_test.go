@@ -0,0 +1,186 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcservices"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/config3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon/db/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/db/snaptype"
+	"github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/db/state/execctx"
+	"github.com/erigontech/erigon/execution/chain"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/tests/blockgen"
+	"github.com/erigontech/erigon/execution/tests/mock"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/jsonrpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// buildSnapshotOnlyDatadir - produces chain of `blocks` transfers and datadir which has only files of it:
+// block files are dumped from the chain (blocks [0, blocks)), state files have accounts as of end of the last dumped block.
+func buildSnapshotOnlyDatadir(t *testing.T, blocks int, stepSize uint64) (dirs datadir.Dirs, m *mock.MockSentry, gspec *types.Genesis, accs []common.Address) {
+	t.Helper()
+	ctx := context.Background()
+	logger := log.New()
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient, coinbase := common.HexToAddress("0xdeadbeef"), common.Address{1}
+	gspec = &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(common.Ether)}},
+	}
+	signer := types.LatestSigner(gspec.Config)
+	m = mock.MockWithGenesis(t, gspec, key, false)
+	chainPack, err := blockgen.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, blocks, func(i int, b *blockgen.BlockGen) {
+		b.SetCoinbase(coinbase)
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(sender), recipient, uint256.NewInt(100), 21000, uint256.NewInt(common.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+
+	dirs = datadir.New(t.TempDir())
+	_, err = snaptype.LoadSalt(dirs.Snap, true, logger)
+	require.NoError(t, err)
+	require.NoError(t, freezeblocks.DumpBlocks(ctx, 0, uint64(blocks), m.ChainConfig, dirs.Tmp, dirs.Snap, m.DB, 1, log.LvlDebug, logger, m.BlockReader))
+
+	accs = []common.Address{sender, recipient, coinbase}
+	srcTx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer srcTx.Rollback()
+	lastTxNum, err := m.BlockReader.TxnumReader(ctx).Max(srcTx, uint64(blocks-1))
+	require.NoError(t, err)
+
+	db := temporaltest.NewTestDBWithStepSize(t, dirs, stepSize)
+	agg := db.(state.HasAgg).Agg().(*state.Aggregator)
+	rwTx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+	domains, err := execctx.NewSharedDomains(rwTx, logger)
+	require.NoError(t, err)
+	defer domains.Close()
+	for _, addr := range accs {
+		v, _, err := srcTx.GetAsOf(kv.AccountsDomain, addr[:], lastTxNum+1)
+		require.NoError(t, err)
+		require.NotEmpty(t, v)
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, addr[:], v, lastTxNum, nil, 0))
+	}
+	// files are built up to the last step of state domains: state files must end after the last block
+	filler := common.HexToAddress("0xff")
+	endTxNum := (lastTxNum/stepSize + 2) * stepSize
+	for txNum := lastTxNum + 1; txNum < endTxNum+stepSize; txNum++ {
+		acc := accounts.Account{Nonce: txNum}
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, filler[:], accounts.SerialiseV3(&acc), txNum, nil, 0))
+	}
+	require.NoError(t, domains.Flush(ctx, rwTx))
+	require.NoError(t, rwTx.Commit())
+	require.NoError(t, agg.BuildFiles(endTxNum))
+	require.Greater(t, agg.EndTxNumMinimax(), lastTxNum)
+	db.Close()
+	return dirs, m, gspec, accs
+}
+
+func TestSnapshotOnlyServices(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := context.Background()
+	logger := log.New()
+	stepSize := uint64(16)
+	dirs, m, gspec, accs := buildSnapshotOnlyDatadir(t, 1000, stepSize)
+	chainName := "snapshot-only-test"
+	chainspec.RegisterChainSpec(chainName, chainspec.Spec{Name: chainName, GenesisHash: m.Genesis.Hash(), Genesis: gspec, Config: m.ChainConfig})
+
+	cfg := &httpcfg.HttpCfg{
+		Dirs:                      dirs,
+		WithDatadir:               true,
+		SnapshotOnly:              true,
+		Chain:                     chainName,
+		API:                       []string{"eth", "txpool"},
+		ErigonDBStepSize:          stepSize,
+		ErigonDBStepsInFrozenFile: config3.DefaultStepsInFrozenFile,
+		RpcFiltersConfig:          rpchelper.DefaultFiltersConfig,
+	}
+	db, eth, txPool, mining, stateCache, blockReader, engine, ff, _, _, err := RemoteServices(ctx, cfg, logger, func() {})
+	require.NoError(t, err)
+	defer db.Close()
+	require.Equal(t, []string{"eth"}, cfg.API)
+
+	base := jsonrpc.NewBaseApi(ff, stateCache, blockReader, false, time.Minute, engine, dirs, nil)
+	api := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, 50_000_000, 1, 100_000, false, 100_000, 128, logger)
+
+	head, err := api.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(999), uint64(head))
+
+	block, err := api.GetBlockByNumber(ctx, rpc.LatestBlockNumber, false)
+	require.NoError(t, err)
+	require.NotNil(t, block)
+	srcTx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer srcTx.Rollback()
+	expected, err := m.BlockReader.BlockByNumber(ctx, srcTx, 999)
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), block["hash"])
+
+	headTxNum, err := m.BlockReader.TxnumReader(ctx).Max(srcTx, 999)
+	require.NoError(t, err)
+	for _, addr := range accs {
+		v, _, err := srcTx.GetAsOf(kv.AccountsDomain, addr[:], headTxNum+1)
+		require.NoError(t, err)
+		var acc accounts.Account
+		require.NoError(t, accounts.DeserialiseV3(&acc, v))
+		balance, err := api.GetBalance(ctx, addr, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+		require.NoError(t, err)
+		require.Equal(t, acc.Balance.ToBig(), balance.ToInt(), addr)
+	}
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	txn, err := types.SignTx(types.NewTransaction(1000, accs[1], uint256.NewInt(100), 21000, uint256.NewInt(common.GWei), nil), *types.LatestSigner(m.ChainConfig), key)
+	require.NoError(t, err)
+	var rlpTxn bytes.Buffer
+	require.NoError(t, txn.MarshalBinary(&rlpTxn))
+	_, err = api.SendRawTransaction(ctx, rlpTxn.Bytes())
+	require.ErrorIs(t, err, rpcservices.ErrSnapshotOnly)
+	_, err = api.SubmitWork(ctx, types.BlockNonce{}, common.Hash{}, common.Hash{})
+	require.ErrorIs(t, err, rpcservices.ErrSnapshotOnly)
+
+	pending, err := api.GetTransactionByHash(ctx, txn.Hash())
+	require.NoError(t, err)
+	require.Nil(t, pending)
+}
@@ -21,12 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 
 	"github.com/erigontech/erigon/cmd/rpcdaemon/cli"
 	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/node/debug"
+	"github.com/erigontech/erigon/ots/events"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/jsonrpc"
 
@@ -40,6 +42,13 @@ func main() {
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		logger := debug.SetupCobra(cmd, "rpcdaemon")
+		if slices.Contains(cfg.API, "ots2") {
+			// the event indexers add their tables to the chaindata schema: must be registered before the db is opened
+			if err := events.Enable(cfg.OtsEventsFile); err != nil {
+				logger.Error("Could not enable ots2 event indexers", "err", err)
+				return nil
+			}
+		}
 		db, backend, txPool, mining, stateCache, blockReader, engine, ff, bridgeReader, heimdallReader, err := cli.RemoteServices(ctx, cfg, logger, rootCancel)
 		if err != nil {
 			if !errors.Is(err, context.Canceled) {
@@ -0,0 +1,99 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpcservices
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/db/version"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/node/direct"
+	remote "github.com/erigontech/erigon/node/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon/p2p"
+)
+
+var ErrSnapshotOnly = errors.New("not available in snapshot-only mode")
+
+// SnapshotOnlyBackend - ApiBackend of rpcdaemon running without Erigon (--snapshot-only):
+// no p2p, no txpool, no new blocks. Chain ends at `head` - last block fully covered by snapshot files.
+type SnapshotOnlyBackend struct {
+	chainID     uint64
+	head        uint64
+	blockReader services.FullBlockReader
+}
+
+func NewSnapshotOnlyBackend(chainID, head uint64, blockReader services.FullBlockReader) *SnapshotOnlyBackend {
+	return &SnapshotOnlyBackend{chainID: chainID, head: head, blockReader: blockReader}
+}
+
+func (back *SnapshotOnlyBackend) Syncing(_ context.Context) (*remote.SyncingReply, error) {
+	return &remote.SyncingReply{
+		CurrentBlock:     back.head,
+		FrozenBlocks:     back.blockReader.FrozenBlocks(),
+		LastNewBlockSeen: back.head,
+		Syncing:          false,
+	}, nil
+}
+
+func (back *SnapshotOnlyBackend) Etherbase(_ context.Context) (common.Address, error) {
+	return common.Address{}, ErrSnapshotOnly
+}
+func (back *SnapshotOnlyBackend) NetVersion(_ context.Context) (uint64, error) {
+	return back.chainID, nil
+}
+func (back *SnapshotOnlyBackend) NetPeerCount(_ context.Context) (uint64, error) { return 0, nil }
+func (back *SnapshotOnlyBackend) ProtocolVersion(_ context.Context) (uint64, error) {
+	return direct.ETH68, nil
+}
+func (back *SnapshotOnlyBackend) ClientVersion(_ context.Context) (string, error) {
+	return common.MakeName("erigon", version.VersionNoMeta), nil
+}
+
+// Subscribe - there are no new blocks in snapshot-only mode: just wait for shutdown
+func (back *SnapshotOnlyBackend) Subscribe(ctx context.Context, _ func(*remote.SubscribeReply)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+func (back *SnapshotOnlyBackend) SubscribeLogs(ctx context.Context, _ func(*remote.SubscribeLogsReply), _ *atomic.Value) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (back *SnapshotOnlyBackend) BlockWithSenders(ctx context.Context, tx kv.Getter, hash common.Hash, blockNum uint64) (block *types.Block, senders []common.Address, err error) {
+	return back.blockReader.BlockWithSenders(ctx, tx, hash, blockNum)
+}
+
+func (back *SnapshotOnlyBackend) NodeInfo(_ context.Context, _ uint32) ([]p2p.NodeInfo, error) {
+	return nil, ErrSnapshotOnly
+}
+func (back *SnapshotOnlyBackend) Peers(_ context.Context) ([]*p2p.PeerInfo, error) {
+	return []*p2p.PeerInfo{}, nil
+}
+func (back *SnapshotOnlyBackend) AddPeer(_ context.Context, _ *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (back *SnapshotOnlyBackend) RemovePeer(_ context.Context, _ *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (back *SnapshotOnlyBackend) PendingBlock(_ context.Context) (*types.Block, error) {
+	return nil, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpcservices
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/node/gointerfaces/typesproto"
+)
+
+// SnapshotOnlyTxPool - TxpoolClient of rpcdaemon running without Erigon (--snapshot-only): the pool is always empty,
+// and sending transactions fails with ErrSnapshotOnly.
+type SnapshotOnlyTxPool struct{}
+
+var _ txpoolproto.TxpoolClient = SnapshotOnlyTxPool{}
+
+func (SnapshotOnlyTxPool) Version(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*typesproto.VersionReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) FindUnknown(_ context.Context, in *txpoolproto.TxHashes, _ ...grpc.CallOption) (*txpoolproto.TxHashes, error) {
+	return in, nil
+}
+func (SnapshotOnlyTxPool) Add(_ context.Context, _ *txpoolproto.AddRequest, _ ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) Transactions(_ context.Context, in *txpoolproto.TransactionsRequest, _ ...grpc.CallOption) (*txpoolproto.TransactionsReply, error) {
+	return &txpoolproto.TransactionsReply{RlpTxs: make([][]byte, len(in.Hashes))}, nil
+}
+func (SnapshotOnlyTxPool) All(_ context.Context, _ *txpoolproto.AllRequest, _ ...grpc.CallOption) (*txpoolproto.AllReply, error) {
+	return &txpoolproto.AllReply{}, nil
+}
+func (SnapshotOnlyTxPool) Pending(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*txpoolproto.PendingReply, error) {
+	return &txpoolproto.PendingReply{}, nil
+}
+func (SnapshotOnlyTxPool) OnAdd(_ context.Context, _ *txpoolproto.OnAddRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[txpoolproto.OnAddReply], error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) Status(_ context.Context, _ *txpoolproto.StatusRequest, _ ...grpc.CallOption) (*txpoolproto.StatusReply, error) {
+	return &txpoolproto.StatusReply{}, nil
+}
+func (SnapshotOnlyTxPool) Nonce(_ context.Context, _ *txpoolproto.NonceRequest, _ ...grpc.CallOption) (*txpoolproto.NonceReply, error) {
+	return &txpoolproto.NonceReply{}, nil
+}
+func (SnapshotOnlyTxPool) GetBlobs(_ context.Context, _ *txpoolproto.GetBlobsRequest, _ ...grpc.CallOption) (*txpoolproto.GetBlobsReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) TxnStatus(_ context.Context, _ *txpoolproto.TxnStatusRequest, _ ...grpc.CallOption) (*txpoolproto.TxnStatusReply, error) {
+	return &txpoolproto.TxnStatusReply{}, nil
+}
+func (SnapshotOnlyTxPool) AddConditional(_ context.Context, _ *txpoolproto.AddConditionalRequest, _ ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) AddPrivate(_ context.Context, _ *txpoolproto.AddPrivateRequest, _ ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) Export(_ context.Context, _ *txpoolproto.ExportRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[txpoolproto.ExportedTxn], error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyTxPool) Import(_ context.Context, _ *txpoolproto.ImportRequest, _ ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return nil, ErrSnapshotOnly
+}
+
+// SnapshotOnlyMining - MiningClient of rpcdaemon running without Erigon (--snapshot-only): nothing is mined.
+type SnapshotOnlyMining struct{}
+
+var _ txpoolproto.MiningClient = SnapshotOnlyMining{}
+
+func (SnapshotOnlyMining) Version(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*typesproto.VersionReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) OnPendingBlock(_ context.Context, _ *txpoolproto.OnPendingBlockRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[txpoolproto.OnPendingBlockReply], error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) OnMinedBlock(_ context.Context, _ *txpoolproto.OnMinedBlockRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[txpoolproto.OnMinedBlockReply], error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) OnPendingLogs(_ context.Context, _ *txpoolproto.OnPendingLogsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[txpoolproto.OnPendingLogsReply], error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) GetWork(_ context.Context, _ *txpoolproto.GetWorkRequest, _ ...grpc.CallOption) (*txpoolproto.GetWorkReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) SubmitWork(_ context.Context, _ *txpoolproto.SubmitWorkRequest, _ ...grpc.CallOption) (*txpoolproto.SubmitWorkReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) SubmitHashRate(_ context.Context, _ *txpoolproto.SubmitHashRateRequest, _ ...grpc.CallOption) (*txpoolproto.SubmitHashRateReply, error) {
+	return nil, ErrSnapshotOnly
+}
+func (SnapshotOnlyMining) HashRate(_ context.Context, _ *txpoolproto.HashRateRequest, _ ...grpc.CallOption) (*txpoolproto.HashRateReply, error) {
+	return &txpoolproto.HashRateReply{}, nil
+}
+func (SnapshotOnlyMining) Mining(_ context.Context, _ *txpoolproto.MiningRequest, _ ...grpc.CallOption) (*txpoolproto.MiningReply, error) {
+	return &txpoolproto.MiningReply{}, nil
+}
+func (SnapshotOnlyMining) SendBundle(_ context.Context, _ *txpoolproto.SendBundleRequest, _ ...grpc.CallOption) (*txpoolproto.SendBundleReply, error) {
+	return nil, ErrSnapshotOnly
+}
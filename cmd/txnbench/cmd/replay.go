@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv/kvcache"
+	"github.com/erigontech/erigon/db/kv/remotedb"
+	"github.com/erigontech/erigon/db/kv/remotedbserver"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/grpcutil"
+	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon/txnprovider/txpool"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+var (
+	replayPrivateApiAddr string
+	replaySpeed          float64
+	replayOut            string
+	replayCfg            = txpoolcfg.DefaultConfig
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <journal>",
+	Short: "Replay txpool journal (recorded by erigon --txpool.journal) into a test pool on state of node, and report pool decisions and latency",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("provide exactly one argument: journal")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if replaySpeed < 0 {
+			return errors.New("speed must be non-negative")
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		logger := log.New()
+
+		journal, err := txpool.NewJournalReader(args[0])
+		if err != nil {
+			return err
+		}
+		defer journal.Close()
+
+		coreConn, err := grpcutil.Connect(nil, replayPrivateApiAddr)
+		if err != nil {
+			return fmt.Errorf("could not connect to remoteKv: %w", err)
+		}
+		kvClient := remoteproto.NewKVClient(coreConn)
+		coreDB, err := remotedb.NewRemote(gointerfaces.VersionFromProto(remotedbserver.KvServiceAPIVersion), logger, kvClient).Open()
+		if err != nil {
+			return fmt.Errorf("could not connect to remoteKv: %w", err)
+		}
+		defer coreDB.Close()
+
+		cfg := replayCfg
+		if cfg.DBDir, err = os.MkdirTemp("", "txnbench-replay"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(cfg.DBDir)
+		cacheCfg := kvcache.DefaultCoherentConfig
+		cacheCfg.WaitForNewBlock = false // replayed blocks are not blocks of node
+		// pool is neither connected to sentries nor to state changes of node: only replay feeds it
+		pool, _, err := txpool.Assemble(ctx, cfg, coreDB, kvcache.New(cacheCfg), nil, nil, func() {}, logger, nil)
+		if err != nil {
+			return err
+		}
+
+		report, err := txpool.ReplayJournal(ctx, pool, journal, replaySpeed, logger)
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if replayOut != "" {
+			if out, err = os.Create(replayOut); err != nil {
+				return fmt.Errorf("create %s: %w", replayOut, err)
+			}
+			defer out.Close()
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		if replayOut != "" {
+			fmt.Printf("replay report written to %s\n", replayOut)
+		}
+		return nil
+	},
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&replayPrivateApiAddr, "private.api.addr", "localhost:9090", "private api of erigon node, which state is a starting point of replay")
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 1, "replay speed relative to recorded: 2 - twice faster, 0 - as fast as possible")
+	replayCmd.Flags().StringVar(&replayOut, "out", "", "file to write report to, stdout if empty")
+	replayCmd.Flags().IntVar(&replayCfg.PendingSubPoolLimit, "txpool.globalslots", replayCfg.PendingSubPoolLimit, "Maximum number of executable transaction slots for all accounts")
+	replayCmd.Flags().IntVar(&replayCfg.BaseFeeSubPoolLimit, "txpool.globalbasefeeslots", replayCfg.BaseFeeSubPoolLimit, "Maximum number of non-executable transactions where only not enough baseFee")
+	replayCmd.Flags().IntVar(&replayCfg.QueuedSubPoolLimit, "txpool.globalqueue", replayCfg.QueuedSubPoolLimit, "Maximum number of non-executable transaction slots for all accounts")
+	replayCmd.Flags().Uint64Var(&replayCfg.MinFeeCap, "txpool.pricelimit", replayCfg.MinFeeCap, "Minimum gas price (fee cap) limit to enforce for acceptance into the pool")
+	replayCmd.Flags().Uint64Var(&replayCfg.AccountSlots, "txpool.accountslots", replayCfg.AccountSlots, "Minimum number of executable transaction slots guaranteed per account")
+	replayCmd.Flags().Uint64Var(&replayCfg.PriceBump, "txpool.pricebump", replayCfg.PriceBump, "Price bump percentage to replace an already existing transaction")
+	rootCmd.AddCommand(replayCmd)
+}
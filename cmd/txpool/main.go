@@ -54,6 +54,11 @@ var (
 	txpoolApiAddr  string
 	datadirCli     string // Path to td working dir
 
+	ordering        string
+	prioritySenders []string
+	journal         string
+	replicateTo     string
+
 	TLSCertfile string
 	TLSCACert   string
 	TLSKeyFile  string
@@ -102,6 +107,10 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&ordering, utils.TxPoolOrderingFlag.Name, utils.TxPoolOrderingFlag.Value, utils.TxPoolOrderingFlag.Usage)
+	rootCmd.Flags().StringSliceVar(&prioritySenders, utils.TxPoolPrioritySendersFlag.Name, []string{}, utils.TxPoolPrioritySendersFlag.Usage)
+	rootCmd.Flags().StringVar(&journal, utils.TxPoolJournalFlag.Name, utils.TxPoolJournalFlag.Value, utils.TxPoolJournalFlag.Usage)
+	rootCmd.Flags().StringVar(&replicateTo, utils.TxPoolReplicateToFlag.Name, utils.TxPoolReplicateToFlag.Value, utils.TxPoolReplicateToFlag.Usage)
 }
 
 var rootCmd = &cobra.Command{
@@ -180,6 +189,14 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 		sender := common.HexToAddress(senderHex)
 		cfg.TracedSenders[i] = string(sender[:])
 	}
+	if cfg.Ordering, err = txpoolcfg.ParseOrdering(ordering); err != nil {
+		return err
+	}
+	for _, senderHex := range prioritySenders {
+		cfg.PrioritySenders = append(cfg.PrioritySenders, common.HexToAddress(senderHex))
+	}
+	cfg.JournalPath = journal
+	cfg.ReplicateTo = replicateTo
 
 	notifyMiner := func() {}
 	txPool, txpoolGrpcServer, err := txpool.Assemble(
@@ -197,7 +214,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 		return err
 	}
 
-	miningGrpcServer := privateapi.NewMiningServer(ctx, &rpcdaemontest.IsMiningMock{}, nil, logger)
+	miningGrpcServer := privateapi.NewMiningServer(ctx, &rpcdaemontest.IsMiningMock{}, nil, nil, logger)
 	grpcServer, err := txpool.StartGrpc(txpoolGrpcServer, miningGrpcServer, txpoolApiAddr, nil, logger)
 	if err != nil {
 		return err
@@ -65,6 +65,12 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 				return err
 			}
 		}
+		if err := utils.ResolveChainBundle(context); err != nil {
+			return err
+		}
+		if err := utils.RegisterOtsEventIndexers(context); err != nil {
+			return err
+		}
 
 		// run default action
 		return action(context)
@@ -127,6 +133,9 @@ func appFlags(cliFlags []cli.Flag) []cli.Flag {
 func MigrateFlags(action cli.ActionFunc) cli.ActionFunc {
 	return func(ctx *cli.Context) error {
 		doMigrateFlags(ctx)
+		if err := utils.ResolveChainBundle(ctx); err != nil {
+			return err
+		}
 		return action(ctx)
 	}
 }
@@ -62,6 +62,7 @@ import (
 	"github.com/erigontech/erigon/db/seg"
 	"github.com/erigontech/erigon/db/snapshotsync"
 	"github.com/erigontech/erigon/db/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/db/snapstore"
 	"github.com/erigontech/erigon/db/snaptype"
 	"github.com/erigontech/erigon/db/snaptype2"
 	"github.com/erigontech/erigon/db/state"
@@ -95,7 +96,7 @@ func commonBeforeSnapshotCommand(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return utils.ResolveChainBundle(cliCtx)
 }
 
 func init() {
@@ -295,6 +296,17 @@ var snapshotCommand = cli.Command{
 			},
 			),
 		},
+		&exportCommand,
+		{
+			Name:   "shared-store-gc",
+			Usage:  "Remove files from --snap.shared-store which are not used by any datadir anymore",
+			Action: doSharedStoreGC,
+			Flags: joinFlags([]cli.Flag{
+				&utils.SnapSharedStoreFlag,
+				&utils.SnapSharedStoreModeFlag,
+				&cli.BoolFlag{Name: "dry-run"},
+			}),
+		},
 		{
 			Name:   "diff",
 			Action: doDiff,
@@ -779,6 +791,29 @@ func doRmStateSnapshots(cliCtx *cli.Context) error {
 	return DeleteStateSnapshots(dirs, removeLatest, promptUser, dryRun, stepRange, domainNames...)
 }
 
+func doSharedStoreGC(cliCtx *cli.Context) error {
+	storeDir := cliCtx.Path(utils.SnapSharedStoreFlag.Name)
+	if storeDir == "" {
+		return fmt.Errorf("--%s is required", utils.SnapSharedStoreFlag.Name)
+	}
+	mode, err := snapstore.ParseMode(cliCtx.String(utils.SnapSharedStoreModeFlag.Name))
+	if err != nil {
+		return err
+	}
+	store, err := snapstore.Open(storeDir, mode, log.Root())
+	if err != nil {
+		return err
+	}
+	dryRun := cliCtx.Bool("dry-run")
+	stats, err := store.GC(dryRun)
+	if err != nil {
+		return err
+	}
+	log.Info("[snapstore] gc done", "dryRun", dryRun, "objects", stats.Objects, "removed", stats.Removed,
+		"freed", datasize.ByteSize(stats.RemovedSize).HR(), "staleRefs", stats.StaleRefs)
+	return nil
+}
+
 func doBtSearch(cliCtx *cli.Context) error {
 	_, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
 	if err != nil {
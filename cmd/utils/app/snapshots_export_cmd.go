@@ -0,0 +1,209 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/common/dir"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/export"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/dbcfg"
+	"github.com/erigontech/erigon/db/kv/mdbx"
+	"github.com/erigontech/erigon/db/kv/temporal"
+	"github.com/erigontech/erigon/db/rawdb"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/node/debug"
+	"github.com/erigontech/erigon/node/ethconfig"
+)
+
+var exportCommand = cli.Command{
+	Name:  "export",
+	Usage: "Export state, history, inverted indices, blocks and receipts from snapshot files. Node must not be running",
+	Description: `Datasets (--data, comma-separated):
+	accounts, storage, code, commitment, receipt, rcache - domain state: latest, or as of --asof.block/--asof.txnum
+	accounts_history, storage_history, ...             - per-block changes of domain in [--from, --to] blocks
+	logaddrs, logtopics, tracesfrom, tracesto          - inverted index (key, txNum) pairs in [--from, --to] blocks
+	blocks                                             - blocks and transactions in [--from, --to]
+	receipts                                           - receipts and logs in [--from, --to] (requires --persist.receipts)
+Output schema: db/export/README.md`,
+	Action: doExport,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&utils.ChainFlag,
+		&cli.StringFlag{Name: "format", Value: export.FormatParquet, Usage: "output format. supported: parquet"},
+		&cli.StringFlag{Name: "data", Required: true, Usage: "comma-separated list of datasets"},
+		&cli.PathFlag{Name: "out", Required: true, Usage: "output directory"},
+		&cli.Uint64Flag{Name: "from", Usage: "first block (inclusive)"},
+		&cli.Uint64Flag{Name: "to", Value: math.MaxUint64, Usage: "last block (inclusive). default: last frozen block"},
+		&cli.Uint64Flag{Name: "asof.block", Usage: "export domain state after this block"},
+		&cli.Uint64Flag{Name: "asof.txnum", Usage: "export domain state before this txNum"},
+		&cli.Uint64Flag{Name: "rows-per-file", Value: export.DefaultRowsPerFile},
+		&cli.Uint64Flag{Name: "blocks-per-file", Value: export.DefaultBlocksPerFile},
+	}),
+}
+
+func doExport(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	if f := cliCtx.String("format"); f != export.FormatParquet {
+		return fmt.Errorf("unsupported export format: %s", f)
+	}
+	if cliCtx.IsSet("asof.block") && cliCtx.IsSet("asof.txnum") {
+		return errors.New("--asof.block and --asof.txnum can't be used together")
+	}
+	ctx := cliCtx.Context
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	chainDB, cleanDB, err := openExportChainDB(ctx, dirs, cliCtx.String(utils.ChainFlag.Name), logger)
+	if err != nil {
+		return err
+	}
+	defer cleanDB()
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+	_, _, _, br, agg, _, clean, err := openSnaps(ctx, cfg, dirs, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	blockReader, _ := br.IO()
+	txNumReader := blockReader.TxnumReader(ctx)
+	if blockReader.FrozenBlocks() == 0 {
+		return fmt.Errorf("no block snapshot files in %s", dirs.Snap)
+	}
+	fromBlock, toBlock := cliCtx.Uint64("from"), min(cliCtx.Uint64("to"), blockReader.FrozenBlocks())
+
+	exportCfg := export.Cfg{
+		OutDir:        cliCtx.Path("out"),
+		RowsPerFile:   cliCtx.Uint64("rows-per-file"),
+		BlocksPerFile: cliCtx.Uint64("blocks-per-file"),
+		Logger:        logger,
+	}
+
+	var asOfTxNum *uint64 // latest
+	if cliCtx.IsSet("asof.txnum") {
+		v := cliCtx.Uint64("asof.txnum")
+		asOfTxNum = &v
+	}
+	if cliCtx.IsSet("asof.block") {
+		maxTxNum, err := txNumReader.Max(tx, cliCtx.Uint64("asof.block"))
+		if err != nil {
+			return err
+		}
+		v := maxTxNum + 1
+		asOfTxNum = &v
+	}
+
+	for _, name := range strings.Split(cliCtx.String("data"), ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "blocks":
+			err = export.Blocks(ctx, tx, blockReader, chainConfig, exportCfg, fromBlock, toBlock)
+		case name == "receipts":
+			err = export.Receipts(ctx, tx, blockReader, exportCfg, fromBlock, toBlock)
+		case strings.HasSuffix(name, "_history"):
+			var domain kv.Domain
+			domain, err = kv.String2Domain(strings.TrimSuffix(name, "_history"))
+			if err != nil {
+				return err
+			}
+			err = export.History(ctx, tx, txNumReader, exportCfg, domain, fromBlock, toBlock)
+		default:
+			if domain, errD := kv.String2Domain(name); errD == nil {
+				err = export.Domain(ctx, tx, exportCfg, domain, asOfTxNum)
+				break
+			}
+			idx, errI := kv.String2InvertedIdx(name)
+			if errI != nil {
+				return fmt.Errorf("unknown dataset: %s", name)
+			}
+			var fromTxNum, toTxNum uint64
+			if fromTxNum, err = txNumReader.Min(tx, fromBlock); err != nil {
+				return err
+			}
+			if toTxNum, err = txNumReader.Max(tx, toBlock); err != nil {
+				return err
+			}
+			err = export.InvertedIndex(ctx, tx, exportCfg, idx, fromTxNum, toTxNum+1)
+		}
+		if err != nil {
+			return fmt.Errorf("export %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// openExportChainDB - export needs only snapshot files. If datadir has no chaindata - use temporary db with chain config of `--chain`.
+func openExportChainDB(ctx context.Context, dirs datadir.Dirs, chainName string, logger log.Logger) (kv.RwDB, func(), error) {
+	if exists, err := dir.FileExist(filepath.Join(dirs.Chaindata, "mdbx.dat")); err != nil {
+		return nil, nil, err
+	} else if exists {
+		db := dbCfg(dbcfg.ChainDB, dirs.Chaindata).MustOpen()
+		return db, db.Close, nil
+	}
+
+	chainSpec, err := chainspec.ChainSpecByName(chainName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("datadir has no chaindata, --%s is required: %w", utils.ChainFlag.Name, err)
+	}
+	tmpPath := filepath.Join(dirs.Tmp, "export-chaindata")
+	db := mdbx.New(dbcfg.ChainDB, logger).Path(tmpPath).MustOpen()
+	clean := func() {
+		db.Close()
+		_ = dir.RemoveAll(tmpPath)
+	}
+	if err := db.Update(ctx, func(tx kv.RwTx) error {
+		if err := rawdb.WriteCanonicalHash(tx, chainSpec.GenesisHash, 0); err != nil {
+			return err
+		}
+		return rawdb.WriteChainConfig(tx, chainSpec.GenesisHash, chainSpec.Config)
+	}); err != nil {
+		clean()
+		return nil, nil, err
+	}
+	return db, clean, nil
+}
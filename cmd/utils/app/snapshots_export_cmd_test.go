@@ -0,0 +1,105 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/export"
+	"github.com/erigontech/erigon/db/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/db/snaptype"
+	"github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/execution/chain"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/tests/blockgen"
+	"github.com/erigontech/erigon/execution/tests/mock"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+func runExport(args ...string) error {
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{&exportCommand}
+	return app.Run(append([]string{"erigon", exportCommand.Name}, args...))
+}
+
+func TestExportToLastFrozenBlock(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx := context.Background()
+	logger := log.New()
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(common.Ether)}},
+	}
+	signer := types.LatestSigner(gspec.Config)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	const blocks = 1000
+	chainPack, err := blockgen.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, blocks, func(i int, b *blockgen.BlockGen) {
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(sender), common.Address{1}, uint256.NewInt(100), 21000, uint256.NewInt(common.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+
+	// datadir with block files only: export takes the chain config of --chain
+	dirs := datadir.New(t.TempDir())
+	_, err = snaptype.LoadSalt(dirs.Snap, true, logger)
+	require.NoError(t, err)
+	_, err = state.GetStateIndicesSalt(dirs, true, logger)
+	require.NoError(t, err)
+	require.NoError(t, freezeblocks.DumpBlocks(ctx, 0, blocks, m.ChainConfig, dirs.Tmp, dirs.Snap, m.DB, 1, log.LvlDebug, logger, m.BlockReader))
+	chainName := "export-test"
+	chainspec.RegisterChainSpec(chainName, chainspec.Spec{Name: chainName, GenesisHash: m.Genesis.Hash(), Genesis: gspec, Config: m.ChainConfig})
+
+	out := t.TempDir()
+	require.NoError(t, runExport("--datadir", dirs.DataDir, "--chain", chainName, "--data", "blocks", "--out", out))
+	files, err := filepath.Glob(filepath.Join(out, "blocks", "*.parquet"))
+	require.NoError(t, err)
+	slices.Sort(files)
+	var rows []export.BlockRow
+	for _, f := range files {
+		part, err := parquet.ReadFile[export.BlockRow](f)
+		require.NoError(t, err)
+		rows = append(rows, part...)
+	}
+	require.Len(t, rows, blocks)
+	last := chainPack.Blocks[blocks-2] // chain pack has no genesis
+	require.Equal(t, last.NumberU64(), rows[len(rows)-1].BlockNum)
+	require.Equal(t, last.Hash().Bytes(), rows[len(rows)-1].Hash)
+}
+
+func TestExportAsOfFlagsExclusive(t *testing.T) {
+	err := runExport("--datadir", t.TempDir(), "--data", "accounts", "--out", t.TempDir(), "--asof.block", "1", "--asof.txnum", "1")
+	require.ErrorContains(t, err, "can't be used together")
+}
@@ -49,9 +49,11 @@ import (
 	"github.com/erigontech/erigon/db/datadir"
 	"github.com/erigontech/erigon/db/downloader/downloadercfg"
 	"github.com/erigontech/erigon/db/snapcfg"
+	"github.com/erigontech/erigon/db/snapstore"
 	"github.com/erigontech/erigon/db/state/statecfg"
 	"github.com/erigontech/erigon/db/version"
 	"github.com/erigontech/erigon/execution/builder/buildercfg"
+	chainbundle "github.com/erigontech/erigon/execution/chain/bundle"
 	"github.com/erigontech/erigon/execution/chain/networkname"
 	chainspec "github.com/erigontech/erigon/execution/chain/spec"
 	"github.com/erigontech/erigon/execution/protocol/params"
@@ -62,6 +64,7 @@ import (
 	"github.com/erigontech/erigon/node/logging"
 	"github.com/erigontech/erigon/node/nodecfg"
 	"github.com/erigontech/erigon/node/paths"
+	"github.com/erigontech/erigon/ots/events"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/nat"
@@ -104,9 +107,13 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperPoSFlag = cli.BoolFlag{
+		Name:  "dev.pos",
+		Usage: "Developer mode chain is proof-of-stake from genesis: blocks are produced via Engine API by external CL, not mined by Clique",
+	}
 	ChainFlag = cli.StringFlag{
 		Name:  "chain",
-		Usage: "name of the network to join",
+		Usage: "name of the network to join, or path to a chain bundle directory",
 		// Can we remove this default? It can be destructive.
 		// Giulio here after it broke CI: no, we cannot remove it.
 		Value: networkname.Mainnet,
@@ -215,6 +222,26 @@ var (
 		Usage: "Comma separated list of addresses, whose transactions will traced in transaction pool with debug printing",
 		Value: "",
 	}
+	TxPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool.ordering",
+		Usage: "Order in which pending transactions are included into produced blocks: fee (by effective tip), fcfs (first-come-first-served), priority-senders (txns of --txpool.prioritysenders first, then by effective tip)",
+		Value: string(txpoolcfg.DefaultConfig.Ordering),
+	}
+	TxPoolPrioritySendersFlag = cli.StringFlag{
+		Name:  "txpool.prioritysenders",
+		Usage: "Comma separated list of addresses, whose transactions are included first with --txpool.ordering=priority-senders",
+		Value: "",
+	}
+	TxPoolJournalFlag = cli.StringFlag{
+		Name:  "txpool.journal",
+		Usage: "File to journal incoming transactions and new blocks of transaction pool to, for replay by 'txnbench replay'. Disabled if empty",
+		Value: "",
+	}
+	TxPoolReplicateToFlag = cli.StringFlag{
+		Name:  "txpool.replicate.to",
+		Usage: "Private api address (host:port) of txpool of standby node, to mirror local transactions to: so they survive failover to that node. Disabled if empty",
+		Value: "",
+	}
 	TxPoolCommitEveryFlag = cli.DurationFlag{
 		Name:  "txpool.commit.every",
 		Usage: "How often transactions should be committed to the storage",
@@ -318,6 +345,11 @@ var (
 		Usage: "Path to the token that ensures safe connection between CL and EL",
 		Value: "",
 	}
+	AuthRpcRecordFlag = cli.StringFlag{
+		Name:  "authrpc.record",
+		Usage: "Path to file to record all Engine API requests and responses to (gzipped JSON lines). Replay it by `integration engine_replay`",
+		Value: "",
+	}
 
 	HttpCompressionFlag = cli.BoolFlag{
 		Name:  "http.compression",
@@ -713,6 +745,15 @@ var (
 		Name:  "downloader.verify",
 		Usage: "Verify snapshots on startup. It will not report problems found, but re-download broken pieces.",
 	}
+	SnapSharedStoreFlag = cli.PathFlag{
+		Name:  "snap.shared-store",
+		Usage: "Path to snapshot store shared by many datadirs on this host. Files are hard-linked (or reflinked) from it instead of downloading. Must be on same filesystem as datadir",
+	}
+	SnapSharedStoreModeFlag = cli.StringFlag{
+		Name:  "snap.shared-store.mode",
+		Usage: "How to link files from --snap.shared-store: hardlink, reflink, auto (reflink with fallback to hardlink)",
+		Value: string(snapstore.ModeAuto),
+	}
 	DisableIPV6 = cli.BoolFlag{
 		Name:  "downloader.disable.ipv6",
 		Usage: "Turns off ipv6 for the downloader",
@@ -850,6 +891,15 @@ var (
 		Usage: "MEV relay endpoint. Caplin runs in builder mode if this is set",
 		Value: "",
 	}
+	CaplinBuilderRelaysFlag = cli.StringSliceFlag{
+		Name:  "caplin.builder.relays",
+		Usage: "Comma separated list of relay endpoints. Caplin builds a block for every slot and submits it to relays at which proposer of the slot is registered",
+	}
+	CaplinBuilderSecretKeyFlag = cli.StringFlag{
+		Name:  "caplin.builder.secret-key",
+		Usage: "Path to file with hex-encoded BLS secret key to sign block submissions to relays (see --caplin.builder.relays)",
+		Value: "",
+	}
 	CaplinValidatorMonitorFlag = cli.BoolFlag{
 		Name:  "caplin.validator-monitor",
 		Usage: "Enable caplin validator monitoring metrics",
@@ -896,6 +946,10 @@ var (
 		Usage: "Enable experimental Otterscan API V2",
 		Value: false,
 	}
+	OtsV2EventsFlag = cli.StringFlag{
+		Name:  "experimental.ots2.events",
+		Usage: "Path to a TOML file of extra Otterscan API V2 event indexers, used with --experimental.ots2",
+	}
 
 	SilkwormExecutionFlag = cli.BoolFlag{
 		Name:  "silkworm.exec",
@@ -1167,6 +1221,31 @@ func setBootstrapNodesV5(ctx *cli.Context, cfg *p2p.Config) {
 	cfg.BootstrapNodesV5 = nodes
 }
 
+// ResolveChainBundle registers the chain of the bundle directory given as --chain,
+// and replaces the flag value with the chain name, so the rest of the flags
+// handling can look it up like a built-in chain.
+func ResolveChainBundle(ctx *cli.Context) error {
+	chain := ctx.String(ChainFlag.Name)
+	if !chainbundle.IsBundle(chain) {
+		return nil
+	}
+	name, err := chainbundle.Resolve(chain, log.Root())
+	if err != nil {
+		return err
+	}
+	return ctx.Set(ChainFlag.Name, name)
+}
+
+// RegisterOtsEventIndexers registers the built-in and --experimental.ots2.events
+// event indexers, if OTS v2 is enabled. It must run before the chaindata DB is
+// opened, as the indexers add their own tables.
+func RegisterOtsEventIndexers(ctx *cli.Context) error {
+	if !ctx.Bool(OtsV2Flag.Name) {
+		return nil
+	}
+	return events.Enable(ctx.String(OtsV2EventsFlag.Name))
+}
+
 // GetBootnodesFromFlags makes a list of bootnodes from command line flags.
 // If urlsStr is given, it is used and parsed as a comma-separated list of enode:// urls,
 // otherwise a list of preconfigured bootnodes of the specified chain is returned.
@@ -1550,6 +1629,24 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolGossipDisableFlag.Name) {
 		cfg.NoGossip = ctx.Bool(TxPoolGossipDisableFlag.Name)
 	}
+	if ctx.IsSet(TxPoolOrderingFlag.Name) {
+		ordering, err := txpoolcfg.ParseOrdering(ctx.String(TxPoolOrderingFlag.Name))
+		if err != nil {
+			Fatalf("Option %s: %v", TxPoolOrderingFlag.Name, err)
+		}
+		cfg.Ordering = ordering
+	}
+	if ctx.IsSet(TxPoolPrioritySendersFlag.Name) {
+		for _, senderHex := range common.CliString2Array(ctx.String(TxPoolPrioritySendersFlag.Name)) {
+			cfg.PrioritySenders = append(cfg.PrioritySenders, common.HexToAddress(senderHex))
+		}
+	}
+	if ctx.IsSet(TxPoolJournalFlag.Name) {
+		cfg.JournalPath = ctx.String(TxPoolJournalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolReplicateToFlag.Name) {
+		cfg.ReplicateTo = ctx.String(TxPoolReplicateToFlag.Name)
+	}
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = common.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
@@ -1753,6 +1850,8 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.CaplinConfig.DisabledCheckpointSync = ctx.Bool(CaplinDisableCheckpointSyncFlag.Name)
 	// bunch of extra stuff
 	cfg.CaplinConfig.MevRelayUrl = ctx.String(CaplinMevRelayUrl.Name)
+	cfg.CaplinConfig.BuilderRelayUrls = ctx.StringSlice(CaplinBuilderRelaysFlag.Name)
+	cfg.CaplinConfig.BuilderSecretKeyPath = ctx.String(CaplinBuilderSecretKeyFlag.Name)
 	cfg.CaplinConfig.EnableValidatorMonitor = ctx.Bool(CaplinValidatorMonitorFlag.Name)
 	if checkpointUrls := ctx.StringSlice(CaplinCheckpointSyncUrlFlag.Name); len(checkpointUrls) > 0 {
 		clparams.ConfigurableCheckpointsURLs = checkpointUrls
@@ -1955,8 +2054,13 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		logger.Info("Using developer account", "address", developer)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = chainspec.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
-		logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		if ctx.Bool(DeveloperPoSFlag.Name) {
+			cfg.Genesis = chainspec.DeveloperPoSGenesisBlock()
+			logger.Info("Using proof-of-stake developer genesis: drive block production via Engine API")
+		} else {
+			cfg.Genesis = chainspec.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
+			logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		}
 	}
 
 	if ctx.IsSet(OverrideOsakaFlag.Name) {
@@ -1967,6 +2071,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	if clparams.EmbeddedSupported(cfg.NetworkID) || cfg.CaplinConfig.IsDevnet() {
 		cfg.InternalCL = !ctx.Bool(ExternalConsensusFlag.Name)
 	}
+	cfg.EngineApiRecordPath = ctx.String(AuthRpcRecordFlag.Name)
 
 	if ctx.IsSet(TrustedSetupFile.Name) {
 		libkzg.SetTrustedSetupFilePath(ctx.String(TrustedSetupFile.Name))
@@ -2005,6 +2110,8 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 				DownloadRateLimit:        MustGetStringFlagDownloaderRateLimit(ctx.String(TorrentDownloadRateFlag.Name)),
 				UploadRateLimit:          MustGetStringFlagDownloaderRateLimit(ctx.String(TorrentUploadRateFlag.Name)),
 				WebseedDownloadRateLimit: MustGetStringFlagDownloaderRateLimit(ctx.String(TorrentWebseedDownloadRateFlag.Name)),
+				SharedStoreDir:           ctx.Path(SnapSharedStoreFlag.Name),
+				SharedStoreMode:          snapstore.Mode(ctx.String(SnapSharedStoreModeFlag.Name)),
 			},
 		)
 		if err != nil {
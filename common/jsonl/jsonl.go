@@ -0,0 +1,135 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package jsonl - gzip stream of JSON lines, one entry per line: format of traffic recordings of node.
+//
+// Writer appends to existing file as a new gzip member - so node restarts don't lose previous sessions,
+// and Reader reads all members as one stream.
+package jsonl
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon/common/log/v3"
+)
+
+type Writer struct {
+	lock       sync.Mutex
+	file       *os.File
+	zw         *gzip.Writer
+	enc        *json.Encoder
+	flushEvery time.Duration
+	flushTimer *time.Timer // pending flush of written entries
+	logger     log.Logger
+}
+
+// NewWriter - opens file for appending.
+// flushEvery: 0 - every entry is flushed by Write, otherwise written entries are flushed in background not later than
+// flushEvery after write - also when no more entries come. Crash of node loses at most flushEvery of entries.
+func NewWriter(path string, flushEvery time.Duration, logger log.Logger) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	zw := gzip.NewWriter(f)
+	return &Writer{file: f, zw: zw, enc: json.NewEncoder(zw), flushEvery: flushEvery, logger: logger}, nil
+}
+
+// Write - returns os.ErrClosed after Close
+func (w *Writer) Write(entry any) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.zw == nil {
+		return os.ErrClosed
+	}
+	if err := w.enc.Encode(entry); err != nil {
+		return err
+	}
+	if w.flushEvery == 0 {
+		return w.zw.Flush()
+	}
+	if w.flushTimer == nil {
+		w.flushTimer = time.AfterFunc(w.flushEvery, w.flush)
+	}
+	return nil
+}
+
+func (w *Writer) flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.flushTimer = nil
+	if w.zw == nil {
+		return // closed
+	}
+	if err := w.zw.Flush(); err != nil {
+		w.logger.Warn("[jsonl] flush failed", "file", w.file.Name(), "err", err)
+	}
+}
+
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.zw == nil {
+		return nil
+	}
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+		w.flushTimer = nil
+	}
+	err := w.zw.Close()
+	w.zw = nil
+	return errors.Join(err, w.file.Close())
+}
+
+// Reader - reads entries of type T written by Writer
+type Reader[T any] struct {
+	file *os.File
+	zr   *gzip.Reader
+	dec  *json.Decoder
+}
+
+func NewReader[T any](path string) (*Reader[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &Reader[T]{file: f, zr: zr, dec: json.NewDecoder(zr)}, nil
+}
+
+// Next - returns io.EOF at the end of file, io.ErrUnexpectedEOF if file was cut (for example by crash of node)
+func (r *Reader[T]) Next() (*T, error) {
+	var entry T
+	if err := r.dec.Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *Reader[T]) Close() error {
+	return errors.Join(r.zr.Close(), r.file.Close())
+}
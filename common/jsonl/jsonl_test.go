@@ -0,0 +1,83 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonl
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common/log/v3"
+)
+
+type entry struct {
+	N int `json:"n"`
+}
+
+func readAll(path string) (entries []int, err error) {
+	r, err := NewReader[entry](path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return entries, err
+		}
+		entries = append(entries, e.N)
+	}
+}
+
+func TestSessionsAppended(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.gz")
+	for _, session := range [][]int{{1, 2}, {3}} {
+		w, err := NewWriter(path, 0, log.New())
+		require.NoError(t, err)
+		for _, n := range session {
+			require.NoError(t, w.Write(entry{N: n}))
+		}
+		require.NoError(t, w.Close())
+		require.ErrorIs(t, w.Write(entry{}), os.ErrClosed)
+	}
+	entries, err := readAll(path)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, entries)
+}
+
+func TestIdleTailFlushed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rec.gz")
+	w, err := NewWriter(path, 10*time.Millisecond, log.New())
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.Write(entry{N: 1}))
+	require.NoError(t, w.Write(entry{N: 2}))
+
+	// no more writes: entries are flushed anyway, file is cut as by crash
+	require.Eventually(t, func() bool {
+		entries, err := readAll(path)
+		return errors.Is(err, io.ErrUnexpectedEOF) && len(entries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -940,10 +940,14 @@ func (d *Downloader) AddNewSeedableFile(ctx context.Context, name string) error
 	defer d.lock.Unlock()
 	// The above BuildTorrentIfNeed should put the metainfo in the right place for name.
 	// addPreverifiedTorrent is the correct wrapper to check for existing torrents in the client.
-	_, err = d.addPreverifiedTorrent(g.None[metainfo.Hash](), name)
+	t, err := d.addPreverifiedTorrent(g.None[metainfo.Hash](), name)
 	if err != nil {
 		return fmt.Errorf("adding torrent: %w", err)
 	}
+	if t != nil {
+		// File produced locally: it's complete by definition.
+		d.adoptIntoSharedStore(t.InfoHash(), name)
+	}
 	return nil
 }
 
@@ -1008,6 +1012,9 @@ func (d *Downloader) addPreverifiedTorrent(
 	infoHashHint g.Option[metainfo.Hash], // The infohash to use if there isn't one on disk. If there isn't one on disk then we can't proceed.
 	name string,
 ) (t *torrent.Torrent, err error) {
+	if infoHashHint.Ok {
+		d.linkFromSharedStore(infoHashHint.Value, name)
+	}
 	diskSpecOpt := d.loadSpecFromDisk(name)
 	if !diskSpecOpt.Ok && !infoHashHint.Ok {
 		err = fmt.Errorf("can't add torrent without infohash. name=%s", name)
@@ -1187,9 +1194,36 @@ func (d *Downloader) saveMetainfo(t *torrent.Torrent) error {
 	if err != nil {
 		return fmt.Errorf("error creating metainfo file: %w", err)
 	}
+	d.adoptIntoSharedStore(t.InfoHash(), t.Name())
 	return nil
 }
 
+// Hard-link (or reflink) file from shared snapshot store if it has this infohash. Then torrent will
+// see complete data and only verify it, instead of downloading.
+func (d *Downloader) linkFromSharedStore(infoHash metainfo.Hash, name string) {
+	if d.cfg.SharedStore == nil {
+		return
+	}
+	linked, err := d.cfg.SharedStore.LinkInto(infoHash, d.filePathForName(name))
+	if err != nil {
+		d.logger.Warn("[snapstore] failed to link file from shared store", "name", name, "err", err)
+		return
+	}
+	if linked {
+		d.logger.Debug("[snapstore] linked file from shared store", "name", name, "infohash", infoHash.HexString())
+	}
+}
+
+// Put complete file into shared snapshot store, so other datadirs can use it.
+func (d *Downloader) adoptIntoSharedStore(infoHash metainfo.Hash, name string) {
+	if d.cfg.SharedStore == nil {
+		return
+	}
+	if err := d.cfg.SharedStore.Adopt(infoHash, d.filePathForName(name)); err != nil {
+		d.logger.Warn("[snapstore] failed to adopt file into shared store", "name", name, "err", err)
+	}
+}
+
 func SeedableFiles(dirs datadir.Dirs, chainName string, all bool) ([]string, error) {
 	files, err := seedableSegmentFiles(dirs.Snap, chainName, all)
 	if err != nil {
@@ -1517,6 +1551,12 @@ func (s *Downloader) Delete(name string) error {
 	// But we also can delete .torrent: earlier is better (`kill -9` may come at any time)
 	t.Drop()
 	g.MustDelete(s.torrentsByName, name)
+	if s.cfg.SharedStore != nil {
+		// Object stays in store while other datadirs reference it.
+		if releaseErr := s.cfg.SharedStore.Release(t.InfoHash(), s.filePathForName(name)); releaseErr != nil {
+			s.logger.Warn("[snapstore] failed to release file", "name", name, "err", releaseErr)
+		}
+	}
 	// I wonder if it's an issue if this occurs before initial sync has completed.
 	delete(s.requiredTorrents, t)
 	// Return torrent file deletion error.
@@ -42,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/db/datadir"
 	"github.com/erigontech/erigon/db/snapcfg"
+	"github.com/erigontech/erigon/db/snapstore"
 )
 
 // DefaultPieceSize - Erigon serves many big files, bigger pieces will reduce
@@ -86,6 +87,9 @@ type Cfg struct {
 	// Disable automatic data verification in the torrent client. We want to call VerifyData
 	// ourselves.
 	ManualDataVerification bool
+	// Optional store of snapshot files shared with other datadirs on this host. Files are linked
+	// from it instead of downloading, and completed files are adopted into it.
+	SharedStore *snapstore.Store
 }
 
 // Before options/flags applied.
@@ -125,6 +129,9 @@ type NewCfgOpts struct {
 	UploadRateLimit          g.Option[rate.Limit]
 	DownloadRateLimit        g.Option[rate.Limit]
 	WebseedDownloadRateLimit g.Option[rate.Limit]
+	// Path to snapshot store shared between datadirs. Disabled if empty.
+	SharedStoreDir  string
+	SharedStoreMode snapstore.Mode
 }
 
 func New(
@@ -294,6 +301,18 @@ func New(
 		cfg.SeparateWebseedDownloadRateLimit.Set(value)
 	}
 
+	if opts.SharedStoreDir != "" {
+		mode, err := snapstore.ParseMode(string(opts.SharedStoreMode))
+		if err != nil {
+			return nil, err
+		}
+		cfg.SharedStore, err = snapstore.Open(opts.SharedStoreDir, mode, log.Root())
+		if err != nil {
+			return nil, fmt.Errorf("opening shared snapshot store: %w", err)
+		}
+		log.Info("using shared snapshot store", "dir", cfg.SharedStore.Root(), "mode", cfg.SharedStore.Mode())
+	}
+
 	return &cfg, nil
 }
 
@@ -317,7 +336,7 @@ func LoadSnapshotsHashes(ctx context.Context, dirs datadir.Dirs, chainName strin
 			return err
 		}
 		snapcfg.SetToml(chainName, haveToml, true)
-	} else {
+	} else if !snapcfg.Registered(chainName) { // runtime registered chains come with their own hashes
 		// Fetch the snapshot hashes from the web
 		err := snapcfg.LoadRemotePreverified(ctx)
 		if err != nil {
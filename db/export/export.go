@@ -0,0 +1,465 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package export - bulk export of state, history, inverted indices and blocks to Parquet files for analytics.
+// Reads only through kv.TemporalTx and services.FullBlockReader - so works on datadir with snapshot files and
+// without running node.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/length"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/order"
+	"github.com/erigontech/erigon/db/kv/rawdbv3"
+	"github.com/erigontech/erigon/db/kv/stream"
+	"github.com/erigontech/erigon/db/rawdb"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+)
+
+const (
+	FormatParquet = "parquet"
+
+	DefaultRowsPerFile   = 10_000_000
+	DefaultBlocksPerFile = 100_000
+)
+
+type Cfg struct {
+	OutDir        string
+	RowsPerFile   uint64 // partition size of key-ordered datasets (domains, inverted indices)
+	BlocksPerFile uint64 // partition size of block-ordered datasets (history, blocks, receipts)
+	Logger        log.Logger
+}
+
+func (cfg Cfg) blocksPerFile() uint64 {
+	if cfg.BlocksPerFile == 0 {
+		return DefaultBlocksPerFile
+	}
+	return cfg.BlocksPerFile
+}
+
+func (cfg Cfg) rowsPerFile() uint64 {
+	if cfg.RowsPerFile == 0 {
+		return DefaultRowsPerFile
+	}
+	return cfg.RowsPerFile
+}
+
+func optBytes(b []byte) *[]byte { return &b }
+
+func blockPartition(blockNum, blocksPerFile uint64) string {
+	from := blockNum / blocksPerFile * blocksPerFile
+	return fmt.Sprintf("blocks-%09d-%09d", from, from+blocksPerFile)
+}
+
+// Domain - export state of domain. `asOfTxNum == nil` means latest state, otherwise - state before `*asOfTxNum` transaction.
+// Accounts and storage are decoded (see AccountRow, StorageRow), other domains exported as raw KVRow.
+func Domain(ctx context.Context, tx kv.TemporalTx, cfg Cfg, domain kv.Domain, asOfTxNum *uint64) error {
+	var it stream.KV
+	var err error
+	if asOfTxNum == nil {
+		it, err = tx.Debug().RangeLatest(domain, nil, nil, -1)
+	} else {
+		it, err = tx.RangeAsOf(domain, nil, nil, *asOfTxNum, order.Asc, -1)
+	}
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	dataset := domain.String()
+	switch domain {
+	case kv.AccountsDomain:
+		return exportKV(ctx, it, cfg, dataset, func(k, v []byte) (AccountRow, error) {
+			var a accounts.Account
+			if err := accounts.DeserialiseV3(&a, v); err != nil {
+				return AccountRow{}, fmt.Errorf("account %x: %w", k, err)
+			}
+			return AccountRow{
+				Address:     common.Copy(k),
+				Nonce:       a.Nonce,
+				Balance:     a.Balance.Dec(),
+				CodeHash:    common.Copy(a.CodeHash[:]),
+				Incarnation: a.Incarnation,
+			}, nil
+		})
+	case kv.StorageDomain:
+		return exportKV(ctx, it, cfg, dataset, func(k, v []byte) (StorageRow, error) {
+			if len(k) != length.Addr+length.Hash {
+				return StorageRow{}, fmt.Errorf("unexpected storage key length: %x", k)
+			}
+			return StorageRow{Address: common.Copy(k[:length.Addr]), Slot: common.Copy(k[length.Addr:]), Value: common.Copy(v)}, nil
+		})
+	default:
+		return exportKV(ctx, it, cfg, dataset, func(k, v []byte) (KVRow, error) {
+			return KVRow{Key: common.Copy(k), Value: common.Copy(v)}, nil
+		})
+	}
+}
+
+func exportKV[T any](ctx context.Context, it stream.KV, cfg Cfg, dataset string, conv func(k, v []byte) (T, error)) (err error) {
+	pw, err := newPartWriter[T](cfg.OutDir, dataset, cfg.rowsPerFile())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			pw.Abort()
+		}
+	}()
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return err
+		}
+		if len(v) == 0 { // deleted
+			continue
+		}
+		row, err := conv(k, v)
+		if err != nil {
+			return err
+		}
+		if err := pw.Write(row); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			cfg.Logger.Info("[export] progress", "dataset", dataset, "rows", pw.Rows, "key", fmt.Sprintf("%x", k))
+		default:
+		}
+	}
+	if err := pw.Close(); err != nil {
+		return err
+	}
+	cfg.Logger.Info("[export] done", "dataset", dataset, "rows", pw.Rows, "files", len(pw.Files))
+	return nil
+}
+
+// History - per-block changes of domain in blocks [fromBlock, toBlock]: every key changed in block with it's value before
+// and after the block. Empty value means "not exists".
+func History(ctx context.Context, tx kv.TemporalTx, txNumReader rawdbv3.TxNumsReader, cfg Cfg, domain kv.Domain, fromBlock, toBlock uint64) (err error) {
+	dataset := domain.String() + "_history"
+	pw, err := newPartWriter[HistoryRow](cfg.OutDir, dataset, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			pw.Abort()
+		}
+	}()
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	bpf := cfg.blocksPerFile()
+	for blockNum := fromBlock; blockNum <= toBlock; blockNum++ {
+		if blockNum == fromBlock || blockNum%bpf == 0 {
+			if err := pw.Roll(blockPartition(blockNum, bpf)); err != nil {
+				return err
+			}
+		}
+		fromTxNum, err := txNumReader.Min(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		toTxNum, err := txNumReader.Max(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if err := historyOfBlock(tx, pw, domain, blockNum, fromTxNum, toTxNum+1); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			cfg.Logger.Info("[export] progress", "dataset", dataset, "block", blockNum, "rows", pw.Rows)
+		default:
+		}
+	}
+	if err := pw.Close(); err != nil {
+		return err
+	}
+	cfg.Logger.Info("[export] done", "dataset", dataset, "rows", pw.Rows, "files", len(pw.Files))
+	return nil
+}
+
+func historyOfBlock(tx kv.TemporalTx, pw *partWriter[HistoryRow], domain kv.Domain, blockNum, fromTxNum, toTxNum uint64) error {
+	it, err := tx.HistoryRange(domain, int(fromTxNum), int(toTxNum), order.Asc, -1)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.HasNext() {
+		k, before, err := it.Next()
+		if err != nil {
+			return err
+		}
+		after, _, err := tx.GetAsOf(domain, k, toTxNum)
+		if err != nil {
+			return err
+		}
+		if err := pw.Write(HistoryRow{BlockNum: blockNum, Key: common.Copy(k), ValueBefore: common.Copy(before), ValueAfter: common.Copy(after)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvertedIndex - all (key, txNum) pairs of standalone inverted index (logs, traces) in files, in [fromTxNum, toTxNum)
+func InvertedIndex(ctx context.Context, tx kv.TemporalTx, cfg Cfg, idx kv.InvertedIdx, fromTxNum, toTxNum uint64) (err error) {
+	aggTx := state.AggTx(tx)
+	if aggTx == nil {
+		return errors.New("export of inverted index requires temporal tx with files")
+	}
+	dataset := idx.String()
+	pw, err := newPartWriter[IndexRow](cfg.OutDir, dataset, cfg.rowsPerFile())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			pw.Abort()
+		}
+	}()
+	if err := aggTx.IndexFileStream(ctx, idx, fromTxNum, toTxNum, func(k []byte, txNum uint64) error {
+		return pw.Write(IndexRow{Key: k, TxNum: txNum})
+	}); err != nil {
+		return err
+	}
+	if err := pw.Close(); err != nil {
+		return err
+	}
+	cfg.Logger.Info("[export] done", "dataset", dataset, "rows", pw.Rows, "files", len(pw.Files))
+	return nil
+}
+
+// Blocks - headers and transactions of blocks [fromBlock, toBlock] into `blocks` and `transactions` datasets
+func Blocks(ctx context.Context, tx kv.Tx, br services.FullBlockReader, chainConfig *chain.Config, cfg Cfg, fromBlock, toBlock uint64) (err error) {
+	blocks, err := newPartWriter[BlockRow](cfg.OutDir, "blocks", 0)
+	if err != nil {
+		return err
+	}
+	txns, err := newPartWriter[TransactionRow](cfg.OutDir, "transactions", 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			blocks.Abort()
+			txns.Abort()
+		}
+	}()
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	bpf := cfg.blocksPerFile()
+	for blockNum := fromBlock; blockNum <= toBlock; blockNum++ {
+		if blockNum == fromBlock || blockNum%bpf == 0 {
+			if err := blocks.Roll(blockPartition(blockNum, bpf)); err != nil {
+				return err
+			}
+			if err := txns.Roll(blockPartition(blockNum, bpf)); err != nil {
+				return err
+			}
+		}
+		block, err := br.BlockByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block %d not found", blockNum)
+		}
+		h := block.HeaderNoCopy()
+		row := BlockRow{
+			BlockNum:    blockNum,
+			Hash:        block.Hash().Bytes(),
+			ParentHash:  h.ParentHash.Bytes(),
+			Timestamp:   h.Time,
+			Miner:       h.Coinbase.Bytes(),
+			GasLimit:    h.GasLimit,
+			GasUsed:     h.GasUsed,
+			TxCount:     uint32(len(block.Transactions())),
+			StateRoot:   h.Root.Bytes(),
+			ReceiptRoot: h.ReceiptHash.Bytes(),
+		}
+		if h.BaseFee != nil {
+			row.BaseFee = h.BaseFee.String()
+		}
+		if err := blocks.Write(row); err != nil {
+			return err
+		}
+		signer := types.MakeSigner(chainConfig, blockNum, h.Time)
+		for i, txn := range block.Transactions() {
+			from, err := txn.Sender(*signer)
+			if err != nil {
+				return fmt.Errorf("block %d, txn %d: %w", blockNum, i, err)
+			}
+			trow := TransactionRow{
+				BlockNum:  blockNum,
+				TxIndex:   uint32(i),
+				Hash:      txn.Hash().Bytes(),
+				Type:      uint32(txn.Type()),
+				From:      from.Bytes(),
+				Nonce:     txn.GetNonce(),
+				Value:     txn.GetValue().Dec(),
+				GasLimit:  txn.GetGasLimit(),
+				FeeCap:    txn.GetFeeCap().Dec(),
+				TipCap:    txn.GetTipCap().Dec(),
+				Input:     txn.GetData(),
+				BlobCount: uint32(len(txn.GetBlobHashes())),
+			}
+			if to := txn.GetTo(); to != nil {
+				trow.To = optBytes(to.Bytes())
+			}
+			if err := txns.Write(trow); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			cfg.Logger.Info("[export] progress", "dataset", "blocks", "block", blockNum, "txs", txns.Rows)
+		default:
+		}
+	}
+	if err := blocks.Close(); err != nil {
+		return err
+	}
+	if err := txns.Close(); err != nil {
+		return err
+	}
+	cfg.Logger.Info("[export] done", "dataset", "blocks", "blocks", blocks.Rows, "txs", txns.Rows, "files", len(blocks.Files)+len(txns.Files))
+	return nil
+}
+
+// Receipts - receipts and logs of blocks [fromBlock, toBlock] into `receipts` and `logs` datasets.
+// Requires RCacheDomain (`--persist.receipts`): receipts are not re-executed.
+func Receipts(ctx context.Context, tx kv.TemporalTx, br services.FullBlockReader, cfg Cfg, fromBlock, toBlock uint64) (err error) {
+	receipts, err := newPartWriter[ReceiptRow](cfg.OutDir, "receipts", 0)
+	if err != nil {
+		return err
+	}
+	logs, err := newPartWriter[LogRow](cfg.OutDir, "logs", 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			receipts.Abort()
+			logs.Abort()
+		}
+	}()
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	txNumReader := br.TxnumReader(ctx)
+	bpf := cfg.blocksPerFile()
+	for blockNum := fromBlock; blockNum <= toBlock; blockNum++ {
+		if blockNum == fromBlock || blockNum%bpf == 0 {
+			if err := receipts.Roll(blockPartition(blockNum, bpf)); err != nil {
+				return err
+			}
+			if err := logs.Roll(blockPartition(blockNum, bpf)); err != nil {
+				return err
+			}
+		}
+		block, err := br.BlockByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block %d not found", blockNum)
+		}
+		blockReceipts, err := rawdb.ReadReceiptsCacheV2(tx, block, txNumReader)
+		if err != nil {
+			return err
+		}
+		if len(blockReceipts) != len(block.Transactions()) {
+			return fmt.Errorf("block %d: receipts not available (have %d, expected %d). Is RCacheDomain enabled?", blockNum, len(blockReceipts), len(block.Transactions()))
+		}
+		for _, r := range blockReceipts {
+			row := ReceiptRow{
+				BlockNum:          blockNum,
+				TxIndex:           uint32(r.TransactionIndex),
+				TxHash:            r.TxHash.Bytes(),
+				Type:              uint32(r.Type),
+				Status:            r.Status,
+				CumulativeGasUsed: r.CumulativeGasUsed,
+				GasUsed:           r.GasUsed,
+				LogCount:          uint32(len(r.Logs)),
+			}
+			if r.ContractAddress != (common.Address{}) {
+				row.ContractAddress = optBytes(r.ContractAddress.Bytes())
+			}
+			if err := receipts.Write(row); err != nil {
+				return err
+			}
+			for _, l := range r.Logs {
+				lrow := LogRow{
+					BlockNum: blockNum,
+					TxIndex:  uint32(r.TransactionIndex),
+					LogIndex: uint32(l.Index),
+					TxHash:   r.TxHash.Bytes(),
+					Address:  l.Address.Bytes(),
+					Data:     l.Data,
+				}
+				for i, topic := range l.Topics {
+					switch i {
+					case 0:
+						lrow.Topic0 = optBytes(topic.Bytes())
+					case 1:
+						lrow.Topic1 = optBytes(topic.Bytes())
+					case 2:
+						lrow.Topic2 = optBytes(topic.Bytes())
+					case 3:
+						lrow.Topic3 = optBytes(topic.Bytes())
+					}
+				}
+				if err := logs.Write(lrow); err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			cfg.Logger.Info("[export] progress", "dataset", "receipts", "block", blockNum, "receipts", receipts.Rows, "logs", logs.Rows)
+		default:
+		}
+	}
+	if err := receipts.Close(); err != nil {
+		return err
+	}
+	if err := logs.Close(); err != nil {
+		return err
+	}
+	cfg.Logger.Info("[export] done", "dataset", "receipts", "receipts", receipts.Rows, "logs", logs.Rows, "files", len(receipts.Files)+len(logs.Files))
+	return nil
+}
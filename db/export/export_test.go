@@ -0,0 +1,292 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package export_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/export"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/db/state/execctx"
+	"github.com/erigontech/erigon/db/state/statecfg"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/protocol/params"
+	"github.com/erigontech/erigon/execution/tests/blockgen"
+	"github.com/erigontech/erigon/execution/tests/mock"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testBalance = big.NewInt(1_000_000_000)
+	recipient   = common.HexToAddress("0xaa")
+
+	// PUSH1 0 PUSH1 0 LOG0 STOP - constructor emitting empty log
+	logInitCode = common.FromHex("0x60006000a000")
+)
+
+// 3 blocks: transfer of 1000 to recipient, contract creation emitting log, transfer of 2000 to recipient
+func mockChain(t *testing.T) (*mock.MockSentry, *blockgen.ChainPack) {
+	// receipts are exported from the receipts cache, which keeps history only with --persist.receipts
+	rcacheCfg := statecfg.Schema.RCacheDomain
+	statecfg.EnableHistoricalRCache()
+	t.Cleanup(func() { statecfg.Schema.RCacheDomain = rcacheCfg })
+
+	m := mock.MockWithGenesis(t, &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc:  types.GenesisAlloc{testAddr: {Balance: testBalance}},
+	}, testKey, false)
+	signer := types.LatestSignerForChainID(nil)
+	chainPack, err := blockgen.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, func(i int, b *blockgen.BlockGen) {
+		var txn types.Transaction
+		switch i {
+		case 0:
+			txn = types.NewTransaction(b.TxNonce(testAddr), recipient, uint256.NewInt(1000), params.TxGas, nil, nil)
+		case 1:
+			txn = types.NewContractCreation(b.TxNonce(testAddr), uint256.NewInt(0), 100_000, nil, logInitCode)
+		case 2:
+			txn = types.NewTransaction(b.TxNonce(testAddr), recipient, uint256.NewInt(2000), params.TxGas, nil, nil)
+		}
+		signed, err := types.SignTx(txn, *signer, testKey)
+		require.NoError(t, err)
+		b.AddTx(signed)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+	return m, chainPack
+}
+
+func readDataset[T any](t *testing.T, dir, dataset string) []T {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, dataset, "*.parquet"))
+	require.NoError(t, err)
+	slices.Sort(files)
+	var rows []T
+	for _, f := range files {
+		part, err := parquet.ReadFile[T](f)
+		require.NoError(t, err)
+		rows = append(rows, part...)
+	}
+	return rows
+}
+
+func balanceOf(t *testing.T, rows []export.AccountRow, addr common.Address) (string, bool) {
+	t.Helper()
+	for _, r := range rows {
+		if common.BytesToAddress(r.Address) == addr {
+			return r.Balance, true
+		}
+	}
+	return "", false
+}
+
+func TestExportDomain(t *testing.T) {
+	m, _ := mockChain(t)
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txNumReader := m.BlockReader.TxnumReader(ctx)
+
+	export1 := func(asOfTxNum *uint64) []export.AccountRow {
+		cfg := export.Cfg{OutDir: t.TempDir(), Logger: log.New()}
+		require.NoError(t, export.Domain(ctx, tx, cfg, kv.AccountsDomain, asOfTxNum))
+		return readDataset[export.AccountRow](t, cfg.OutDir, kv.AccountsDomain.String())
+	}
+
+	latest := export1(nil)
+	balance, ok := balanceOf(t, latest, recipient)
+	require.True(t, ok)
+	require.Equal(t, "3000", balance)
+	for _, r := range latest {
+		if common.BytesToAddress(r.Address) == testAddr {
+			require.Equal(t, uint64(3), r.Nonce)
+		}
+	}
+
+	// state after block 1
+	maxTxNum, err := txNumReader.Max(tx, 1)
+	require.NoError(t, err)
+	afterBlock1 := maxTxNum + 1
+	balance, ok = balanceOf(t, export1(&afterBlock1), recipient)
+	require.True(t, ok)
+	require.Equal(t, "1000", balance)
+
+	// txNum 0 is state before genesis, not latest
+	genesis := uint64(0)
+	require.Empty(t, export1(&genesis))
+}
+
+func TestExportHistory(t *testing.T) {
+	m, _ := mockChain(t)
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	cfg := export.Cfg{OutDir: t.TempDir(), BlocksPerFile: 2, Logger: log.New()}
+	require.NoError(t, export.History(ctx, tx, m.BlockReader.TxnumReader(ctx), cfg, kv.AccountsDomain, 1, 3))
+	rows := readDataset[export.HistoryRow](t, cfg.OutDir, kv.AccountsDomain.String()+"_history")
+
+	var recipientRows []export.HistoryRow
+	for _, r := range rows {
+		require.GreaterOrEqual(t, r.BlockNum, uint64(1))
+		require.LessOrEqual(t, r.BlockNum, uint64(3))
+		if common.BytesToAddress(r.Key) == recipient {
+			recipientRows = append(recipientRows, r)
+		}
+	}
+	require.Len(t, recipientRows, 2)
+
+	balance := func(v []byte) uint64 {
+		var a accounts.Account
+		require.NoError(t, accounts.DeserialiseV3(&a, v))
+		return a.Balance.Uint64()
+	}
+	require.Equal(t, uint64(1), recipientRows[0].BlockNum)
+	require.Empty(t, recipientRows[0].ValueBefore)
+	require.Equal(t, uint64(1000), balance(recipientRows[0].ValueAfter))
+	require.Equal(t, uint64(3), recipientRows[1].BlockNum)
+	require.Equal(t, uint64(1000), balance(recipientRows[1].ValueBefore))
+	require.Equal(t, uint64(3000), balance(recipientRows[1].ValueAfter))
+}
+
+func TestExportBlocks(t *testing.T) {
+	m, chainPack := mockChain(t)
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	cfg := export.Cfg{OutDir: t.TempDir(), BlocksPerFile: 2, Logger: log.New()}
+	require.NoError(t, export.Blocks(ctx, tx, m.BlockReader, m.ChainConfig, cfg, 0, 3))
+
+	blocks := readDataset[export.BlockRow](t, cfg.OutDir, "blocks")
+	require.Len(t, blocks, 4)
+	require.Equal(t, m.Genesis.Hash().Bytes(), blocks[0].Hash)
+	for i, b := range chainPack.Blocks {
+		row := blocks[i+1]
+		require.Equal(t, b.NumberU64(), row.BlockNum)
+		require.Equal(t, b.Hash().Bytes(), row.Hash)
+		require.Equal(t, b.ParentHash().Bytes(), row.ParentHash)
+		require.Equal(t, b.GasUsed(), row.GasUsed)
+		require.Equal(t, uint32(1), row.TxCount)
+	}
+
+	txns := readDataset[export.TransactionRow](t, cfg.OutDir, "transactions")
+	require.Len(t, txns, 3)
+	for i, row := range txns {
+		txn := chainPack.Blocks[i].Transactions()[0]
+		require.Equal(t, uint64(i+1), row.BlockNum)
+		require.Equal(t, txn.Hash().Bytes(), row.Hash)
+		require.Equal(t, testAddr.Bytes(), row.From)
+		require.Equal(t, uint64(i), row.Nonce)
+	}
+	require.Equal(t, recipient.Bytes(), *txns[0].To)
+	require.Equal(t, "1000", txns[0].Value)
+	require.Nil(t, txns[1].To)
+	require.Equal(t, logInitCode, txns[1].Input)
+}
+
+func TestExportReceipts(t *testing.T) {
+	m, chainPack := mockChain(t)
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	cfg := export.Cfg{OutDir: t.TempDir(), Logger: log.New()}
+	require.NoError(t, export.Receipts(ctx, tx, m.BlockReader, cfg, 1, 3))
+
+	receipts := readDataset[export.ReceiptRow](t, cfg.OutDir, "receipts")
+	require.Len(t, receipts, 3)
+	for i, row := range receipts {
+		r := chainPack.Receipts[i][0]
+		require.Equal(t, uint64(i+1), row.BlockNum)
+		require.Equal(t, r.TxHash.Bytes(), row.TxHash)
+		require.Equal(t, types.ReceiptStatusSuccessful, row.Status)
+		require.Equal(t, r.GasUsed, row.GasUsed)
+		require.Equal(t, uint32(len(r.Logs)), row.LogCount)
+	}
+	contract := types.CreateAddress(testAddr, 1)
+	require.Equal(t, contract.Bytes(), *receipts[1].ContractAddress)
+	require.Nil(t, receipts[0].ContractAddress)
+
+	logs := readDataset[export.LogRow](t, cfg.OutDir, "logs")
+	require.Len(t, logs, 1)
+	require.Equal(t, uint64(2), logs[0].BlockNum)
+	require.Equal(t, contract.Bytes(), logs[0].Address)
+	require.Equal(t, receipts[1].TxHash, logs[0].TxHash)
+	require.Nil(t, logs[0].Topic0)
+}
+
+func TestExportInvertedIndex(t *testing.T) {
+	ctx := context.Background()
+	dirs := datadir.New(t.TempDir())
+	stepSize := uint64(4)
+	db := temporaltest.NewTestDBWithStepSize(t, dirs, stepSize)
+	agg := db.(state.HasAgg).Agg().(*state.Aggregator)
+
+	// logs of 2 addresses; files are built for the steps followed by other one, so the 3rd stays in db
+	addrs := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	rwTx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+	domains, err := execctx.NewSharedDomains(rwTx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	for txNum := uint64(0); txNum < 3*stepSize; txNum++ {
+		require.NoError(t, domains.IndexAdd(kv.LogAddrIdx, addrs[txNum%2].Bytes(), txNum))
+		// files are built up to the last step of state domains
+		acc := accounts.Account{Nonce: txNum}
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, addrs[txNum%2].Bytes(), accounts.SerialiseV3(&acc), txNum, nil, 0))
+	}
+	require.NoError(t, domains.Flush(ctx, rwTx))
+	require.NoError(t, rwTx.Commit())
+	require.NoError(t, agg.BuildFiles(3*stepSize))
+
+	tx, err := db.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	cfg := export.Cfg{OutDir: t.TempDir(), RowsPerFile: 3, Logger: log.New()}
+	require.NoError(t, export.InvertedIndex(ctx, tx, cfg, kv.LogAddrIdx, 1, 3*stepSize))
+
+	rows := readDataset[export.IndexRow](t, cfg.OutDir, kv.LogAddrIdx.String())
+	slices.SortFunc(rows, func(a, b export.IndexRow) int { return int(a.TxNum) - int(b.TxNum) })
+	require.Len(t, rows, int(2*stepSize-1)) // [1, 2*stepSize) - files only
+	for i, r := range rows {
+		txNum := uint64(i + 1)
+		require.Equal(t, txNum, r.TxNum)
+		require.Equal(t, addrs[txNum%2].Bytes(), r.Key)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package export
+
+// Parquet schema of exported datasets. See README.md for description of columns.
+// Big numbers (balance, value, fees) are decimal strings: parquet has no uint256 type.
+// Optional binary columns are pointers: parquet-go writes optional []byte as null.
+
+type KVRow struct {
+	Key   []byte `parquet:"key"`
+	Value []byte `parquet:"value"`
+}
+
+type AccountRow struct {
+	Address     []byte `parquet:"address"`
+	Nonce       uint64 `parquet:"nonce"`
+	Balance     string `parquet:"balance"`
+	CodeHash    []byte `parquet:"code_hash"`
+	Incarnation uint64 `parquet:"incarnation"`
+}
+
+type StorageRow struct {
+	Address []byte `parquet:"address"`
+	Slot    []byte `parquet:"slot"`
+	Value   []byte `parquet:"value"`
+}
+
+type HistoryRow struct {
+	BlockNum    uint64 `parquet:"block_num"`
+	Key         []byte `parquet:"key"`
+	ValueBefore []byte `parquet:"value_before"`
+	ValueAfter  []byte `parquet:"value_after"`
+}
+
+type IndexRow struct {
+	Key   []byte `parquet:"key"`
+	TxNum uint64 `parquet:"tx_num"`
+}
+
+type BlockRow struct {
+	BlockNum    uint64 `parquet:"block_num"`
+	Hash        []byte `parquet:"hash"`
+	ParentHash  []byte `parquet:"parent_hash"`
+	Timestamp   uint64 `parquet:"timestamp"`
+	Miner       []byte `parquet:"miner"`
+	GasLimit    uint64 `parquet:"gas_limit"`
+	GasUsed     uint64 `parquet:"gas_used"`
+	BaseFee     string `parquet:"base_fee,optional"`
+	TxCount     uint32 `parquet:"tx_count"`
+	StateRoot   []byte `parquet:"state_root"`
+	ReceiptRoot []byte `parquet:"receipt_root"`
+}
+
+type TransactionRow struct {
+	BlockNum  uint64  `parquet:"block_num"`
+	TxIndex   uint32  `parquet:"tx_index"`
+	Hash      []byte  `parquet:"hash"`
+	Type      uint32  `parquet:"type"`
+	From      []byte  `parquet:"from"`
+	To        *[]byte `parquet:"to,optional"`
+	Nonce     uint64  `parquet:"nonce"`
+	Value     string  `parquet:"value"`
+	GasLimit  uint64  `parquet:"gas_limit"`
+	FeeCap    string  `parquet:"fee_cap"`
+	TipCap    string  `parquet:"tip_cap"`
+	Input     []byte  `parquet:"input"`
+	BlobCount uint32  `parquet:"blob_count"`
+}
+
+type ReceiptRow struct {
+	BlockNum          uint64  `parquet:"block_num"`
+	TxIndex           uint32  `parquet:"tx_index"`
+	TxHash            []byte  `parquet:"tx_hash"`
+	Type              uint32  `parquet:"type"`
+	Status            uint64  `parquet:"status"`
+	CumulativeGasUsed uint64  `parquet:"cumulative_gas_used"`
+	GasUsed           uint64  `parquet:"gas_used"`
+	ContractAddress   *[]byte `parquet:"contract_address,optional"`
+	LogCount          uint32  `parquet:"log_count"`
+}
+
+type LogRow struct {
+	BlockNum uint64  `parquet:"block_num"`
+	TxIndex  uint32  `parquet:"tx_index"`
+	LogIndex uint32  `parquet:"log_index"`
+	TxHash   []byte  `parquet:"tx_hash"`
+	Address  []byte  `parquet:"address"`
+	Topic0   *[]byte `parquet:"topic0,optional"`
+	Topic1   *[]byte `parquet:"topic1,optional"`
+	Topic2   *[]byte `parquet:"topic2,optional"`
+	Topic3   *[]byte `parquet:"topic3,optional"`
+	Data     []byte  `parquet:"data"`
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/erigontech/erigon/common/dir"
+)
+
+const writeBatchSize = 4096
+
+// partWriter - writes rows of type T into partitioned parquet files: `<dir>/<dataset>/<partition>.parquet`.
+// New partition is opened by `Roll` (block-range partitions) or automatically after `maxRows` rows (key-range partitions).
+type partWriter[T any] struct {
+	dir     string
+	maxRows uint64
+
+	f          *os.File
+	bw         *bufio.Writer
+	w          *parquet.GenericWriter[T]
+	tmpPath    string
+	finalPath  string
+	batch      []T
+	rowsInPart uint64
+	parts      int
+
+	Rows  uint64
+	Files []string
+}
+
+func newPartWriter[T any](outDir, dataset string, maxRows uint64) (*partWriter[T], error) {
+	d := filepath.Join(outDir, dataset)
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return nil, err
+	}
+	return &partWriter[T]{dir: d, maxRows: maxRows, batch: make([]T, 0, writeBatchSize)}, nil
+}
+
+// Roll - close current partition (if any) and open new one with given name
+func (pw *partWriter[T]) Roll(partition string) error {
+	if err := pw.closePart(); err != nil {
+		return err
+	}
+	pw.finalPath = filepath.Join(pw.dir, partition+".parquet")
+	// write to .tmp and rename at close: partially written files are never visible to readers
+	pw.tmpPath = pw.finalPath + ".tmp"
+	f, err := os.Create(pw.tmpPath)
+	if err != nil {
+		return err
+	}
+	pw.f = f
+	pw.bw = bufio.NewWriterSize(f, 4*1024*1024)
+	pw.w = parquet.NewGenericWriter[T](pw.bw, parquet.Compression(&parquet.Zstd))
+	pw.rowsInPart = 0
+	pw.parts++
+	return nil
+}
+
+func (pw *partWriter[T]) Write(row T) error {
+	if pw.w == nil || (pw.maxRows > 0 && pw.rowsInPart >= pw.maxRows) {
+		if err := pw.Roll(fmt.Sprintf("part-%05d", pw.parts)); err != nil {
+			return err
+		}
+	}
+	pw.batch = append(pw.batch, row)
+	pw.rowsInPart++
+	pw.Rows++
+	if len(pw.batch) >= writeBatchSize {
+		return pw.flushBatch()
+	}
+	return nil
+}
+
+func (pw *partWriter[T]) flushBatch() error {
+	if len(pw.batch) == 0 {
+		return nil
+	}
+	if _, err := pw.w.Write(pw.batch); err != nil {
+		return err
+	}
+	clear(pw.batch)
+	pw.batch = pw.batch[:0]
+	return nil
+}
+
+func (pw *partWriter[T]) closePart() error {
+	if pw.w == nil {
+		return nil
+	}
+	if err := pw.flushBatch(); err != nil {
+		return err
+	}
+	if err := pw.w.Close(); err != nil {
+		return err
+	}
+	if err := pw.bw.Flush(); err != nil {
+		return err
+	}
+	if err := pw.f.Close(); err != nil {
+		return err
+	}
+	pw.w, pw.bw, pw.f = nil, nil, nil
+	if pw.rowsInPart == 0 {
+		return dir.RemoveFile(pw.tmpPath)
+	}
+	if err := os.Rename(pw.tmpPath, pw.finalPath); err != nil {
+		return err
+	}
+	pw.Files = append(pw.Files, pw.finalPath)
+	return nil
+}
+
+func (pw *partWriter[T]) Close() error { return pw.closePart() }
+
+// Abort - remove not finished partition
+func (pw *partWriter[T]) Abort() {
+	if pw.w == nil {
+		return
+	}
+	_ = pw.f.Close()
+	_ = dir.RemoveFile(pw.tmpPath)
+	pw.w, pw.bw, pw.f = nil, nil, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartWriter(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	pw, err := newPartWriter[IndexRow](dir, "logaddrs", 3)
+	require.NoError(err)
+	for i := uint64(0); i < 7; i++ {
+		require.NoError(pw.Write(IndexRow{Key: []byte{byte(i)}, TxNum: i}))
+	}
+	require.NoError(pw.Close())
+	require.Equal(uint64(7), pw.Rows)
+	require.Len(pw.Files, 3)
+
+	var total []IndexRow
+	for _, f := range pw.Files {
+		rows, err := parquet.ReadFile[IndexRow](f)
+		require.NoError(err)
+		total = append(total, rows...)
+	}
+	require.Len(total, 7)
+	require.Equal(uint64(6), total[6].TxNum)
+	require.Equal([]byte{5}, total[5].Key)
+
+	// no tmp files left
+	entries, err := os.ReadDir(filepath.Join(dir, "logaddrs"))
+	require.NoError(err)
+	require.Len(entries, 3)
+}
+
+func TestPartWriterRollSkipsEmpty(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	pw, err := newPartWriter[BlockRow](dir, "blocks", 0)
+	require.NoError(err)
+	require.NoError(pw.Roll(blockPartition(0, 10)))
+	require.NoError(pw.Roll(blockPartition(10, 10)))
+	require.NoError(pw.Write(BlockRow{BlockNum: 10, BaseFee: "7"}))
+	require.NoError(pw.Close())
+	require.Len(pw.Files, 1)
+	require.Equal("blocks-000000010-000000020.parquet", filepath.Base(pw.Files[0]))
+
+	rows, err := parquet.ReadFile[BlockRow](pw.Files[0])
+	require.NoError(err)
+	require.Len(rows, 1)
+	require.Equal("7", rows[0].BaseFee)
+}
@@ -32,7 +32,11 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/empty"
+	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/state/execctx"
 	"github.com/erigontech/erigon/diagnostics/metrics"
 	"github.com/erigontech/erigon/node/gointerfaces"
 	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
@@ -59,6 +63,8 @@ type CacheView interface {
 	Get(k []byte) ([]byte, error)
 	GetCode(k []byte) ([]byte, error)
 	HasStorage(address common.Address) (bool, error)
+	GetStorage(address common.Address, location common.Hash) ([]byte, error)
+	StorageRoot(address common.Address) (common.Hash, error)
 }
 
 // Coherent works on top of Database Transaction and pair Coherent+ReadTransaction must
@@ -155,6 +161,37 @@ func (c *CoherentView) HasStorage(address common.Address) (bool, error) {
 	_, _, hasStorage, err := c.tx.HasPrefix(kv.StorageDomain, address[:])
 	return hasStorage, err
 }
+func (c *CoherentView) GetStorage(address common.Address, location common.Hash) ([]byte, error) {
+	// note: storage changes are cached under address+incarnation+location keys, and incarnation is not
+	// known to callers - so read latest value from the DB (it's consistent with the view)
+	v, _, err := c.tx.GetLatest(kv.StorageDomain, append(address[:], location[:]...))
+	return v, err
+}
+
+func (c *CoherentView) StorageRoot(address common.Address) (common.Hash, error) {
+	return storageRoot(c.tx, address)
+}
+
+// storageRoot - reads storage root of account from commitment: unfolds trie branches on the path to account.
+// Account without storage (or not existing account) has empty root.
+func storageRoot(tx kv.TemporalTx, address common.Address) (common.Hash, error) {
+	domains, err := execctx.NewSharedDomains(tx, log.Root())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer domains.Close()
+	sdCtx := domains.GetCommitmentContext()
+	sdCtx.TouchKey(kv.AccountsDomain, string(address.Bytes()), nil)
+	proofTrie, _, err := sdCtx.Witness(context.Background(), nil, "storageRoot")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	acc, _ := proofTrie.GetAccount(crypto.Keccak256(address.Bytes()))
+	if acc == nil {
+		return empty.RootHash, nil
+	}
+	return acc.Root, nil
+}
 
 var _ Cache = (*Coherent)(nil)         // compile-time interface check
 var _ CacheView = (*CoherentView)(nil) // compile-time interface check
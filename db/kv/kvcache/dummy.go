@@ -65,3 +65,10 @@ func (c *DummyView) HasStorage(address common.Address) (bool, error) {
 	_, _, hasStorage, err := c.tx.HasPrefix(kv.StorageDomain, address[:])
 	return hasStorage, err
 }
+func (c *DummyView) GetStorage(address common.Address, location common.Hash) ([]byte, error) {
+	v, _, err := c.tx.GetLatest(kv.StorageDomain, append(address[:], location[:]...))
+	return v, err
+}
+func (c *DummyView) StorageRoot(address common.Address) (common.Hash, error) {
+	return storageRoot(c.tx, address)
+}
@@ -27,6 +27,7 @@ type ConfigKey []byte
 var (
 	PersistReceipts   = ConfigKey("persist.receipts")
 	CommitmentHistory = ConfigKey("commitment.history")
+	CustomTraces      = ConfigKey("custom.traces")
 )
 
 func (k ConfigKey) Enabled(tx kv.Tx) (bool, error) { return kv.GetBool(tx, kv.DatabaseInfo, k) }
@@ -18,6 +18,7 @@ package kv
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -172,6 +173,11 @@ const (
 	TblRCacheHistoryVals = "ReceiptCacheHistoryVals"
 	TblRCacheIdx         = "ReceiptCacheIdx"
 
+	TblCustomTraceVals        = "CustomTraceVals"
+	TblCustomTraceHistoryKeys = "CustomTraceHistoryKeys"
+	TblCustomTraceHistoryVals = "CustomTraceHistoryVals"
+	TblCustomTraceIdx         = "CustomTraceIdx"
+
 	TblLogAddressKeys = "LogAddressKeys"
 	TblLogAddressIdx  = "LogAddressIdx"
 	TblLogTopicsKeys  = "LogTopicsKeys"
@@ -306,6 +312,7 @@ var (
 	PruneTypeOlder = []byte("older")
 	PruneHistory   = []byte("pruneHistory")
 	PruneBlocks    = []byte("pruneBlocks")
+	PruneRetention = []byte("pruneRetention") // last used `statecfg.Retentions`
 
 	DBSchemaVersionKey = []byte("dbVersion")
 	GenesisKey         = []byte("genesis")
@@ -411,6 +418,11 @@ var ChaindataTables = []string{
 	TblRCacheHistoryVals,
 	TblRCacheIdx,
 
+	TblCustomTraceVals,
+	TblCustomTraceHistoryKeys,
+	TblCustomTraceHistoryVals,
+	TblCustomTraceIdx,
+
 	TblLogAddressKeys,
 	TblLogAddressIdx,
 	TblLogTopicsKeys,
@@ -511,12 +523,14 @@ var ChaindataTables = []string{
 const (
 	RecentLocalTransaction = "RecentLocalTransaction" // sequence_u64 -> tx_hash
 	PoolTransaction        = "PoolTransaction"        // txHash -> sender+tx_rlp
+	PoolTransactionArrival = "PoolTransactionArrival" // txHash -> arrival_sequence_u64
 	PoolInfo               = "PoolInfo"               // option_key -> option_value
 )
 
 var TxPoolTables = []string{
 	RecentLocalTransaction,
 	PoolTransaction,
+	PoolTransactionArrival,
 	PoolInfo,
 }
 var SentryTables = []string{
@@ -619,6 +633,9 @@ var ChaindataTablesCfg = TableCfg{
 	TblRCacheHistoryKeys: {Flags: DupSort},
 	TblRCacheIdx:         {Flags: DupSort},
 
+	TblCustomTraceHistoryKeys: {Flags: DupSort},
+	TblCustomTraceIdx:         {Flags: DupSort},
+
 	TblLogAddressKeys: {Flags: DupSort},
 	TblLogAddressIdx:  {Flags: DupSort},
 	TblLogTopicsKeys:  {Flags: DupSort},
@@ -707,6 +724,17 @@ func init() {
 	reinit()
 }
 
+// RegisterChaindataTable adds a table to the chaindata schema which isn't known at
+// compile time (e.g. tables of configurable Otterscan event indexers). It must be
+// called before the chaindata DB is opened.
+func RegisterChaindataTable(name string, cfg TableCfgItem) {
+	if !slices.Contains(ChaindataTables, name) {
+		ChaindataTables = append(ChaindataTables, name)
+	}
+	ChaindataTablesCfg[name] = cfg
+	reinit()
+}
+
 func reinit() {
 	sortBuckets()
 
@@ -779,13 +807,14 @@ func reinit() {
 // Temporal
 
 const (
-	AccountsDomain   Domain = 0 // Eth Accounts
-	StorageDomain    Domain = 1 // Eth Account's Storage
-	CodeDomain       Domain = 2 // Eth Smart-Contract Code
-	CommitmentDomain Domain = 3 // Merkle Trie
-	ReceiptDomain    Domain = 4 // Tiny Receipts - without logs. Required for node-operations.
-	RCacheDomain     Domain = 5 // Fat Receipts - with logs. Optional.
-	DomainLen        Domain = 6 // Technical marker of Enum. Not real Domain.
+	AccountsDomain    Domain = 0 // Eth Accounts
+	StorageDomain     Domain = 1 // Eth Account's Storage
+	CodeDomain        Domain = 2 // Eth Smart-Contract Code
+	CommitmentDomain  Domain = 3 // Merkle Trie
+	ReceiptDomain     Domain = 4 // Tiny Receipts - without logs. Required for node-operations.
+	RCacheDomain      Domain = 5 // Fat Receipts - with logs. Optional.
+	CustomTraceDomain Domain = 6 // Results of user-defined tracers, by tracer. Optional.
+	DomainLen         Domain = 7 // Technical marker of Enum. Not real Domain.
 )
 
 var StateDomains = []Domain{AccountsDomain, StorageDomain, CodeDomain, CommitmentDomain}
@@ -802,6 +831,8 @@ const (
 	LogAddrIdx    InvertedIdx = 7
 	TracesFromIdx InvertedIdx = 8
 	TracesToIdx   InvertedIdx = 9
+
+	CustomTraceHistoryIdx InvertedIdx = 10
 )
 
 func (idx InvertedIdx) String() string {
@@ -818,6 +849,8 @@ func (idx InvertedIdx) String() string {
 		return "receipt"
 	case RCacheHistoryIdx:
 		return "rcache"
+	case CustomTraceHistoryIdx:
+		return "customtrace"
 	case LogAddrIdx:
 		return "logaddrs"
 	case LogTopicIdx:
@@ -845,6 +878,8 @@ func String2InvertedIdx(in string) (InvertedIdx, error) {
 		return ReceiptHistoryIdx, nil
 	case "rcache":
 		return RCacheHistoryIdx, nil
+	case "customtrace":
+		return CustomTraceHistoryIdx, nil
 	case "logaddrs":
 		return LogAddrIdx, nil
 	case "logaddr":
@@ -888,6 +923,8 @@ func (d Domain) String() string {
 		return "receipt"
 	case RCacheDomain:
 		return "rcache"
+	case CustomTraceDomain:
+		return "customtrace"
 	default:
 		return "unknown domain"
 	}
@@ -907,6 +944,8 @@ func String2Domain(in string) (Domain, error) {
 		return ReceiptDomain, nil
 	case "rcache":
 		return RCacheDomain, nil
+	case "customtrace":
+		return CustomTraceDomain, nil
 	default:
 		return Domain(MaxUint16), fmt.Errorf("unknown name: %s", in)
 	}
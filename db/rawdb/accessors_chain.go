@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
 	"github.com/erigontech/erigon/common/dbg"
 	"github.com/erigontech/erigon/common/hexutil"
 	"github.com/erigontech/erigon/common/length"
@@ -1319,6 +1320,72 @@ func WriteReceiptCacheV2(tx kv.TemporalPutDel, receipt *types.Receipt, txNum uin
 	return nil
 }
 
+// ReadCustomTrace returns the result the custom trace `name` produced for the txn `txNum`,
+// nothing for txns it produced no result for
+func ReadCustomTrace(tx kv.TemporalTx, name string, txNum uint64) ([]byte, bool, error) {
+	v, ok, err := tx.GetAsOf(kv.CustomTraceDomain, customTraceKey(name), txNum+1 /*history storing value BEFORE-change*/)
+	if err != nil {
+		return nil, false, fmt.Errorf("ReadCustomTrace %s: %w", name, err)
+	}
+	if !ok || len(v) == 0 {
+		return nil, false, nil
+	}
+	return v, true, nil
+}
+
+// WriteCustomTrace stores the result of the custom trace `name` for the txn `txNum`, the
+// empty result is written for the txns it produced nothing for
+func WriteCustomTrace(tx kv.TemporalPutDel, name string, result []byte, txNum uint64) error {
+	if result == nil {
+		result = []byte{}
+	}
+	if err := tx.DomainPut(kv.CustomTraceDomain, customTraceKey(name), result, txNum, nil, 0); err != nil {
+		return fmt.Errorf("WriteCustomTrace %s: %w", name, err)
+	}
+	return nil
+}
+
+// ReadCustomTraceProgress returns the txns the custom trace `name` was produced for: `fromTxNum..toTxNum`,
+// inclusive. The custom traces share the domain, but each of them is produced from its own point
+func ReadCustomTraceProgress(tx kv.TemporalGetter, name string) (fromTxNum, toTxNum uint64, ok bool, err error) {
+	v, _, err := tx.GetLatest(kv.CustomTraceDomain, customTraceProgressKey(name))
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("ReadCustomTraceProgress %s: %w", name, err)
+	}
+	if len(v) != 16 {
+		return 0, 0, false, nil
+	}
+	return binary.BigEndian.Uint64(v), binary.BigEndian.Uint64(v[8:]), true, nil
+}
+
+// WriteCustomTraceProgress stores the txns the custom trace `name` was produced for, see ReadCustomTraceProgress
+func WriteCustomTraceProgress(tx kv.TemporalPutDel, name string, fromTxNum, toTxNum uint64) error {
+	v := make([]byte, 16)
+	binary.BigEndian.PutUint64(v, fromTxNum)
+	binary.BigEndian.PutUint64(v[8:], toTxNum)
+	if err := tx.DomainPut(kv.CustomTraceDomain, customTraceProgressKey(name), v, toTxNum, nil, 0); err != nil {
+		return fmt.Errorf("WriteCustomTraceProgress %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteCustomTraceProgress makes the custom trace `name` not produced, its results are not served anymore
+func DeleteCustomTraceProgress(tx kv.TemporalPutDel, name string, txNum uint64) error {
+	if err := tx.DomainDel(kv.CustomTraceDomain, customTraceProgressKey(name), txNum, nil, 0); err != nil {
+		return fmt.Errorf("DeleteCustomTraceProgress %s: %w", name, err)
+	}
+	return nil
+}
+
+// customTraceKey - keys of the custom traces must be of the same length, the domain stores large values
+func customTraceKey(name string) []byte {
+	return crypto.Keccak256([]byte(name))
+}
+
+func customTraceProgressKey(name string) []byte {
+	return crypto.Keccak256([]byte(name), []byte("/progress"))
+}
+
 var (
 	receiptCacheKey = []byte{0x0}
 )
@@ -565,9 +565,42 @@ func LoadRemotePreverified(ctx context.Context) (err error) {
 		networkname.Chiado:     Chiado,
 		networkname.Hoodi:      Hoodi,
 	}
+	for networkName := range registeredToml {
+		addRegistered(networkName)
+	}
 	return
 }
 
+var (
+	// preverified lists and webseeds of the networks registered at runtime
+	registeredToml     = map[string][]byte{}
+	registeredWebseeds = map[string][]string{}
+)
+
+// RegisterPreverified registers the preverified snapshots list (in the same TOML
+// format as the built-in ones) and webseeds of a network defined at runtime
+// (e.g. loaded from a chain bundle). It survives LoadRemotePreverified, since
+// remote lists only cover the built-in networks. preverifiedToml must be
+// validated by the caller, like the built-in lists it panics on a bad one.
+func RegisterPreverified(networkName string, preverifiedToml []byte, webseeds []string) {
+	registeredToml[networkName] = preverifiedToml
+	registeredWebseeds[networkName] = webseeds
+	addRegistered(networkName)
+}
+
+// Registered returns true if networkName preverified list was registered at runtime
+func Registered(networkName string) bool {
+	_, ok := registeredToml[networkName]
+	return ok
+}
+
+func addRegistered(networkName string) {
+	knownPreverified[networkName] = fromEmbeddedToml(registeredToml[networkName])
+	if webseeds := registeredWebseeds[networkName]; len(webseeds) > 0 {
+		KnownWebseeds[networkName] = webseeds
+	}
+}
+
 func SetToml(networkName string, toml []byte, local bool) {
 	if _, ok := knownPreverified[networkName]; !ok {
 		return
@@ -596,6 +629,6 @@ func GetToml(networkName string) []byte {
 	case networkname.Hoodi:
 		return snapshothashes.Hoodi
 	default:
-		return nil
+		return registeredToml[networkName]
 	}
 }
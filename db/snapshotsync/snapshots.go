@@ -260,6 +260,9 @@ type DirtySegment struct {
 
 	canDelete atomic.Bool
 
+	retired       atomic.Bool // replaced by re-indexed segment: close files when last reader is gone
+	retiredClosed atomic.Bool
+
 	// only caplin state
 	filePath string
 }
@@ -523,6 +526,8 @@ func (s *RoTx) Close() {
 
 		if refCnt == 0 && src.canDelete.Load() {
 			src.closeAndRemoveFiles()
+		} else if refCnt == 0 && src.retired.Load() {
+			src.closeRetired()
 		}
 	}
 
@@ -556,6 +561,8 @@ type RoSnapshots struct {
 	ready     ready
 	operators map[snaptype.Enum]*retireOperators
 	alignMin  bool // do we want to align all visible segments to the minimum available
+
+	reindexing atomic.Bool // online re-index of .idx files is in progress
 }
 
 // NewRoSnapshots - opens all snapshots. But to simplify everything:
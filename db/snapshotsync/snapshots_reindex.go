@@ -0,0 +1,234 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshotsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/background"
+	"github.com/erigontech/erigon/common/dbg"
+	"github.com/erigontech/erigon/common/dir"
+	"github.com/erigontech/erigon/common/estimate"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/snaptype"
+	"github.com/erigontech/erigon/execution/chain"
+)
+
+var ErrReindexBusy = errors.New("block files re-index is in progress, try later")
+
+// HasFile - true if segment with given name (or name of its .idx file) is open
+func (s *RoSnapshots) HasFile(fileName string) bool {
+	return s.findDirtySegment(fileName) != nil
+}
+
+func (s *RoSnapshots) findDirtySegment(fileName string) (found *DirtySegment) {
+	s.dirtyLock.RLock()
+	defer s.dirtyLock.RUnlock()
+	for _, t := range s.enums {
+		s.dirty[t].Walk(func(segs []*DirtySegment) bool {
+			for _, seg := range segs {
+				if seg.Decompressor == nil {
+					continue
+				}
+				for _, fPath := range seg.FilePaths(s.dir) {
+					if filepath.Base(fPath) == fileName {
+						found = seg
+						return false
+					}
+				}
+			}
+			return true
+		})
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (s *RoSnapshots) reindexTargets(fileNames []string) ([]*DirtySegment, error) {
+	var targets []*DirtySegment
+	for _, fileName := range fileNames {
+		seg := s.findDirtySegment(fileName)
+		if seg == nil {
+			return nil, fmt.Errorf("file not found: %s", fileName)
+		}
+		if !slices.Contains(targets, seg) { // segment and its .idx
+			targets = append(targets, seg)
+		}
+	}
+	return targets, nil
+}
+
+// RebuildIndicesInBackground - re-builds .idx files of given segments while node keeps serving reads.
+// File name: segment (`v1.0-000000-000500-headers.seg`) or any of its .idx files.
+// Returns error if file not found or another re-index is in progress. Progress is reported to logs and diagnostics.
+func (s *RoSnapshots) RebuildIndicesInBackground(fileNames []string, tmpDir string, chainConfig *chain.Config) error {
+	if !s.reindexing.CompareAndSwap(false, true) {
+		return ErrReindexBusy
+	}
+	targets, err := s.reindexTargets(fileNames)
+	if err != nil {
+		s.reindexing.Store(false)
+		return err
+	}
+	go func() {
+		defer s.reindexing.Store(false)
+		if err := s.reindex(context.Background(), targets, tmpDir, chainConfig, estimate.IndexSnapshot.Workers()); err != nil {
+			s.logger.Warn("[snapshots] re-index", "err", err)
+		}
+	}()
+	return nil
+}
+
+// ReindexFiles - synchronous version of `RebuildIndicesInBackground`
+func (s *RoSnapshots) ReindexFiles(ctx context.Context, fileNames []string, tmpDir string, chainConfig *chain.Config, workers int) error {
+	if !s.reindexing.CompareAndSwap(false, true) {
+		return ErrReindexBusy
+	}
+	defer s.reindexing.Store(false)
+	targets, err := s.reindexTargets(fileNames)
+	if err != nil {
+		return err
+	}
+	return s.reindex(ctx, targets, tmpDir, chainConfig, workers)
+}
+
+// reindex - builds new .idx files over the old ones (index builder writes .tmp file and renames it),
+// opens new segments and replaces old segments by them under one lock: if any new segment can't be opened - nothing is replaced.
+// Readers which already hold old segments - keep using old (opened) files, old segments are closed when last of them is gone.
+func (s *RoSnapshots) reindex(ctx context.Context, targets []*DirtySegment, tmpDir string, chainConfig *chain.Config, workers int) error {
+	infos := make([]snaptype.FileInfo, len(targets))
+	for i, seg := range targets {
+		var ok bool
+		if infos[i], _, ok = snaptype.ParseFileName(s.dir, seg.FileName()); !ok {
+			return fmt.Errorf("re-index: can't parse file name %s", seg.FileName())
+		}
+	}
+
+	started := time.Now()
+	ps := background.NewProgressSet()
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	go func() {
+		logEvery := time.NewTicker(20 * time.Second)
+		defer logEvery.Stop()
+		for {
+			select {
+			case <-gCtx.Done():
+				return
+			case <-logEvery.C:
+				var m runtime.MemStats
+				dbg.ReadMemStats(&m)
+				sendDiagnostics(started, ps.DiagnosticsData(), m.Alloc, m.Sys)
+				s.logger.Info("[snapshots] Indexing", "progress", ps.String(), "total-indexing-time", time.Since(started).Round(time.Second).String(), "alloc", common.ByteCount(m.Alloc), "sys", common.ByteCount(m.Sys))
+			}
+		}
+	}()
+	for i, seg := range targets {
+		info := infos[i]
+		indexBuilder := s.IndexBuilder(seg.segType)
+		g.Go(func() error {
+			p := &background.Progress{}
+			ps.Add(p)
+			defer notifySegmentIndexingFinished(info.Name())
+			defer ps.Delete(p)
+			return seg.segType.BuildIndexes(gCtx, info, indexBuilder, chainConfig, tmpDir, p, log.LvlInfo, s.logger)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	fresh := make([]*DirtySegment, 0, len(targets))
+	closeFresh := func() {
+		for _, seg := range fresh {
+			seg.close()
+		}
+	}
+	for _, old := range targets {
+		seg := NewDirtySegment(old.segType, old.version, old.from, old.to, old.frozen)
+		fresh = append(fresh, seg)
+		if err := seg.Open(s.dir); err != nil {
+			closeFresh()
+			return err
+		}
+		if err := seg.openIdx(s.dir); err != nil {
+			closeFresh()
+			return err
+		}
+		if !seg.IsIndexed() {
+			closeFresh()
+			return fmt.Errorf("re-index: can't open indices of %s", seg.FileName())
+		}
+	}
+
+	var replaced []*DirtySegment
+	s.dirtyLock.Lock()
+	for i, old := range targets {
+		dirtySegments := s.dirty[old.segType.Enum()]
+		if cur, ok := dirtySegments.Get(old); !ok || cur != old {
+			s.logger.Warn("[snapshots] re-index: file was deleted while indexing", "file", old.FileName())
+			fresh[i].close()
+			continue
+		}
+		dirtySegments.Set(fresh[i])
+		// new index may have newer version in file name: old one is not needed anymore
+		newPaths := fresh[i].FilePaths(s.dir)
+		for _, fPath := range old.FilePaths(s.dir) {
+			if !slices.Contains(newPaths, fPath) {
+				if err := dir.RemoveFile(filepath.Join(s.dir, fPath)); err != nil {
+					s.logger.Debug("[snapshots] re-index: remove old index", "err", err, "file", fPath)
+				}
+			}
+		}
+		replaced = append(replaced, old)
+	}
+	s.dirtyLock.Unlock()
+	s.recalcVisibleFiles(s.alignMin)
+
+	// after `recalcVisibleFiles` new readers can't see replaced segments
+	for _, old := range replaced {
+		old.retire()
+	}
+	s.logger.Info("[snapshots] re-index done", "files", len(replaced), "took", time.Since(started).Round(time.Second))
+	return nil
+}
+
+// retire - closes files of segment replaced by re-index, when last reader is gone. Files are not removed: new segment uses them.
+func (s *DirtySegment) retire() {
+	s.retired.Store(true)
+	if s.refcount.Load() == 0 {
+		s.closeRetired()
+	}
+}
+
+func (s *DirtySegment) closeRetired() {
+	if s.retiredClosed.CompareAndSwap(false, true) {
+		s.close()
+	}
+}
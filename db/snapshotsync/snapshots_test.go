@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/erigontech/erigon/common/background"
 	dir2 "github.com/erigontech/erigon/common/dir"
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/common/math"
@@ -36,6 +37,7 @@ import (
 	"github.com/erigontech/erigon/db/snaptype"
 	"github.com/erigontech/erigon/db/snaptype2"
 	"github.com/erigontech/erigon/db/version"
+	"github.com/erigontech/erigon/execution/chain"
 	"github.com/erigontech/erigon/execution/chain/networkname"
 	chainspec "github.com/erigontech/erigon/execution/chain/spec"
 	"github.com/erigontech/erigon/node/ethconfig"
@@ -344,6 +346,65 @@ func TestDeleteSnapshots(t *testing.T) {
 	}
 }
 
+func TestReindexFiles(t *testing.T) {
+	logger := log.New()
+	dir, require := t.TempDir(), require.New(t)
+	_, err := snaptype.LoadSalt(dir, true, logger)
+	require.NoError(err)
+	for _, snT := range snaptype2.BlockSnapshotTypes {
+		createTestSegmentFile(t, 0, 10_000, snT.Enum(), dir, version.V1_0, logger)
+	}
+	s := NewRoSnapshots(ethconfig.BlocksFreezing{ChainName: networkname.Mainnet}, dir, snaptype2.BlockSnapshotTypes, true, logger)
+	defer s.Close()
+	require.NoError(s.OpenFolder())
+
+	var built []string
+	s.SetIndexBuilder(snaptype2.Headers, snaptype.IndexBuilderFunc(func(ctx context.Context, info snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, _ *background.Progress, _ log.Lvl, logger log.Logger) error {
+		built = append(built, info.Name())
+		idx, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+			KeyCount:   1,
+			BucketSize: 10,
+			Salt:       &salt,
+			TmpDir:     tmpDir,
+			IndexFile:  filepath.Join(dir, snaptype.IdxFileName(info.Version, info.From, info.To, snaptype2.Headers.Name())),
+			LeafSize:   8,
+		}, logger)
+		if err != nil {
+			return err
+		}
+		defer idx.Close()
+		idx.DisableFsync()
+		if err := idx.AddKey([]byte{1}, 0); err != nil {
+			return err
+		}
+		return idx.Build(ctx)
+	}))
+
+	view := s.View()
+	old, ok := view.Segment(snaptype2.Headers, 0)
+	require.True(ok)
+	require.True(s.HasFile("v1.0-000000-000010-headers.idx"))
+
+	// segment and its index are one target
+	require.NoError(s.ReindexFiles(context.Background(), []string{"v1.0-000000-000010-headers.seg", "v1.0-000000-000010-headers.idx"}, dir, nil, 1))
+	require.Equal([]string{"v1.0-000000-000010-headers.seg"}, built)
+	require.Error(s.ReindexFiles(context.Background(), []string{"v1.0-000010-000020-headers.seg"}, dir, nil, 1))
+
+	// reader which started before re-index - keeps using old files
+	require.NotNil(old.Src().Index())
+	require.NotNil(old.Src().Decompressor)
+	view.Close()
+	require.Nil(old.Src().Decompressor)
+
+	view = s.View()
+	defer view.Close()
+	fresh, ok := view.Segment(snaptype2.Headers, 0)
+	require.True(ok)
+	require.NotSame(old.Src(), fresh.Src())
+	require.True(fresh.IsIndexed())
+	require.Equal(uint64(1), fresh.Src().Index().KeyCount())
+}
+
 func TestRemoveOverlaps(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -0,0 +1,46 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build linux
+
+package snapstore
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/erigontech/erigon/common/dir"
+)
+
+// reflink - copy-on-write clone of file (FICLONE). Supported by btrfs, xfs, bcachefs.
+func reflink(src, dst string) error {
+	s, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	d, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlFileClone(int(d.Fd()), int(s.Fd())); err != nil {
+		_ = d.Close()
+		_ = dir.RemoveFile(dst)
+		return err
+	}
+	return d.Close()
+}
@@ -0,0 +1,421 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package snapstore - content-addressed store of immutable snapshot files, shared between many datadirs
+// on the same host. Objects are keyed by torrent infohash. Datadirs get hard-links (or reflinks) to objects,
+// so deleting a file from one datadir (retire/merge/rm-state-snapshots) only drops that datadir's link.
+// Object itself removed only by GC - when no datadir references it anymore.
+//
+// Layout:
+//
+//	<root>/LOCK
+//	<root>/objects/<ab>/<infohash>          - data
+//	<root>/objects/<ab>/<infohash>.torrent  - metainfo
+//	<root>/refs/<infohash>.refs             - files linked to object (1 per line): absolute path and file id, tab separated
+package snapstore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/gofrs/flock"
+
+	"github.com/erigontech/erigon/common/dir"
+	"github.com/erigontech/erigon/common/log/v3"
+)
+
+type Mode string
+
+const (
+	// ModeHardlink - datadir files are hard-links to objects. Requires datadirs and store on same filesystem.
+	// Refcount can be cross-checked by inode: live reference must be same file as object.
+	ModeHardlink Mode = "hardlink"
+	// ModeReflink - datadir files are copy-on-write clones of objects (btrfs, xfs with reflink=1).
+	ModeReflink Mode = "reflink"
+	// ModeAuto - try reflink, fallback to hardlink
+	ModeAuto Mode = "auto"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(s)); m {
+	case ModeHardlink, ModeReflink, ModeAuto:
+		return m, nil
+	case "":
+		return ModeAuto, nil
+	default:
+		return "", fmt.Errorf("unknown snapshot store mode: %q, expected one of: %s, %s, %s", s, ModeHardlink, ModeReflink, ModeAuto)
+	}
+}
+
+var ErrNotFound = errors.New("object not found in snapshot store")
+
+type Store struct {
+	root   string
+	mode   Mode
+	lock   *flock.Flock
+	logger log.Logger
+}
+
+func Open(root string, mode Mode, logger log.Logger) (*Store, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if mode == "" {
+		mode = ModeAuto
+	}
+	if err := os.MkdirAll(filepath.Join(root, "objects"), 0o775); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(root, "refs"), 0o775); err != nil {
+		return nil, err
+	}
+	return &Store{
+		root:   root,
+		mode:   mode,
+		lock:   flock.New(filepath.Join(root, "LOCK")),
+		logger: logger,
+	}, nil
+}
+
+func (s *Store) Root() string { return s.root }
+func (s *Store) Mode() Mode   { return s.mode }
+
+func (s *Store) objectPath(h metainfo.Hash) string {
+	hx := h.HexString()
+	return filepath.Join(s.root, "objects", hx[:2], hx)
+}
+func (s *Store) metainfoPath(h metainfo.Hash) string { return s.objectPath(h) + ".torrent" }
+func (s *Store) refsPath(h metainfo.Hash) string {
+	return filepath.Join(s.root, "refs", h.HexString()+".refs")
+}
+
+// withLock - store is shared by many processes (one per datadir), all mutations must go under file-lock
+func (s *Store) withLock(f func() error) error {
+	if err := s.lock.Lock(); err != nil {
+		return fmt.Errorf("snapstore lock: %w", err)
+	}
+	defer s.lock.Unlock()
+	return f()
+}
+
+func (s *Store) Has(h metainfo.Hash) (bool, error) {
+	return dir.FileExist(s.objectPath(h))
+}
+
+// LinkInto - materialize object `h` at `dst` (and it's metainfo at `dst.torrent`) and register `dst` as reference.
+// Returns false if store has no such object. Does nothing if `dst` already exists.
+func (s *Store) LinkInto(h metainfo.Hash, dst string) (linked bool, err error) {
+	dst, err = filepath.Abs(dst)
+	if err != nil {
+		return false, err
+	}
+	err = s.withLock(func() error {
+		obj := s.objectPath(h)
+		if exists, err := dir.FileExist(obj); err != nil || !exists {
+			return err
+		}
+		if exists, err := dir.FileExist(dst); err != nil || exists {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o775); err != nil {
+			return err
+		}
+		if err := s.link(obj, dst); err != nil {
+			return fmt.Errorf("linking %s -> %s: %w", obj, dst, err)
+		}
+		if exists, _ := dir.FileExist(s.metainfoPath(h)); exists {
+			if exists, _ := dir.FileExist(dst + ".torrent"); !exists {
+				if err := copyFile(s.metainfoPath(h), dst+".torrent"); err != nil {
+					return err
+				}
+			}
+		}
+		linked = true
+		return s.addRef(h, dst)
+	})
+	return linked, err
+}
+
+// Adopt - put complete and verified file `src` into store under key `h`. If store already has such object - `src` is
+// replaced by link to it (dedup). `src` is registered as reference.
+func (s *Store) Adopt(h metainfo.Hash, src string) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	return s.withLock(func() error {
+		obj := s.objectPath(h)
+		exists, err := dir.FileExist(obj)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err := os.MkdirAll(filepath.Dir(obj), 0o775); err != nil {
+				return err
+			}
+			if err := s.link(src, obj); err != nil {
+				return fmt.Errorf("adopting %s: %w", src, err)
+			}
+		} else if !s.isLiveRef(obj, s.findRef(h, src)) {
+			if err := s.replaceWithLink(obj, src); err != nil {
+				return fmt.Errorf("dedup %s: %w", src, err)
+			}
+		}
+		if exists, _ := dir.FileExist(s.metainfoPath(h)); !exists {
+			if exists, _ := dir.FileExist(src + ".torrent"); exists {
+				if err := copyFile(src+".torrent", s.metainfoPath(h)); err != nil {
+					return err
+				}
+			}
+		}
+		return s.addRef(h, src)
+	})
+}
+
+// Release - unregister `path` as reference of `h`. Doesn't remove anything: object will be removed by GC.
+func (s *Store) Release(h metainfo.Hash, path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	return s.withLock(func() error {
+		refs, err := s.readRefs(h)
+		if err != nil {
+			return err
+		}
+		return s.writeRefs(h, slices.DeleteFunc(refs, func(r ref) bool { return r.path == path }))
+	})
+}
+
+// Refs - live references of object. Reference is live if file still exists in datadir and is a link to object.
+// Datadir which deleted file (by merge, retire, rm-state-snapshots, etc...) automatically stops referencing object.
+func (s *Store) Refs(h metainfo.Hash) (live []string, err error) {
+	refs, err := s.readRefs(h)
+	if err != nil {
+		return nil, err
+	}
+	obj := s.objectPath(h)
+	for _, r := range refs {
+		if s.isLiveRef(obj, r) {
+			live = append(live, r.path)
+		}
+	}
+	return live, nil
+}
+
+type GCStats struct {
+	Objects     int
+	Removed     int
+	RemovedSize int64
+	StaleRefs   int
+}
+
+// GC - drop stale references and remove objects which have no live references.
+func (s *Store) GC(dryRun bool) (stats GCStats, err error) {
+	err = s.withLock(func() error {
+		return filepath.WalkDir(filepath.Join(s.root, "objects"), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || strings.HasSuffix(path, ".torrent") {
+				return nil
+			}
+			var h metainfo.Hash
+			if err := h.FromHexString(d.Name()); err != nil {
+				s.logger.Warn("[snapstore] unexpected file in objects dir", "path", path)
+				return nil
+			}
+			stats.Objects++
+			refs, err := s.readRefs(h)
+			if err != nil {
+				return err
+			}
+			live := make([]ref, 0, len(refs))
+			for _, r := range refs {
+				if s.isLiveRef(path, r) {
+					live = append(live, r)
+				}
+			}
+			stats.StaleRefs += len(refs) - len(live)
+			if len(live) > 0 {
+				if dryRun || len(live) == len(refs) {
+					return nil
+				}
+				return s.writeRefs(h, live)
+			}
+			if fi, err := d.Info(); err == nil {
+				stats.RemovedSize += fi.Size()
+			}
+			stats.Removed++
+			if dryRun {
+				return nil
+			}
+			s.logger.Debug("[snapstore] removing unreferenced object", "infohash", h.HexString())
+			if err := dir.RemoveFile(path); err != nil {
+				return err
+			}
+			if err := dir.RemoveFile(path + ".torrent"); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := dir.RemoveFile(s.refsPath(h)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		})
+	})
+	return stats, err
+}
+
+// ref - file linked to object. `id` is fileID of the file at the moment it was linked: reflink is separate inode, so
+// it's the only way to tell that file at `path` is still the clone of object and wasn't replaced by another one.
+type ref struct {
+	path string
+	id   string
+}
+
+func (s *Store) isLiveRef(obj string, r ref) bool {
+	refInfo, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	objInfo, err := os.Stat(obj)
+	if err != nil {
+		return false
+	}
+	if os.SameFile(refInfo, objInfo) {
+		return true
+	}
+	return s.mode != ModeHardlink && r.id != "" && fileID(refInfo) == r.id
+}
+
+// findRef - registered reference of `h` at `path`, or just `path` if it's not registered
+func (s *Store) findRef(h metainfo.Hash, path string) ref {
+	refs, err := s.readRefs(h)
+	if err != nil {
+		return ref{path: path}
+	}
+	if i := slices.IndexFunc(refs, func(r ref) bool { return r.path == path }); i >= 0 {
+		return refs[i]
+	}
+	return ref{path: path}
+}
+
+func (s *Store) link(src, dst string) error {
+	switch s.mode {
+	case ModeHardlink:
+		return os.Link(src, dst)
+	case ModeReflink:
+		return reflink(src, dst)
+	default:
+		if err := reflink(src, dst); err == nil {
+			return nil
+		}
+		return os.Link(src, dst)
+	}
+}
+
+// replaceWithLink - atomically replace `dst` by link to `obj`
+func (s *Store) replaceWithLink(obj, dst string) error {
+	tmp := dst + ".snapstore.tmp"
+	_ = dir.RemoveFile(tmp)
+	if err := s.link(obj, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		_ = dir.RemoveFile(tmp)
+		return err
+	}
+	return nil
+}
+
+func (s *Store) addRef(h metainfo.Hash, path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	r := ref{path: path, id: fileID(fi)}
+	refs, err := s.readRefs(h)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(refs, func(r ref) bool { return r.path == path })
+	if i < 0 {
+		return s.writeRefs(h, append(refs, r))
+	}
+	if refs[i] == r {
+		return nil
+	}
+	refs[i] = r
+	return s.writeRefs(h, refs)
+}
+
+// readRefs - refs written before file ids were recorded have empty id, they stay live only as hard-links
+func (s *Store) readRefs(h metainfo.Hash) ([]ref, error) {
+	data, err := os.ReadFile(s.refsPath(h))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var refs []ref
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			path, id, _ := strings.Cut(line, "\t")
+			refs = append(refs, ref{path: path, id: id})
+		}
+	}
+	return refs, sc.Err()
+}
+
+func (s *Store) writeRefs(h metainfo.Hash, refs []ref) error {
+	if len(refs) == 0 {
+		if err := dir.RemoveFile(s.refsPath(h)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, r := range refs {
+		buf.WriteString(r.path + "\t" + r.id + "\n")
+	}
+	tmp := s.refsPath(h) + ".tmp"
+	if err := dir.WriteFileWithFsync(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.refsPath(h))
+}
+
+func copyFile(from, to string) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	return dir.WriteFileWithFsync(to, data, 0o644)
+}
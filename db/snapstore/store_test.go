@@ -0,0 +1,147 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snapstore
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common/dir"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/snaptype"
+)
+
+func TestSharedBetweenDatadirs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	require := require.New(t)
+	root := t.TempDir()
+	s, err := Open(filepath.Join(root, "store"), ModeHardlink, log.New())
+	require.NoError(err)
+
+	h := snaptype.Hex2InfoHash("aa")
+	fileA := filepath.Join(root, "a", "snapshots", "v1.0-000000-000500-headers.seg")
+	fileB := filepath.Join(root, "b", "snapshots", "v1.0-000000-000500-headers.seg")
+	require.NoError(os.MkdirAll(filepath.Dir(fileA), 0o755))
+	require.NoError(os.WriteFile(fileA, []byte("data"), 0o644))
+	require.NoError(os.WriteFile(fileA+".torrent", []byte("metainfo"), 0o644))
+
+	// datadir A downloaded file - adopt it
+	require.NoError(s.Adopt(h, fileA))
+	has, err := s.Has(h)
+	require.NoError(err)
+	require.True(has)
+
+	// datadir B gets file without download
+	linked, err := s.LinkInto(h, fileB)
+	require.NoError(err)
+	require.True(linked)
+	data, err := os.ReadFile(fileB)
+	require.NoError(err)
+	require.Equal("data", string(data))
+	require.True(dir.FileNonZero(fileB + ".torrent"))
+
+	refs, err := s.Refs(h)
+	require.NoError(err)
+	require.Len(refs, 2)
+
+	// merge in datadir A deleted file - object still used by B
+	require.NoError(dir.RemoveFile(fileA))
+	stats, err := s.GC(false)
+	require.NoError(err)
+	require.Equal(0, stats.Removed)
+	require.Equal(1, stats.StaleRefs)
+	data, err = os.ReadFile(fileB)
+	require.NoError(err)
+	require.Equal("data", string(data))
+
+	// nobody uses it
+	require.NoError(s.Release(h, fileB))
+	stats, err = s.GC(false)
+	require.NoError(err)
+	require.Equal(1, stats.Removed)
+	has, err = s.Has(h)
+	require.NoError(err)
+	require.False(has)
+
+	// unknown object
+	linked, err = s.LinkInto(snaptype.Hex2InfoHash("bb"), fileB+"2")
+	require.NoError(err)
+	require.False(linked)
+}
+
+func TestAdoptDeduplicates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	require := require.New(t)
+	root := t.TempDir()
+	s, err := Open(filepath.Join(root, "store"), ModeHardlink, log.New())
+	require.NoError(err)
+
+	h := snaptype.Hex2InfoHash("aa")
+	fileA, fileB := filepath.Join(root, "a.seg"), filepath.Join(root, "b.seg")
+	require.NoError(os.WriteFile(fileA, []byte("data"), 0o644))
+	require.NoError(os.WriteFile(fileB, []byte("data"), 0o644))
+	require.NoError(s.Adopt(h, fileA))
+	require.NoError(s.Adopt(h, fileB))
+
+	fiA, err := os.Stat(fileA)
+	require.NoError(err)
+	fiB, err := os.Stat(fileB)
+	require.NoError(err)
+	require.True(os.SameFile(fiA, fiB))
+}
+
+func TestReflinkRefFollowsFileID(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	require := require.New(t)
+	root := t.TempDir()
+	s, err := Open(filepath.Join(root, "store"), ModeReflink, log.New())
+	require.NoError(err)
+
+	// clone is separate file of same content: simulate it by copy, as tmpfs/ext4 have no reflinks
+	h := snaptype.Hex2InfoHash("aa")
+	file := filepath.Join(root, "a.seg")
+	require.NoError(os.MkdirAll(filepath.Dir(s.objectPath(h)), 0o755))
+	require.NoError(os.WriteFile(s.objectPath(h), []byte("data"), 0o644))
+	require.NoError(copyFile(s.objectPath(h), file))
+	require.NoError(s.addRef(h, file))
+
+	refs, err := s.Refs(h)
+	require.NoError(err)
+	require.Equal([]string{file}, refs)
+
+	// file replaced by another one of same size - it's not a clone of object anymore
+	require.NoError(os.WriteFile(file+".tmp", []byte("atad"), 0o644))
+	require.NoError(os.Rename(file+".tmp", file))
+	refs, err = s.Refs(h)
+	require.NoError(err)
+	require.Empty(refs)
+
+	stats, err := s.GC(false)
+	require.NoError(err)
+	require.Equal(1, stats.Removed)
+	require.Equal(1, stats.StaleRefs)
+}
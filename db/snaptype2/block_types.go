@@ -40,14 +40,15 @@ import (
 	"github.com/erigontech/erigon/execution/types"
 )
 
-func init() {
-	ethereumTypes := append(BlockSnapshotTypes, snaptype.CaplinSnapshotTypes...)
+// EthereumSnapshotTypes are the snapshot types of the non-bor chains
+var EthereumSnapshotTypes = append(BlockSnapshotTypes, snaptype.CaplinSnapshotTypes...)
 
-	snapcfg.RegisterKnownTypes(networkname.Mainnet, ethereumTypes)
-	snapcfg.RegisterKnownTypes(networkname.Sepolia, ethereumTypes)
-	snapcfg.RegisterKnownTypes(networkname.Gnosis, ethereumTypes)
-	snapcfg.RegisterKnownTypes(networkname.Chiado, ethereumTypes)
-	snapcfg.RegisterKnownTypes(networkname.Hoodi, ethereumTypes)
+func init() {
+	snapcfg.RegisterKnownTypes(networkname.Mainnet, EthereumSnapshotTypes)
+	snapcfg.RegisterKnownTypes(networkname.Sepolia, EthereumSnapshotTypes)
+	snapcfg.RegisterKnownTypes(networkname.Gnosis, EthereumSnapshotTypes)
+	snapcfg.RegisterKnownTypes(networkname.Chiado, EthereumSnapshotTypes)
+	snapcfg.RegisterKnownTypes(networkname.Hoodi, EthereumSnapshotTypes)
 }
 
 var Enums = struct {
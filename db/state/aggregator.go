@@ -49,6 +49,7 @@ import (
 	"github.com/erigontech/erigon/db/kv/order"
 	"github.com/erigontech/erigon/db/kv/rawdbv3"
 	"github.com/erigontech/erigon/db/kv/stream"
+	"github.com/erigontech/erigon/db/recsplit/multiencseq"
 	"github.com/erigontech/erigon/db/state/changeset"
 	"github.com/erigontech/erigon/db/state/statecfg"
 	"github.com/erigontech/erigon/db/version"
@@ -96,6 +97,13 @@ type Aggregator struct {
 	produce bool
 
 	checker *DependencyIntegrityChecker
+
+	retention      statecfg.Retentions // histories which are deleted after some time. see `PruneExpiredFiles`
+	retentionTxNum RetentionTxNum
+
+	// retiredFiles - frozen files removed from dirtyFiles while readers may still use them (frozen files are not ref-counted).
+	// Closed at Aggregator.Close. Protected by dirtyFilesLock.
+	retiredFiles []*FilesItem
 }
 
 func newAggregator(ctx context.Context, dirs datadir.Dirs, stepSize, stepsInFrozenFile, reorgBlockDepth uint64, db kv.RoDB, logger log.Logger) (*Aggregator, error) {
@@ -404,6 +412,10 @@ func (a *Aggregator) Close() {
 	defer a.dirtyFilesLock.Unlock()
 	a.closeDirtyFiles()
 	a.recalcVisibleFiles(a.dirtyFilesEndTxNumMinimax())
+	for _, item := range a.retiredFiles {
+		item.closeFiles()
+	}
+	a.retiredFiles = nil
 }
 
 func (a *Aggregator) closeDirtyFiles() {
@@ -522,21 +534,7 @@ func (a *Aggregator) BuildMissedAccessors(ctx context.Context, workers int) erro
 
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(workers)
-	go func() {
-		logEvery := time.NewTicker(20 * time.Second)
-		defer logEvery.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-logEvery.C:
-				var m runtime.MemStats
-				dbg.ReadMemStats(&m)
-				sendDiagnostics(startIndexingTime, ps.DiagnosticsData(), m.Alloc, m.Sys)
-				a.logger.Info("[snapshots] Indexing", "progress", ps.String(), "total-indexing-time", time.Since(startIndexingTime).Round(time.Second).String(), "alloc", common.ByteCount(m.Alloc), "sys", common.ByteCount(m.Sys))
-			}
-		}
-	}()
+	go a.logIndexingProgress(ctx, ps, startIndexingTime)
 
 	rotx := a.DebugBeginDirtyFilesRo()
 	defer rotx.Close()
@@ -567,6 +565,23 @@ func (a *Aggregator) BuildMissedAccessors(ctx context.Context, workers int) erro
 	return nil
 }
 
+// logIndexingProgress - reports progress of accessors building to logs and diagnostics until ctx is done
+func (a *Aggregator) logIndexingProgress(ctx context.Context, ps *background.ProgressSet, startIndexingTime time.Time) {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-logEvery.C:
+			var m runtime.MemStats
+			dbg.ReadMemStats(&m)
+			sendDiagnostics(startIndexingTime, ps.DiagnosticsData(), m.Alloc, m.Sys)
+			a.logger.Info("[snapshots] Indexing", "progress", ps.String(), "total-indexing-time", time.Since(startIndexingTime).Round(time.Second).String(), "alloc", common.ByteCount(m.Alloc), "sys", common.ByteCount(m.Sys))
+		}
+	}
+}
+
 func sendDiagnostics(startIndexingTime time.Time, indexPercent map[string]int, alloc uint64, sys uint64) {
 	segmentsStats := make([]diaglib.SnapshotSegmentIndexingStatistics, 0, len(indexPercent))
 	for k, v := range indexPercent {
@@ -998,6 +1013,9 @@ func (at *AggregatorRoTx) PruneSmallBatches(ctx context.Context, timeout time.Du
 	if dbg.NoPrune() {
 		return false, nil
 	}
+	if _, err := at.a.PruneExpiredFiles(tx); err != nil {
+		return false, err
+	}
 	// On tip-of-chain timeout is about `3sec`
 	//  On tip of chain:     must be real-time - prune by small batches and prioritize exact-`timeout`
 	//  Not on tip of chain: must be aggressive (prune as much as possible) by bigger batches
@@ -1741,6 +1759,47 @@ func (at *AggregatorRoTx) FileStream(name kv.Domain, fromTxNum, toTxNum uint64)
 	return NewSegStreamReader(r, -1), nil
 }
 
+// IndexFileStream - visit all (key, txNum) pairs of standalone inverted index (logs, traces) which are in files and in [fromTxNum, toTxNum).
+// Keys are visited in file order: sorted inside file, but not across files. Designed for bulk export without DB.
+func (at *AggregatorRoTx) IndexFileStream(ctx context.Context, name kv.InvertedIdx, fromTxNum, toTxNum uint64, f func(k []byte, txNum uint64) error) error {
+	iit := at.searchII(name)
+	if iit == nil {
+		return fmt.Errorf("IndexFileStream: inverted index not found: %s", name)
+	}
+	var seq multiencseq.SequenceReader
+	for _, item := range iit.files {
+		if item.endTxNum <= fromTxNum || item.startTxNum >= toTxNum {
+			continue
+		}
+		r := iit.dataReader(item.src.decompressor)
+		r.Reset(0)
+		for r.HasNext() {
+			k, _ := r.Next(nil)
+			encodedSeq, _ := r.Next(nil)
+			seq.Reset(item.startTxNum, encodedSeq)
+			it := seq.Iterator(int(fromTxNum))
+			for it.HasNext() {
+				txNum, err := it.Next()
+				if err != nil {
+					return err
+				}
+				if txNum >= toTxNum {
+					break
+				}
+				if err := f(k, txNum); err != nil {
+					return err
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	return nil
+}
+
 // AggregatorRoTx guarantee consistent View of files ("snapshots isolation" level https://en.wikipedia.org/wiki/Snapshot_isolation):
 //   - long-living consistent view of all files (no limitations)
 //   - hiding garbage and files overlaps
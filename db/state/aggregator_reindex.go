@@ -0,0 +1,262 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	btree2 "github.com/tidwall/btree"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon/common/background"
+	"github.com/erigontech/erigon/common/dir"
+	"github.com/erigontech/erigon/common/estimate"
+	"github.com/erigontech/erigon/db/state/statecfg"
+)
+
+var ErrReindexBusy = errors.New("files merge or re-index is in progress, try later")
+
+// reindexTarget - data file which accessors must be re-built
+type reindexTarget struct {
+	name       string
+	dirtyFiles *btree2.BTreeG[*FilesItem]
+	item       *FilesItem
+	accessors  statecfg.Accessors
+	build      func(ctx context.Context, g *errgroup.Group, ps *background.ProgressSet)
+	open       func() error // opens files of new (replacing) item
+}
+
+// RebuildAccessorsInBackground - re-builds accessors (.bt/.kvei/.kvi/.vi/.efi) of given files while node keeps serving reads.
+// File name: data file (`v1.0-accounts.0-64.kv`) or any of its accessors. Empty list: build all missed accessors.
+// Returns error if file not found or another re-index/merge is in progress. Progress is reported to logs and diagnostics.
+func (a *Aggregator) RebuildAccessorsInBackground(fileNames []string) error {
+	if len(fileNames) == 0 {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			if err := a.BuildMissedAccessors(a.ctx, estimate.IndexSnapshot.Workers()); err != nil {
+				a.logger.Warn("[snapshots] build missed accessors", "err", err)
+			}
+		}()
+		return nil
+	}
+
+	if !a.mergingFiles.CompareAndSwap(false, true) { // merge must not delete files which we are indexing
+		return ErrReindexBusy
+	}
+	a.dirtyFilesLock.Lock()
+	targets, err := a.reindexTargets(fileNames)
+	a.dirtyFilesLock.Unlock()
+	if err != nil {
+		a.mergingFiles.Store(false)
+		return err
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer a.mergingFiles.Store(false)
+		if err := a.reindex(a.ctx, targets, estimate.IndexSnapshot.Workers()); err != nil {
+			a.logger.Warn("[snapshots] re-index", "err", err)
+		}
+	}()
+	return nil
+}
+
+// ReindexFiles - synchronous version of `RebuildAccessorsInBackground`
+func (a *Aggregator) ReindexFiles(ctx context.Context, fileNames []string, workers int) error {
+	if !a.mergingFiles.CompareAndSwap(false, true) {
+		return ErrReindexBusy
+	}
+	defer a.mergingFiles.Store(false)
+	a.dirtyFilesLock.Lock()
+	targets, err := a.reindexTargets(fileNames)
+	a.dirtyFilesLock.Unlock()
+	if err != nil {
+		return err
+	}
+	return a.reindex(ctx, targets, workers)
+}
+
+func (a *Aggregator) reindexTargets(fileNames []string) (targets []*reindexTarget, err error) {
+	for _, fileName := range fileNames {
+		t := a.reindexTarget(fileName)
+		if t == nil {
+			return nil, fmt.Errorf("file not found: %s", fileName)
+		}
+		if slices.ContainsFunc(targets, func(prev *reindexTarget) bool { return prev.item == t.item }) { // data file and its accessor
+			continue
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+func (a *Aggregator) reindexTarget(fileName string) *reindexTarget {
+	for _, d := range a.d {
+		if d == nil || d.Disable {
+			continue
+		}
+		if item := findDirtyFile(d.dirtyFiles, a.dirs.Snap, fileName); item != nil {
+			files := &MissedAccessorDomainFiles{
+				files:   MissedFilesMap{},
+				history: &MissedAccessorHistoryFiles{files: MissedFilesMap{}, ii: &MissedAccessorIIFiles{files: MissedFilesMap{}}},
+			}
+			if d.Accessors.Has(statecfg.AccessorBTree) {
+				files.files[statecfg.AccessorBTree] = []*FilesItem{item}
+			}
+			if d.Accessors.Has(statecfg.AccessorHashMap) {
+				files.files[statecfg.AccessorHashMap] = []*FilesItem{item}
+			}
+			return &reindexTarget{name: fileName, dirtyFiles: d.dirtyFiles, item: item, accessors: d.Accessors, open: d.openDirtyFiles,
+				build: func(ctx context.Context, g *errgroup.Group, ps *background.ProgressSet) {
+					d.BuildMissedAccessors(ctx, g, ps, files)
+				}}
+		}
+		h := d.History
+		if item := findDirtyFile(h.dirtyFiles, a.dirs.Snap, fileName); item != nil {
+			files := &MissedAccessorHistoryFiles{files: MissedFilesMap{statecfg.AccessorHashMap: {item}}, ii: &MissedAccessorIIFiles{files: MissedFilesMap{}}}
+			return &reindexTarget{name: fileName, dirtyFiles: h.dirtyFiles, item: item, accessors: h.Accessors, open: h.openDirtyFiles,
+				build: func(ctx context.Context, g *errgroup.Group, ps *background.ProgressSet) {
+					h.BuildMissedAccessors(ctx, g, ps, files)
+				}}
+		}
+		if t := a.iiReindexTarget(h.InvertedIndex, fileName); t != nil {
+			return t
+		}
+	}
+	for _, ii := range a.iis {
+		if ii.Disable {
+			continue
+		}
+		if t := a.iiReindexTarget(ii, fileName); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+func (a *Aggregator) iiReindexTarget(ii *InvertedIndex, fileName string) *reindexTarget {
+	item := findDirtyFile(ii.dirtyFiles, a.dirs.Snap, fileName)
+	if item == nil {
+		return nil
+	}
+	files := &MissedAccessorIIFiles{files: MissedFilesMap{statecfg.AccessorHashMap: {item}}}
+	return &reindexTarget{name: fileName, dirtyFiles: ii.dirtyFiles, item: item, accessors: ii.Accessors, open: ii.openDirtyFiles,
+		build: func(ctx context.Context, g *errgroup.Group, ps *background.ProgressSet) {
+			ii.BuildMissedAccessors(ctx, g, ps, files)
+		}}
+}
+
+func findDirtyFile(dirtyFiles *btree2.BTreeG[*FilesItem], baseDir, fileName string) (found *FilesItem) {
+	dirtyFiles.Walk(func(items []*FilesItem) bool {
+		for _, item := range items {
+			if item.decompressor == nil {
+				continue
+			}
+			for _, fPath := range item.FilePaths(baseDir) {
+				if filepath.Base(fPath) == fileName {
+					found = item
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// reindex - builds new accessors next to the old ones (accessor builders write .tmp file and rename it),
+// then replaces items in dirtyFiles by new items and re-calculates visibleFiles.
+// Items of all targets are replaced under one lock: if any new item can't be opened - nothing is replaced.
+// Readers which already hold old items - keep using old (opened) files.
+// Caller must hold `mergingFiles` - merge must not delete files which are indexed.
+func (a *Aggregator) reindex(ctx context.Context, targets []*reindexTarget, workers int) error {
+	started := time.Now()
+	ps := background.NewProgressSet()
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	go a.logIndexingProgress(ctx, ps, started)
+	for _, t := range targets {
+		t.build(ctx, g, ps)
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	defer a.onFilesChange(nil)
+
+	a.dirtyFilesLock.Lock()
+	defer a.dirtyFilesLock.Unlock()
+
+	swapped := make([]*reindexTarget, 0, len(targets))
+	fresh := make([]*FilesItem, 0, len(targets))
+	rollback := func() {
+		for i, t := range swapped {
+			fresh[i].closeFiles()
+			t.dirtyFiles.Set(t.item)
+		}
+	}
+	for _, t := range targets {
+		if cur, ok := t.dirtyFiles.Get(t.item); !ok || cur != t.item {
+			a.logger.Warn("[snapshots] re-index: file was deleted while indexing", "file", t.name)
+			continue
+		}
+		item := newFilesItem(t.item.startTxNum, t.item.endTxNum, a.stepSize, a.stepsInFrozenFile)
+		t.dirtyFiles.Set(item)
+		swapped, fresh = append(swapped, t), append(fresh, item)
+	}
+	for i, t := range swapped {
+		if err := t.open(); err != nil {
+			rollback()
+			return err
+		}
+		if !checkForVisibility(fresh[i], t.accessors, false) { // open failed: keep old items
+			rollback()
+			return fmt.Errorf("re-index: can't open %s", t.name)
+		}
+	}
+	for i, t := range swapped {
+		// new accessor may have newer version in file name: old one is not needed anymore
+		newPaths := fresh[i].FilePaths(a.dirs.Snap)
+		for _, fPath := range t.item.FilePaths(a.dirs.Snap) {
+			if !slices.Contains(newPaths, fPath) {
+				if err := dir.RemoveFile(filepath.Join(a.dirs.Snap, fPath)); err != nil {
+					a.logger.Debug("[snapshots] re-index: remove old accessor", "err", err, "file", fPath)
+				}
+			}
+		}
+	}
+	a.recalcVisibleFiles(a.dirtyFilesEndTxNumMinimax())
+
+	// after `recalcVisibleFiles` new readers can't see replaced items.
+	// frozen items are not ref-counted by readers - keep them open until Aggregator.Close
+	for _, t := range swapped {
+		if !t.item.frozen && t.item.refcount.Load() == 0 {
+			t.item.closeFiles()
+			continue
+		}
+		a.retiredFiles = append(a.retiredFiles, t.item)
+	}
+	a.logger.Info("[snapshots] re-index done", "files", len(swapped), "took", time.Since(started).Round(time.Second))
+	return nil
+}
@@ -479,3 +479,37 @@ func setupAggSnapRepo(t *testing.T, dirs datadir.Dirs, genRepo func(stepSize uin
 		Schema:                 schema,
 	}, log.New())
 }
+
+func TestAggregator_PruneExpiredFiles(t *testing.T) {
+	stepSize := uint64(10)
+	_, agg := testDbAndAggregatorv3(t, stepSize)
+
+	generateAccountsFile(t, agg.Dirs(), []testFileRange{{0, 1}, {1, 2}, {2, 3}})
+	generateCodeFile(t, agg.Dirs(), []testFileRange{{0, 1}, {1, 2}, {2, 3}})
+	generateStorageFile(t, agg.Dirs(), []testFileRange{{0, 1}, {1, 2}, {2, 3}})
+	generateCommitmentFile(t, agg.Dirs(), []testFileRange{{0, 1}, {1, 2}, {2, 3}})
+	require.NoError(t, agg.OpenFolder())
+
+	deleted, err := agg.PruneExpiredFiles(nil)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+
+	agg.SetRetention(statecfg.Retentions{kv.AccountsHistoryIdx: {Blocks: 1}}, func(tx kv.Tx, r statecfg.Retention) (uint64, error) {
+		return 2*stepSize + 5, nil
+	})
+	deleted, err = agg.PruneExpiredFiles(nil)
+	require.NoError(t, err)
+	require.Len(t, deleted, 8) // .v, .vi, .ef, .efi of 2 steps
+
+	aggTx := agg.BeginFilesRo()
+	defer aggTx.Close()
+	require.Len(t, aggTx.d[kv.AccountsDomain].files, 3) // latest state is not affected
+	require.Len(t, aggTx.d[kv.AccountsDomain].ht.files, 1)
+	require.Len(t, aggTx.d[kv.AccountsDomain].ht.iit.files, 1)
+	require.Equal(t, 2*stepSize, aggTx.d[kv.AccountsDomain].ht.files[0].startTxNum)
+	require.Len(t, aggTx.d[kv.StorageDomain].ht.files, 3)
+
+	exist, err := dir.FileExist(filepath.Join(agg.Dirs().SnapHistory, "v1.0-accounts.0-1.v"))
+	require.NoError(t, err)
+	require.False(t, exist)
+}
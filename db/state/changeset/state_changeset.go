@@ -186,6 +186,9 @@ func (d *StateChangeSet) SerializeKeys(out []byte) []byte {
 func DeserializeKeys(in []byte) [kv.DomainLen][]kv.DomainEntryDiff {
 	var ret [kv.DomainLen][]kv.DomainEntryDiff
 	for i := range ret {
+		if len(in) == 0 { // written before the domains which follow were added
+			break
+		}
 		diffSetLen := binary.BigEndian.Uint32(in)
 		in = in[4:]
 		ret[i] = DeserializeDiffSet(in[:diffSetLen])
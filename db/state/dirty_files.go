@@ -256,6 +256,31 @@ func (i *FilesItem) closeFilesAndRemove() {
 	}
 }
 
+// removeFiles - removes files from disk without closing them: on unix opened (mmap'ed) files stay readable until close
+func (i *FilesItem) removeFiles() {
+	var paths []string
+	if i.decompressor != nil {
+		paths = append(paths, i.decompressor.FilePath())
+	}
+	if i.index != nil {
+		paths = append(paths, i.index.FilePath())
+	}
+	if i.bindex != nil {
+		paths = append(paths, i.bindex.FilePath())
+	}
+	if i.existence != nil {
+		paths = append(paths, i.existence.FilePath)
+	}
+	for _, fPath := range paths {
+		if err := dir.RemoveFile(fPath); err != nil {
+			log.Trace("remove", "err", err, "file", fPath)
+		}
+		if err := dir.RemoveFile(fPath + ".torrent"); err != nil {
+			log.Trace("remove", "err", err, "file", fPath+".torrent")
+		}
+	}
+}
+
 func filterDirtyFiles(fileNames []string, stepSize, stepsInFrozenFile uint64, filenameBase, ext string, logger log.Logger) (res []*FilesItem) {
 	re := regexp.MustCompile(`^v(\d+(?:\.\d+)?)-` + filenameBase + `\.(\d+)-(\d+)\.` + ext + `$`)
 	var err error
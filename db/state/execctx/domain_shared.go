@@ -370,7 +370,7 @@ func (sd *SharedDomains) DomainPut(domain kv.Domain, roTx kv.TemporalTx, k, v []
 		if bytes.Equal(prevVal, v) {
 			return nil
 		}
-	case kv.RCacheDomain, kv.CommitmentDomain:
+	case kv.RCacheDomain, kv.CustomTraceDomain, kv.CommitmentDomain:
 		//noop
 	default:
 		if bytes.Equal(prevVal, v) {
@@ -67,6 +67,11 @@ func (at *AggregatorRoTx) IntegrityInvertedIndexAllValuesAreInRange(ctx context.
 		if err != nil {
 			return err
 		}
+	case kv.CustomTraceHistoryIdx:
+		err := at.d[kv.CustomTraceDomain].ht.iit.IntegrityInvertedIndexAllValuesAreInRange(ctx, failFast, fromStep)
+		if err != nil {
+			return err
+		}
 	default:
 		// check the ii
 		if v := at.searchII(name); v != nil {
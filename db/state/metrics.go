@@ -118,5 +118,13 @@ var (
 			metrics.GetOrCreateSummary(`kv_get{level="L4",domain="rcache"}`),
 			metrics.GetOrCreateSummary(`kv_get{level="recent",domain="rcache"}`),
 		},
+		kv.CustomTraceDomain: {
+			metrics.GetOrCreateSummary(`kv_get{level="L0",domain="customtrace"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L1",domain="customtrace"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L2",domain="customtrace"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L3",domain="customtrace"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L4",domain="customtrace"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="recent",domain="customtrace"}`),
+		},
 	}
 )
@@ -0,0 +1,131 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	btree2 "github.com/tidwall/btree"
+
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/state/statecfg"
+)
+
+// RetentionTxNum - converts retention to first txNum which must be kept.
+// Aggregator knows nothing about blocks and their timestamps - it's implemented by app-level code.
+type RetentionTxNum func(tx kv.Tx, r statecfg.Retention) (keepFromTxNum uint64, err error)
+
+// SetRetention - enables deletion of history files which are older than retention. Enforced by `PruneSmallBatches`.
+// Latest state (domain .kv files) is never deleted.
+func (a *Aggregator) SetRetention(rs statecfg.Retentions, keepFrom RetentionTxNum) {
+	a.retention, a.retentionTxNum = rs, keepFrom
+}
+
+func (a *Aggregator) Retention() statecfg.Retentions { return a.retention }
+
+// expiredFiles - files which entirely cover txNums before `keepFromTxNum`
+func expiredFiles(dirtyFiles *btree2.BTreeG[*FilesItem], keepFromTxNum uint64) (outs []*FilesItem) {
+	dirtyFiles.Walk(func(items []*FilesItem) bool {
+		for _, item := range items {
+			if item.endTxNum > keepFromTxNum { // sorted by endTxNum
+				return false
+			}
+			outs = append(outs, item)
+		}
+		return true
+	})
+	return outs
+}
+
+// PruneExpiredFiles - deletes files of histories and inverted indices which are entirely older than retention.
+// Files are deleted as a whole: merged file lives until its last step is expired.
+func (a *Aggregator) PruneExpiredFiles(tx kv.Tx) (deleted []string, err error) {
+	if len(a.retention) == 0 || a.retentionTxNum == nil {
+		return nil, nil
+	}
+	if a.mergingFiles.Load() { // merge or re-index expects files to stay. will delete next time
+		return nil, nil
+	}
+	keepFrom := make(map[kv.InvertedIdx]uint64, len(a.retention))
+	for idx, r := range a.retention {
+		if keepFrom[idx], err = a.retentionTxNum(tx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	a.dirtyFilesLock.Lock()
+	defer a.dirtyFilesLock.Unlock()
+
+	type expired struct {
+		dirtyFiles   *btree2.BTreeG[*FilesItem]
+		outs         []*FilesItem
+		filenameBase string
+	}
+	var toDelete []expired
+	for _, d := range a.d {
+		if d == nil || d.Disable {
+			continue
+		}
+		keepFromTxNum, ok := keepFrom[d.History.HistoryIdx]
+		if !ok {
+			continue
+		}
+		toDelete = append(toDelete,
+			expired{d.History.dirtyFiles, expiredFiles(d.History.dirtyFiles, keepFromTxNum), d.History.FilenameBase},
+			expired{d.History.InvertedIndex.dirtyFiles, expiredFiles(d.History.InvertedIndex.dirtyFiles, keepFromTxNum), d.History.FilenameBase},
+		)
+	}
+	for _, ii := range a.iis {
+		if ii.Disable {
+			continue
+		}
+		keepFromTxNum, ok := keepFrom[ii.Name]
+		if !ok {
+			continue
+		}
+		toDelete = append(toDelete, expired{ii.dirtyFiles, expiredFiles(ii.dirtyFiles, keepFromTxNum), ii.FilenameBase})
+	}
+
+	for _, e := range toDelete {
+		for _, item := range e.outs {
+			deleted = append(deleted, item.FilePaths(a.dirs.Snap)...)
+		}
+	}
+	if len(deleted) == 0 {
+		return nil, nil
+	}
+
+	// Blocking-Notification of downstream (like Downloader) before deletion - otherwise Downloader may re-create deleted file
+	a.onFilesDelete(deleted)
+	for _, e := range toDelete {
+		var notFrozen []*FilesItem
+		for _, item := range e.outs {
+			if !item.frozen {
+				notFrozen = append(notFrozen, item)
+				continue
+			}
+			// frozen files are not ref-counted by readers: can't close them while any reader is alive.
+			// remove from disk, but keep opened (mmap'ed) until Aggregator.Close
+			e.dirtyFiles.Delete(item)
+			item.canDelete.Store(true)
+			item.removeFiles()
+			a.retiredFiles = append(a.retiredFiles, item)
+		}
+		deleteMergeFile(e.dirtyFiles, notFrozen, e.filenameBase, a.logger)
+	}
+	a.recalcVisibleFiles(a.dirtyFilesEndTxNumMinimax())
+	a.logger.Info("[snapshots] deleted expired history files", "files", len(deleted), "retention", a.retention.String())
+	return deleted, nil
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	randOld "math/rand"
 	"math/rand/v2"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -592,3 +593,51 @@ func TestAggregatorV3_SharedDomains(t *testing.T) {
 		require.Equal(t, roots[i], rh)
 	}
 }
+
+func TestAggregator_ReindexFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	stepSize := uint64(10)
+	db, agg := testDbAggregatorWithFiles(t, &testAggConfig{stepSize: stepSize})
+	ctx := context.Background()
+	keys, _ := generateInputData(t, length.Addr, 5, int(stepSize)*32)
+
+	tx, err := db.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac := state.AggTx(tx)
+	files := ac.Files(kv.AccountsDomain)
+	require.NotEmpty(t, files)
+	before, ok, _, _, err := ac.DebugGetLatestFromFiles(kv.AccountsDomain, keys[0], math.MaxUint64)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	efFiles, err := filepath.Glob(filepath.Join(agg.Dirs().SnapIdx, "*-accounts.*.ef"))
+	require.NoError(t, err)
+	require.NotEmpty(t, efFiles)
+
+	err = agg.ReindexFiles(ctx, []string{filepath.Base(files[len(files)-1].Fullpath()), filepath.Base(efFiles[0])}, 2)
+	require.NoError(t, err)
+	require.Error(t, agg.ReindexFiles(ctx, []string{"v1.0-accounts.1000-1001.kv"}, 2))
+
+	// reader which started before re-index - keeps using old files
+	v, ok, _, _, err := ac.DebugGetLatestFromFiles(kv.AccountsDomain, keys[0], math.MaxUint64)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, before, v)
+	tx.Rollback()
+
+	tx, err = db.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac = state.AggTx(tx)
+	require.Len(t, ac.Files(kv.AccountsDomain), len(files))
+	v, ok, _, _, err = ac.DebugGetLatestFromFiles(kv.AccountsDomain, keys[0], math.MaxUint64)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, before, v)
+	_, ok, err = tx.HistorySeek(kv.AccountsDomain, keys[0], 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
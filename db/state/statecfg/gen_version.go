@@ -87,6 +87,8 @@ func goStruct(dom string) string {
 		return "ReceiptDomain"
 	case "rcache":
 		return "RCacheDomain"
+	case "customtrace":
+		return "CustomTraceDomain"
 	case "logaddrs":
 		return "LogAddrIdx"
 	case "logtopics":
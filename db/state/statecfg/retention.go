@@ -0,0 +1,147 @@
+package statecfg
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon/db/kv"
+)
+
+// Retention - how much history of Domain (or InvertedIndex) to keep.
+// History is deleted only by whole files: effective retention is rounded up to files boundaries (steps).
+type Retention struct {
+	Blocks uint64        // keep history of `Blocks` most recent blocks
+	Age    time.Duration // keep history of blocks not older than `Age`. If both set - keep history while any of them holds.
+}
+
+func (r Retention) String() string {
+	switch {
+	case r.Age > 0 && r.Blocks > 0:
+		return fmt.Sprintf("%d/%s", r.Blocks, formatAge(r.Age))
+	case r.Age > 0:
+		return formatAge(r.Age)
+	default:
+		return strconv.FormatUint(r.Blocks, 10)
+	}
+}
+
+// Retentions - retention policy per history of domain or inverted index (key: `kv.AccountsHistoryIdx`, `kv.LogAddrIdx`, ...).
+// History without entry is kept forever.
+type Retentions map[kv.InvertedIdx]Retention
+
+// ParseRetentions - parse `name=value` comma-separated list. Name: domain or inverted index (`storage`, `logaddrs`, `tracesfrom`, ...).
+// Value: amount of blocks (`1000000`), age (`90d`, `12h`) or `all` (keep forever).
+func ParseRetentions(s string) (Retentions, error) {
+	res := Retentions{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("retention %q: expected name=value", item)
+		}
+		idx, err := kv.String2InvertedIdx(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("retention %q: %w", item, err)
+		}
+		value = strings.TrimSpace(value)
+		if value == "all" {
+			delete(res, idx)
+			continue
+		}
+		r, err := parseRetention(value)
+		if err != nil {
+			return nil, fmt.Errorf("retention %q: %w", item, err)
+		}
+		res[idx] = r
+	}
+	return res, nil
+}
+
+func parseRetention(value string) (Retention, error) {
+	if blocksStr, ageStr, ok := strings.Cut(value, "/"); ok { // `1000000/90d`
+		blocks, err := parseRetention(blocksStr)
+		if err != nil {
+			return Retention{}, err
+		}
+		age, err := parseRetention(ageStr)
+		if err != nil {
+			return Retention{}, err
+		}
+		return Retention{Blocks: blocks.Blocks, Age: age.Age}, nil
+	}
+	if blocks, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return Retention{Blocks: blocks}, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 64)
+		if err != nil {
+			return Retention{}, err
+		}
+		return Retention{Age: time.Duration(n) * 24 * time.Hour}, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return Retention{}, err
+	}
+	return Retention{Age: age}, nil
+}
+
+func formatAge(age time.Duration) string {
+	if age%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", age/(24*time.Hour))
+	}
+	return age.String()
+}
+
+// Get - returns retention of given history. ok=false: keep forever
+func (rs Retentions) Get(idx kv.InvertedIdx) (r Retention, ok bool) {
+	r, ok = rs[idx]
+	return r, ok
+}
+
+// OfTable - retention of history which is stored in given db table. ok=false: not a history table or history is kept forever
+func (rs Retentions) OfTable(table string) (r Retention, ok bool) {
+	for d := kv.Domain(0); d < kv.DomainLen; d++ {
+		h := Schema.GetDomainCfg(d).Hist
+		if table == h.ValuesTable || table == h.IiCfg.KeysTable || table == h.IiCfg.ValuesTable {
+			return rs.Get(h.HistoryIdx)
+		}
+	}
+	for _, idx := range []kv.InvertedIdx{kv.LogAddrIdx, kv.LogTopicIdx, kv.TracesFromIdx, kv.TracesToIdx} {
+		ii := Schema.GetIICfg(idx)
+		if table == ii.KeysTable || table == ii.ValuesTable {
+			return rs.Get(idx)
+		}
+	}
+	return Retention{}, false
+}
+
+// String - in format of `ParseRetentions`
+func (rs Retentions) String() string {
+	keys := slices.SortedFunc(maps.Keys(rs), func(a, b kv.InvertedIdx) int { return strings.Compare(a.String(), b.String()) })
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, k.String()+"="+rs[k].String())
+	}
+	return strings.Join(items, ",")
+}
+
+// ReadRetentions - policy which was used last time by node (for reporting)
+func ReadRetentions(db kv.Getter) (Retentions, error) {
+	v, err := db.GetOne(kv.DatabaseInfo, kv.PruneRetention)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetentions(string(v))
+}
+
+func WriteRetentions(db kv.Putter, rs Retentions) error {
+	return db.Put(kv.DatabaseInfo, kv.PruneRetention, []byte(rs.String()))
+}
@@ -0,0 +1,45 @@
+package statecfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/db/kv"
+)
+
+func TestParseRetentions(t *testing.T) {
+	require := require.New(t)
+
+	rs, err := ParseRetentions("storage=0, tracesfrom=90d,rcache=1000000,commitment=10000,logaddrs=all,tracesto=1000/36h")
+	require.NoError(err)
+	require.Len(rs, 5)
+	require.Equal(Retention{}, rs[kv.StorageHistoryIdx])
+	require.Equal(Retention{Age: 90 * 24 * time.Hour}, rs[kv.TracesFromIdx])
+	require.Equal(Retention{Blocks: 1_000_000}, rs[kv.RCacheHistoryIdx])
+	require.Equal(Retention{Blocks: 10_000}, rs[kv.CommitmentHistoryIdx])
+	require.Equal(Retention{Blocks: 1000, Age: 36 * time.Hour}, rs[kv.TracesToIdx])
+	_, ok := rs.Get(kv.LogAddrIdx)
+	require.False(ok)
+	r, ok := rs.OfTable(kv.TblStorageHistoryKeys)
+	require.True(ok)
+	require.Equal(Retention{}, r)
+	r, ok = rs.OfTable(kv.TblTracesFromIdx)
+	require.True(ok)
+	require.Equal(Retention{Age: 90 * 24 * time.Hour}, r)
+	_, ok = rs.OfTable(kv.TblStorageVals)
+	require.False(ok)
+
+	again, err := ParseRetentions(rs.String())
+	require.NoError(err)
+	require.Equal(rs, again)
+	require.Equal("commitment=10000,rcache=1000000,storage=0,tracesfrom=90d,tracesto=1000/36h0m0s", rs.String())
+
+	_, err = ParseRetentions("storage")
+	require.Error(err)
+	_, err = ParseRetentions("unknown=1")
+	require.Error(err)
+	_, err = ParseRetentions("storage=1w")
+	require.Error(err)
+}
@@ -53,6 +53,9 @@ func Configure(Schema SchemaGen, a AggSetters, dirs datadir.Dirs, salt *uint32,
 	if err := a.RegisterDomain(Schema.GetDomainCfg(kv.RCacheDomain), salt, dirs, logger); err != nil {
 		return err
 	}
+	if err := a.RegisterDomain(Schema.GetDomainCfg(kv.CustomTraceDomain), salt, dirs, logger); err != nil {
+		return err
+	}
 	if err := a.RegisterII(Schema.GetIICfg(kv.LogAddrIdx), salt, dirs, logger); err != nil {
 		return err
 	}
@@ -92,6 +95,7 @@ type SchemaGen struct {
 	CommitmentDomain      DomainCfg
 	ReceiptDomain         DomainCfg
 	RCacheDomain          DomainCfg
+	CustomTraceDomain     DomainCfg
 	LogAddrIdx            InvIdxCfg
 	LogTopicIdx           InvIdxCfg
 	TracesFromIdx         InvIdxCfg
@@ -104,7 +108,7 @@ type SchemaGen struct {
 
 func (s *SchemaGen) GetVersioned(name string) (Versioned, error) {
 	switch name {
-	case kv.AccountsDomain.String(), kv.StorageDomain.String(), kv.CodeDomain.String(), kv.CommitmentDomain.String(), kv.ReceiptDomain.String(), kv.RCacheDomain.String():
+	case kv.AccountsDomain.String(), kv.StorageDomain.String(), kv.CodeDomain.String(), kv.CommitmentDomain.String(), kv.ReceiptDomain.String(), kv.RCacheDomain.String(), kv.CustomTraceDomain.String():
 		domain, err := kv.String2Domain(name)
 		if err != nil {
 			return nil, err
@@ -140,6 +144,8 @@ func (s *SchemaGen) GetDomainCfg(name kv.Domain) DomainCfg {
 		v = s.ReceiptDomain
 	case kv.RCacheDomain:
 		v = s.RCacheDomain
+	case kv.CustomTraceDomain:
+		v = s.CustomTraceDomain
 	default:
 		v = DomainCfg{}
 	}
@@ -328,6 +334,31 @@ var Schema = SchemaGen{
 			},
 		},
 	},
+	CustomTraceDomain: DomainCfg{
+		Name: kv.CustomTraceDomain, ValuesTable: kv.TblCustomTraceVals,
+		LargeValues: true,
+
+		Accessors:   AccessorHashMap,
+		CompressCfg: DomainCompressCfg, Compression: seg.CompressNone,
+
+		Hist: HistCfg{
+			ValuesTable: kv.TblCustomTraceHistoryVals,
+			Compression: seg.CompressNone,
+			Accessors:   AccessorHashMap,
+
+			HistoryLargeValues: true,
+			HistoryIdx:         kv.CustomTraceHistoryIdx,
+
+			HistoryValuesOnCompressedPage: 16,
+
+			IiCfg: InvIdxCfg{
+				Disable:      true, // disable everything by default, see EnableCustomTraces
+				FilenameBase: kv.CustomTraceDomain.String(), KeysTable: kv.TblCustomTraceHistoryKeys, ValuesTable: kv.TblCustomTraceIdx,
+				CompressorCfg: seg.DefaultCfg,
+				Accessors:     AccessorHashMap,
+			},
+		},
+	},
 
 	LogAddrIdx: InvIdxCfg{
 		FilenameBase: kv.FileLogAddressIdx, KeysTable: kv.TblLogAddressKeys, ValuesTable: kv.TblLogAddressIdx,
@@ -448,4 +479,12 @@ func EnableHistoricalRCache() {
 	Schema.RCacheDomain = cfg
 }
 
+// EnableCustomTraces enables the domain the custom traces are produced into, with its
+// history and files
+func EnableCustomTraces() {
+	cfg := Schema.CustomTraceDomain
+	cfg.Hist.IiCfg.Disable = false
+	Schema.CustomTraceDomain = cfg
+}
+
 var SchemeMinSupportedVersions = map[string]map[string]snaptype.Version{}
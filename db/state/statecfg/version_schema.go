@@ -62,6 +62,15 @@ func InitSchemas() {
 			".vi":  Schema.RCacheDomain.Hist.FileVersion.AccessorVI.MinSupported,
 			".v":   Schema.RCacheDomain.Hist.FileVersion.DataV.MinSupported,
 		},
+		"customtrace": {
+			".kv":  Schema.CustomTraceDomain.FileVersion.DataKV.MinSupported,
+			".bt":  Schema.CustomTraceDomain.FileVersion.AccessorBT.MinSupported,
+			".kvi": Schema.CustomTraceDomain.FileVersion.AccessorKVI.MinSupported,
+			".efi": Schema.CustomTraceDomain.Hist.IiCfg.FileVersion.AccessorEFI.MinSupported,
+			".ef":  Schema.CustomTraceDomain.Hist.IiCfg.FileVersion.DataEF.MinSupported,
+			".vi":  Schema.CustomTraceDomain.Hist.FileVersion.AccessorVI.MinSupported,
+			".v":   Schema.CustomTraceDomain.Hist.FileVersion.DataV.MinSupported,
+		},
 		"logaddrs": {
 			".ef":  Schema.LogAddrIdx.FileVersion.DataEF.MinSupported,
 			".efi": Schema.LogAddrIdx.FileVersion.AccessorEFI.MinSupported,
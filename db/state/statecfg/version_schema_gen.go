@@ -27,6 +27,12 @@ func InitSchemasGen() {
 	Schema.CommitmentDomain.Hist.FileVersion.AccessorVI = version.Versions{version.Version{1, 1}, version.Version{1, 0}}
 	Schema.CommitmentDomain.Hist.IiCfg.FileVersion.DataEF = version.Versions{version.Version{2, 0}, version.Version{1, 0}}
 	Schema.CommitmentDomain.Hist.IiCfg.FileVersion.AccessorEFI = version.Versions{version.Version{2, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.FileVersion.DataKV = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.FileVersion.AccessorKVI = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.Hist.FileVersion.DataV = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.Hist.FileVersion.AccessorVI = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.Hist.IiCfg.FileVersion.DataEF = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
+	Schema.CustomTraceDomain.Hist.IiCfg.FileVersion.AccessorEFI = version.Versions{version.Version{1, 0}, version.Version{1, 0}}
 	Schema.HeadersBlock.Version.AccessorIdx = version.Versions{version.Version{1, 1}, version.Version{1, 0}}
 	Schema.HeadersBlock.Version.DataSeg = version.Versions{version.Version{1, 1}, version.Version{1, 0}}
 	Schema.LogAddrIdx.FileVersion.DataEF = version.Versions{version.Version{2, 1}, version.Version{1, 0}}
@@ -0,0 +1,246 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chainbundle loads named chains from a spec bundle directory, so private
+// networks can be passed as --chain=/path/to/bundle and get registered like the
+// built-in ones (EL chain spec, Caplin configs and genesis state, snapshots).
+package chainbundle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/clparams/initial_state"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/snapcfg"
+	"github.com/erigontech/erigon/db/snaptype2"
+	"github.com/erigontech/erigon/execution/chain/networkname"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/state/genesiswrite"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// Files of a bundle directory; the layout follows the one of the public testnets
+// metadata repositories, plus the optional snapshots files.
+const (
+	GenesisFile        = "genesis.json"        // EL genesis in `erigon init` format (chain config, alloc, header fields), required
+	EnodesFile         = "enodes.txt"          // EL bootnodes, one enode URL per line
+	BeaconConfigFile   = "config.yaml"         // Caplin beacon chain config
+	GenesisStateFile   = "genesis.ssz"         // SSZ encoded beacon genesis state, required with config.yaml
+	BootstrapNodesFile = "bootstrap_nodes.txt" // CL bootnodes, one ENR per line
+	PreverifiedFile    = "preverified.toml"    // snapshots preverified list, same format as the built-in ones
+	WebseedsFile       = "webseeds.txt"        // snapshots webseeds, one URL per line, requires preverified.toml
+)
+
+var ErrConflict = errors.New("chain bundle conflicts with a registered chain")
+
+type Bundle struct {
+	Spec chainspec.Spec
+
+	// CL part, nil if the bundle has no beacon config
+	BeaconConfig  *clparams.BeaconChainConfig
+	NetworkConfig *clparams.NetworkConfig
+	GenesisState  []byte
+
+	// Snapshots part, nil if the bundle has no preverified list
+	Preverified []byte
+	Webseeds    []string
+}
+
+// IsBundle returns true if chain is a path to a bundle directory rather than a
+// chain name.
+func IsBundle(chain string) bool {
+	if !strings.ContainsAny(chain, `/\`) && chain != "." {
+		return false
+	}
+	info, err := os.Stat(chain)
+	return err == nil && info.IsDir()
+}
+
+// Load reads the bundle in dir. The chain is named after the chainName of its
+// chain config, or after dir if it's not set.
+func Load(dir string, logger log.Logger) (*Bundle, error) {
+	genesisJson, err := os.ReadFile(filepath.Join(dir, GenesisFile))
+	if err != nil {
+		return nil, err
+	}
+	genesis := &types.Genesis{}
+	if err := json.Unmarshal(genesisJson, genesis); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", GenesisFile, err)
+	}
+	if genesis.Config == nil || genesis.Config.ChainID == nil {
+		return nil, fmt.Errorf("%s: chain config with chainId is required", GenesisFile)
+	}
+	name := genesis.Config.ChainName
+	if name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Base(abs)
+	}
+	name = strings.ToLower(name)
+	genesis.Config.ChainName = name
+
+	tmpDir, err := os.MkdirTemp("", "chainbundle")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	block, _, err := genesiswrite.GenesisToBlock(nil, genesis, datadir.New(tmpDir), logger)
+	if err != nil {
+		return nil, fmt.Errorf("computing genesis block: %w", err)
+	}
+
+	b := &Bundle{
+		Spec: chainspec.Spec{
+			Name:             name,
+			GenesisHash:      block.Hash(),
+			GenesisStateRoot: block.Root(),
+			Genesis:          genesis,
+			Config:           genesis.Config,
+		},
+	}
+	if b.Spec.Bootnodes, err = readLines(filepath.Join(dir, EnodesFile)); err != nil {
+		return nil, err
+	}
+
+	beaconYaml, err := readOptional(filepath.Join(dir, BeaconConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	if b.GenesisState, err = readOptional(filepath.Join(dir, GenesisStateFile)); err != nil {
+		return nil, err
+	}
+	if (beaconYaml == nil) != (b.GenesisState == nil) {
+		return nil, fmt.Errorf("%s and %s must be provided together", BeaconConfigFile, GenesisStateFile)
+	}
+	if beaconYaml != nil {
+		beaconCfg, networkCfg, err := clparams.ParseCustomConfig(beaconYaml)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", BeaconConfigFile, err)
+		}
+		if beaconCfg.DepositNetworkID != genesis.Config.ChainID.Uint64() {
+			return nil, fmt.Errorf("%s: DEPOSIT_NETWORK_ID %d doesn't match chainId %d", BeaconConfigFile, beaconCfg.DepositNetworkID, genesis.Config.ChainID.Uint64())
+		}
+		if networkCfg.BootNodes, err = readLines(filepath.Join(dir, BootstrapNodesFile)); err != nil {
+			return nil, err
+		}
+		b.BeaconConfig, b.NetworkConfig = &beaconCfg, &networkCfg
+	}
+
+	if b.Preverified, err = readOptional(filepath.Join(dir, PreverifiedFile)); err != nil {
+		return nil, err
+	}
+	if b.Preverified != nil {
+		var items map[string]string
+		if err := toml.Unmarshal(b.Preverified, &items); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", PreverifiedFile, err)
+		}
+	}
+	if b.Webseeds, err = readLines(filepath.Join(dir, WebseedsFile)); err != nil {
+		return nil, err
+	}
+	if b.Preverified == nil && len(b.Webseeds) > 0 {
+		return nil, fmt.Errorf("%s requires %s", WebseedsFile, PreverifiedFile)
+	}
+	return b, nil
+}
+
+// CheckConflicts fails if a different chain is already registered with the
+// same name or id as the bundle chain.
+func (b *Bundle) CheckConflicts() error {
+	if spec, err := chainspec.ChainSpecByName(b.Spec.Name); err == nil && spec.GenesisHash != b.Spec.GenesisHash {
+		return fmt.Errorf("%w: %s", ErrConflict, b.Spec.Name)
+	}
+	if name, ok := chainspec.NetworkNameByID[b.Spec.Config.ChainID.Uint64()]; ok && name != b.Spec.Name {
+		return fmt.Errorf("%w: chainId %d of %s", ErrConflict, b.Spec.Config.ChainID.Uint64(), name)
+	}
+	return nil
+}
+
+// Register registers the bundle chain everywhere the built-in chains are. Load
+// validates all of the bundle, so it can't fail halfway; call CheckConflicts
+// first.
+func (b *Bundle) Register() {
+	networkname.Register(b.Spec.Name)
+	chainspec.RegisterChainSpec(b.Spec.Name, b.Spec)
+
+	if b.BeaconConfig != nil {
+		id := clparams.NetworkType(b.Spec.Config.ChainID.Uint64())
+		clparams.RegisterNetwork(b.Spec.Name, id, *b.NetworkConfig, *b.BeaconConfig, nil)
+		initial_state.RegisterGenesisState(id, b.GenesisState)
+	}
+
+	if b.Preverified != nil {
+		snapcfg.RegisterKnownTypes(b.Spec.Name, snaptype2.EthereumSnapshotTypes)
+		snapcfg.RegisterPreverified(b.Spec.Name, b.Preverified, b.Webseeds)
+	}
+}
+
+// Resolve loads and registers the bundle if chain is a bundle path, returning
+// the registered chain name; chain names are returned as is.
+func Resolve(chain string, logger log.Logger) (string, error) {
+	if !IsBundle(chain) {
+		return chain, nil
+	}
+	b, err := Load(chain, logger)
+	if err != nil {
+		return "", fmt.Errorf("loading chain bundle %s: %w", chain, err)
+	}
+	if err := b.CheckConflicts(); err != nil {
+		return "", err
+	}
+	b.Register()
+	logger.Info("Registered chain from bundle", "chain", b.Spec.Name, "genesis", b.Spec.GenesisHash, "caplin", b.BeaconConfig != nil, "snapshots", b.Preverified != nil)
+	return b.Spec.Name, nil
+}
+
+func readOptional(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// readLines returns the non empty, non comment lines of an optional file
+func readLines(path string) ([]string, error) {
+	b, err := readOptional(path)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chainbundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/clparams/initial_state"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/snapcfg"
+	"github.com/erigontech/erigon/execution/chain/networkname"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+)
+
+const testGenesis = `{
+	"config": {
+		"chainName": "%s",
+		"chainId": 42424,
+		"homesteadBlock": 0,
+		"eip150Block": 0,
+		"eip155Block": 0,
+		"byzantiumBlock": 0,
+		"constantinopleBlock": 0,
+		"petersburgBlock": 0,
+		"istanbulBlock": 0,
+		"berlinBlock": 0,
+		"londonBlock": 0,
+		"terminalTotalDifficulty": 0,
+		"terminalTotalDifficultyPassed": true,
+		"shanghaiTime": 0
+	},
+	"gasLimit": "0x1c9c380",
+	"difficulty": "0x0",
+	"timestamp": "0x0",
+	"alloc": {
+		"0x%040x": {"balance": "0x3635c9adc5dea00000"}
+	}
+}`
+
+const testBeaconConfig = `PRESET_BASE: mainnet
+CONFIG_NAME: testbundle
+DEPOSIT_CHAIN_ID: 42424
+DEPOSIT_NETWORK_ID: 42424
+`
+
+func writeBundle(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func genesisJson(name string, funded uint64) string {
+	return fmt.Sprintf(testGenesis, name, funded)
+}
+
+func TestRegisterBundle(t *testing.T) {
+	sepoliaState, err := initial_state.GetGenesisState(chainspec.SepoliaChainID)
+	require.NoError(t, err)
+	encodedState, err := sepoliaState.EncodeSSZ(nil)
+	require.NoError(t, err)
+
+	dir := writeBundle(t, map[string]string{
+		GenesisFile:        genesisJson("TestBundle", 1),
+		EnodesFile:         "# EL bootnodes\nenode://d860a01f9722d78051619d1e2351aba3f43f943f6f00718d1b9baa4101932a1f5011f16bb2b1bb35db20d6fe28fa0bf09636d26a87d31de9ec6203eeedb1f666@18.138.108.67:30303\n",
+		BeaconConfigFile:   testBeaconConfig,
+		GenesisStateFile:   string(encodedState),
+		BootstrapNodesFile: "enr:-Ku4QImhMc1z8yCiNJ1TyUxdcfNucje3BGwEHzodEZUan8PherEo4sF7pPHPSIB1NNuSJ5fZcGm\n",
+		PreverifiedFile:    "'v1.0-000000-000500-headers.seg' = '0123456789abcdef0123456789abcdef01234567'\n",
+		WebseedsFile:       "https://snapshots.example.org\n",
+	})
+	require.True(t, IsBundle(dir))
+	require.False(t, IsBundle(networkname.Mainnet))
+
+	name, err := Resolve(dir, log.New())
+	require.NoError(t, err)
+	require.Equal(t, "testbundle", name)
+	require.True(t, networkname.Supported(name))
+
+	spec, err := chainspec.ChainSpecByName(name)
+	require.NoError(t, err)
+	require.Equal(t, name, spec.Config.ChainName)
+	require.Len(t, spec.Bootnodes, 1)
+	byHash, err := chainspec.ChainSpecByGenesisHash(spec.GenesisHash)
+	require.NoError(t, err)
+	require.Equal(t, name, byHash.Name)
+	require.Equal(t, name, chainspec.NetworkNameByID[42424])
+
+	networkCfg, beaconCfg, networkId, err := clparams.GetConfigsByNetworkName(name)
+	require.NoError(t, err)
+	require.Equal(t, clparams.NetworkType(42424), networkId)
+	require.Equal(t, uint64(42424), beaconCfg.DepositChainID)
+	require.Len(t, networkCfg.BootNodes, 1)
+	require.True(t, clparams.EmbeddedSupported(42424))
+
+	require.True(t, initial_state.IsGenesisStateSupported(networkId))
+	genesisState, err := initial_state.GetGenesisState(networkId)
+	require.NoError(t, err)
+	require.Equal(t, sepoliaState.GenesisValidatorsRoot(), genesisState.GenesisValidatorsRoot())
+
+	snapCfg, known := snapcfg.KnownCfg(name)
+	require.True(t, known)
+	require.Equal(t, uint64(499_999), snapCfg.ExpectBlocks)
+	require.Equal(t, []string{"https://snapshots.example.org"}, snapcfg.KnownWebseeds[name])
+	require.NotEmpty(t, snapcfg.GetToml(name))
+
+	// Registering the same chain again is a no-op, a different one with the same name conflicts
+	_, err = Resolve(dir, log.New())
+	require.NoError(t, err)
+	_, err = Resolve(writeBundle(t, map[string]string{GenesisFile: genesisJson("TestBundle", 2)}), log.New())
+	require.ErrorIs(t, err, ErrConflict)
+	_, err = Resolve(writeBundle(t, map[string]string{GenesisFile: genesisJson(networkname.Sepolia, 1)}), log.New())
+	require.ErrorIs(t, err, ErrConflict)
+	// a conflicting bundle registers nothing, snapshots included
+	_, err = Resolve(writeBundle(t, map[string]string{
+		GenesisFile:     strings.Replace(genesisJson("OtherBundle", 1), "42424", "1", 1),
+		PreverifiedFile: "'v1.0-000000-000500-headers.seg' = '0123456789abcdef0123456789abcdef01234567'\n",
+	}), log.New())
+	require.ErrorIs(t, err, ErrConflict)
+	require.False(t, networkname.Supported("otherbundle"))
+	_, known = snapcfg.KnownCfg("otherbundle")
+	require.False(t, known)
+}
+
+func TestLoadBundleErrors(t *testing.T) {
+	logger := log.New()
+
+	_, err := Load(t.TempDir(), logger)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load(writeBundle(t, map[string]string{
+		GenesisFile:      genesisJson("Incomplete", 1),
+		BeaconConfigFile: testBeaconConfig,
+	}), logger)
+	require.ErrorContains(t, err, "must be provided together")
+
+	_, err = Load(writeBundle(t, map[string]string{
+		GenesisFile:      genesisJson("WrongId", 1),
+		BeaconConfigFile: strings.ReplaceAll(testBeaconConfig, "42424", "1"),
+		GenesisStateFile: "state",
+	}), logger)
+	require.ErrorContains(t, err, "DEPOSIT_NETWORK_ID")
+
+	_, err = Load(writeBundle(t, map[string]string{
+		GenesisFile:  genesisJson("NoPreverified", 1),
+		WebseedsFile: "https://snapshots.example.org\n",
+	}), logger)
+	require.ErrorContains(t, err, PreverifiedFile)
+
+	_, err = Load(writeBundle(t, map[string]string{
+		GenesisFile:     genesisJson("BadPreverified", 1),
+		PreverifiedFile: "'v1.0-000000-000500-headers.seg' = \n",
+	}), logger)
+	require.ErrorContains(t, err, PreverifiedFile)
+
+	// chain named after the directory when the chain config has none
+	dir := filepath.Join(t.TempDir(), "Unnamed")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, GenesisFile), []byte(genesisJson("", 1)), 0o644))
+	b, err := Load(dir, logger)
+	require.NoError(t, err)
+	require.Equal(t, "unnamed", b.Spec.Name)
+}
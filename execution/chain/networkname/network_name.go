@@ -51,3 +51,12 @@ var All = []string{
 
 // Supported checks if the given network name is supported by Erigon.
 func Supported(name string) bool { return slices.Contains(All, strings.ToLower(name)) }
+
+// Register adds a runtime-defined network (e.g. loaded from a chain bundle) to
+// All, so it's reported as supported like the built-in ones.
+func Register(name string) {
+	name = strings.ToLower(name)
+	if !slices.Contains(All, name) {
+		All = append(All, name)
+	}
+}
@@ -143,3 +143,20 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *types.Genesis
 		Alloc:      ReadPrealloc(allocs, "allocs/dev.json"),
 	}
 }
+
+// DeveloperPoSGenesisBlock returns genesis of proof-of-stake dev chain (see --dev.pos): merged from genesis,
+// blocks are produced via Engine API by external CL. Same chain id and pre-funded accounts as 'geth --dev' genesis,
+// plus pre-deployed system contracts of Cancun and Prague.
+func DeveloperPoSGenesisBlock() *types.Genesis {
+	var config chain.Config
+	if err := copier.CopyWithOption(&config, chain.AllProtocolChanges, copier.Option{DeepCopy: true}); err != nil {
+		panic(err)
+	}
+	config.GlamsterdamTime = nil
+	return &types.Genesis{
+		Config:     &config,
+		GasLimit:   30_000_000,
+		Difficulty: big.NewInt(0),
+		Alloc:      ReadPrealloc(allocs, "allocs/dev_pos.json"),
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/length"
+	"github.com/erigontech/erigon/execution/rlp"
+)
+
+// StateNodes returns the RLP encodings of the resolved nodes of the trie which are
+// referenced by their hash: the state root, the storage roots and every node of 32
+// bytes or more. These are the "state" nodes of a geth style ExecutionWitness.
+func (t *Trie) StateNodes() ([][]byte, error) {
+	h := t.newHasherFunc()
+	defer returnHasherToPool(h)
+
+	var nodes [][]byte
+	seen := map[common.Hash]struct{}{}
+
+	var collect func(n Node, root bool) error
+	collect = func(n Node, root bool) error {
+		switch n.(type) {
+		case *ShortNode, *DuoNode, *FullNode:
+		default:
+			return nil
+		}
+
+		enc, err := h.hashChildren(n, 0)
+		if err != nil {
+			return err
+		}
+		if root || len(enc) >= length.Hash {
+			hash := crypto.Keccak256Hash(enc)
+			if _, ok := seen[hash]; !ok {
+				seen[hash] = struct{}{}
+				nodes = append(nodes, common.CopyBytes(enc))
+			}
+		}
+
+		switch n := n.(type) {
+		case *ShortNode:
+			if an, ok := n.Val.(*AccountNode); ok {
+				return collect(an.Storage, true)
+			}
+			return collect(n.Val, false)
+		case *DuoNode:
+			if err := collect(n.child1, false); err != nil {
+				return err
+			}
+			return collect(n.child2, false)
+		case *FullNode:
+			for _, child := range n.Children {
+				if err := collect(child, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := collect(t.RootNode, true); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// BuildTrieFromNodes rebuilds the state trie with the given root out of the RLP encoded
+// state nodes and the contract codes of a geth style ExecutionWitness. Accounts' storage
+// tries are attached to their leaves and parts of the trie the witness has no nodes for
+// are left as hash nodes, so the resulting trie hashes to root.
+func BuildTrieFromNodes(root common.Hash, nodes [][]byte, codes [][]byte) (*Trie, error) {
+	b := &nodesTrieBuilder{
+		nodes: make(map[common.Hash][]byte, len(nodes)),
+		codes: make(map[common.Hash][]byte, len(codes)),
+	}
+	for _, node := range nodes {
+		b.nodes[crypto.Keccak256Hash(node)] = node
+	}
+	for _, code := range codes {
+		b.codes[crypto.Keccak256Hash(code)] = code
+	}
+
+	if root == EmptyRoot || root == (common.Hash{}) {
+		return New(EmptyRoot), nil
+	}
+	rootNode, err := b.resolveHash(root, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewInMemoryTrie(rootNode), nil
+}
+
+type nodesTrieBuilder struct {
+	nodes map[common.Hash][]byte
+	codes map[common.Hash][]byte
+}
+
+func (b *nodesTrieBuilder) resolveHash(hash common.Hash, accountTrie bool) (Node, error) {
+	enc, ok := b.nodes[hash]
+	if !ok {
+		return &HashNode{hash: common.CopyBytes(hash[:])}, nil
+	}
+	n, err := decodeNode(enc)
+	if err != nil {
+		return nil, fmt.Errorf("decoding node %x: %w", hash, err)
+	}
+	return b.resolve(n, accountTrie)
+}
+
+func (b *nodesTrieBuilder) resolve(n Node, accountTrie bool) (Node, error) {
+	switch n := n.(type) {
+	case nil:
+		return nil, nil
+	case HashNode:
+		return b.resolveHash(common.BytesToHash(n.hash), accountTrie)
+	case *FullNode:
+		for i, child := range n.Children[:16] {
+			resolved, err := b.resolve(child, accountTrie)
+			if err != nil {
+				return nil, err
+			}
+			n.Children[i] = resolved
+		}
+		return n, nil
+	case *ShortNode:
+		value, ok := n.Val.(ValueNode)
+		if !ok {
+			resolved, err := b.resolve(n.Val, accountTrie)
+			if err != nil {
+				return nil, err
+			}
+			n.Val = resolved
+			return n, nil
+		}
+		if !accountTrie {
+			// storage leaves hold the RLP encoding of the slot value, the trie the raw value
+			val, _, err := rlp.SplitString(value)
+			if err != nil {
+				return nil, err
+			}
+			n.Val = ValueNode(val)
+			return n, nil
+		}
+		account, err := b.accountNode(value)
+		if err != nil {
+			return nil, err
+		}
+		n.Val = account
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unexpected node type %T", n)
+	}
+}
+
+func (b *nodesTrieBuilder) accountNode(enc []byte) (*AccountNode, error) {
+	an := &AccountNode{RootCorrect: true, CodeSize: codeSizeUncached}
+	if err := an.Account.DecodeForHashing(enc); err != nil {
+		return nil, err
+	}
+
+	if an.Root != EmptyRoot {
+		storage, err := b.resolveHash(an.Root, false)
+		if err != nil {
+			return nil, err
+		}
+		an.Storage = storage
+	}
+
+	if an.CodeHash == emptyCodeHash {
+		an.CodeSize = 0
+	} else if code, ok := b.codes[an.CodeHash]; ok {
+		an.Code = code
+		an.CodeSize = len(code)
+	}
+	return an, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/db/kv/dbutils"
+	"github.com/erigontech/erigon/execution/types/accounts"
+)
+
+func TestStateNodesRoundTrip(t *testing.T) {
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	codeHash := crypto.Keccak256Hash(code)
+
+	tr := New(EmptyRoot)
+	var contract common.Hash
+	for i := byte(1); i <= 20; i++ {
+		addrHash := crypto.Keccak256Hash([]byte{i})
+		acc := &accounts.Account{
+			Initialised: true,
+			Nonce:       uint64(i),
+			Balance:     *uint256.NewInt(uint64(i) * 1000),
+			Root:        EmptyRoot,
+			CodeHash:    emptyCodeHash,
+		}
+		if i == 7 {
+			contract = addrHash
+			acc.CodeHash = codeHash
+			acc.Incarnation = 1
+		}
+		tr.UpdateAccount(addrHash[:], acc)
+	}
+	require.NoError(t, tr.UpdateAccountCode(contract[:], code))
+	for i := byte(1); i <= 10; i++ {
+		slot := crypto.Keccak256Hash([]byte{0xff, i})
+		tr.Update(dbutils.GenerateCompositeTrieKey(contract, slot), []byte{i})
+	}
+	root := tr.Hash()
+
+	nodes, err := tr.StateNodes()
+	require.NoError(t, err)
+
+	rebuilt, err := BuildTrieFromNodes(root, nodes, [][]byte{code})
+	require.NoError(t, err)
+	require.Equal(t, root, rebuilt.Hash())
+
+	acc, ok := rebuilt.GetAccount(contract[:])
+	require.True(t, ok)
+	require.Equal(t, uint64(7), acc.Nonce)
+	require.Equal(t, codeHash, acc.CodeHash)
+
+	gotCode, ok := rebuilt.GetAccountCode(contract[:])
+	require.True(t, ok)
+	require.Equal(t, code, gotCode)
+
+	slot := crypto.Keccak256Hash([]byte{0xff, 3})
+	value, ok := rebuilt.Get(dbutils.GenerateCompositeTrieKey(contract, slot))
+	require.True(t, ok)
+	require.Equal(t, []byte{3}, value)
+
+	// nodes missing from the witness stay hash nodes and the root is unchanged
+	partial, err := BuildTrieFromNodes(root, nodes[:1], nil)
+	require.NoError(t, err)
+	require.Equal(t, root, partial.Hash())
+	_, ok = partial.GetAccount(contract[:])
+	require.False(t, ok)
+
+	empty, err := BuildTrieFromNodes(EmptyRoot, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, EmptyRoot, empty.Hash())
+}
@@ -287,6 +287,13 @@ func (c *JsonRpcClient) GetClientVersionV1(ctx context.Context, callerVersion *e
 	}, c.backOff(ctx))
 }
 
+// Call - calls any method with raw args, for example recorded by engine_recorder
+func (c *JsonRpcClient) Call(ctx context.Context, result any, method string, args ...any) error {
+	return backoff.Retry(func() error {
+		return c.maybeMakePermanent(c.rpcClient.CallContext(ctx, result, method, args...))
+	}, c.backOff(ctx))
+}
+
 func (c *JsonRpcClient) backOff(ctx context.Context) backoff.BackOff {
 	var backOff backoff.BackOff
 	backOff = backoff.NewConstantBackOff(c.retryBackOff)
@@ -0,0 +1,162 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/execution/engineapi/engine_recorder"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+)
+
+// recordingEngineAPI - serves Engine API by EngineServer and records every call (see --authrpc.record).
+// Must have method for each of ourCapabilities and ExchangeCapabilities: it's registered in rpc server instead of EngineServer.
+type recordingEngineAPI struct {
+	e        *EngineServer
+	recorder *engine_recorder.Recorder
+}
+
+func (r *recordingEngineAPI) NewPayloadV1(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
+	start := time.Now()
+	res, err := r.e.NewPayloadV1(ctx, payload)
+	r.recorder.Record("engine_newPayloadV1", start, []any{payload}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) NewPayloadV2(ctx context.Context, payload *engine_types.ExecutionPayload) (*engine_types.PayloadStatus, error) {
+	start := time.Now()
+	res, err := r.e.NewPayloadV2(ctx, payload)
+	r.recorder.Record("engine_newPayloadV2", start, []any{payload}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (*engine_types.PayloadStatus, error) {
+	start := time.Now()
+	res, err := r.e.NewPayloadV3(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot)
+	r.recorder.Record("engine_newPayloadV3", start, []any{payload, expectedBlobHashes, parentBeaconBlockRoot}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) NewPayloadV4(ctx context.Context, payload *engine_types.ExecutionPayload,
+	expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
+	start := time.Now()
+	res, err := r.e.NewPayloadV4(ctx, payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests)
+	r.recorder.Record("engine_newPayloadV4", start, []any{payload, expectedBlobHashes, parentBeaconBlockRoot, executionRequests}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) ForkchoiceUpdatedV1(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	start := time.Now()
+	res, err := r.e.ForkchoiceUpdatedV1(ctx, forkChoiceState, payloadAttributes)
+	r.recorder.Record("engine_forkchoiceUpdatedV1", start, []any{forkChoiceState, payloadAttributes}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) ForkchoiceUpdatedV2(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	start := time.Now()
+	res, err := r.e.ForkchoiceUpdatedV2(ctx, forkChoiceState, payloadAttributes)
+	r.recorder.Record("engine_forkchoiceUpdatedV2", start, []any{forkChoiceState, payloadAttributes}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	start := time.Now()
+	res, err := r.e.ForkchoiceUpdatedV3(ctx, forkChoiceState, payloadAttributes)
+	r.recorder.Record("engine_forkchoiceUpdatedV3", start, []any{forkChoiceState, payloadAttributes}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadV1(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.ExecutionPayload, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadV1(ctx, payloadID)
+	r.recorder.Record("engine_getPayloadV1", start, []any{payloadID}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadV2(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadV2(ctx, payloadID)
+	r.recorder.Record("engine_getPayloadV2", start, []any{payloadID}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadV3(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadV3(ctx, payloadID)
+	r.recorder.Record("engine_getPayloadV3", start, []any{payloadID}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadV4(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadV4(ctx, payloadID)
+	r.recorder.Record("engine_getPayloadV4", start, []any{payloadID}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadV5(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadV5(ctx, payloadID)
+	r.recorder.Record("engine_getPayloadV5", start, []any{payloadID}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadBodiesByHashV1(ctx context.Context, hashes []common.Hash) ([]*engine_types.ExecutionPayloadBody, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadBodiesByHashV1(ctx, hashes)
+	r.recorder.Record("engine_getPayloadBodiesByHashV1", start, []any{hashes}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetPayloadBodiesByRangeV1(ctx context.Context, from, count hexutil.Uint64) ([]*engine_types.ExecutionPayloadBody, error) {
+	start := time.Now()
+	res, err := r.e.GetPayloadBodiesByRangeV1(ctx, from, count)
+	r.recorder.Record("engine_getPayloadBodiesByRangeV1", start, []any{from, count}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetClientVersionV1(ctx context.Context, callerVersion *engine_types.ClientVersionV1) ([]engine_types.ClientVersionV1, error) {
+	start := time.Now()
+	res, err := r.e.GetClientVersionV1(ctx, callerVersion)
+	r.recorder.Record("engine_getClientVersionV1", start, []any{callerVersion}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) ExchangeCapabilities(fromCl []string) []string {
+	start := time.Now()
+	res := r.e.ExchangeCapabilities(fromCl)
+	r.recorder.Record("engine_exchangeCapabilities", start, []any{fromCl}, res, nil)
+	return res
+}
+
+func (r *recordingEngineAPI) GetBlobsV1(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	start := time.Now()
+	res, err := r.e.GetBlobsV1(ctx, blobHashes)
+	r.recorder.Record("engine_getBlobsV1", start, []any{blobHashes}, res, err)
+	return res, err
+}
+
+func (r *recordingEngineAPI) GetBlobsV2(ctx context.Context, blobHashes []common.Hash) ([]*engine_types.BlobAndProofV2, error) {
+	start := time.Now()
+	res, err := r.e.GetBlobsV2(ctx, blobHashes)
+	r.recorder.Record("engine_getBlobsV2", start, []any{blobHashes}, res, err)
+	return res, err
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEngineAPI replaces EngineServer in rpc server: must serve all methods which we report to CL
+func TestRecordingEngineAPIServesAllCapabilities(t *testing.T) {
+	var _ EngineAPI = &recordingEngineAPI{}
+	recording := reflect.TypeOf(&recordingEngineAPI{})
+	server := reflect.TypeOf(&EngineServer{})
+	for _, capability := range append(ourCapabilities, "engine_exchangeCapabilities") {
+		name := strings.TrimPrefix(capability, "engine_")
+		name = strings.ToUpper(name[:1]) + name[1:]
+		method, ok := recording.MethodByName(name)
+		require.True(t, ok, capability)
+		serverMethod, ok := server.MethodByName(name)
+		require.True(t, ok, capability)
+		require.Equal(t, serverMethod.Type.String()[len("func(*engineapi.EngineServer"):], method.Type.String()[len("func(*engineapi.recordingEngineAPI"):], capability)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package engine_recorder records Engine API traffic between CL and EL to a file and replays it against another EL.
+//
+// Recording is a jsonl file: one Entry per call, in order of completion.
+package engine_recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/erigontech/erigon/common/jsonl"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// Entry - one Engine API call: request, response and timing
+type Entry struct {
+	Method   string            `json:"method"`
+	Params   []json.RawMessage `json:"params"`
+	Result   json.RawMessage   `json:"result,omitempty"`
+	Error    *Error            `json:"error,omitempty"`
+	Offset   time.Duration     `json:"offset"`   // since start of recording session
+	Duration time.Duration     `json:"duration"` // of handling call by EL
+}
+
+// Error - error returned to CL
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func newError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	res := &Error{Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		res.Code = rpcErr.ErrorCode()
+	}
+	return res
+}
+
+type Recorder struct {
+	w      *jsonl.Writer
+	start  time.Time
+	logger log.Logger
+}
+
+func NewRecorder(path string, logger log.Logger) (*Recorder, error) {
+	// flush every call: to not lose recording on crash. Engine API traffic is few calls per slot.
+	w, err := jsonl.NewWriter(path, 0, logger)
+	if err != nil {
+		return nil, fmt.Errorf("engine api recorder: %w", err)
+	}
+	return &Recorder{w: w, start: time.Now(), logger: logger}, nil
+}
+
+// Record - writes call which started at `start` and finished now.
+// Never fails: recording problems are logged and must not affect serving of CL.
+func (r *Recorder) Record(method string, start time.Time, params []any, result any, err error) {
+	entry := Entry{
+		Method:   method,
+		Params:   make([]json.RawMessage, len(params)),
+		Error:    newError(err),
+		Offset:   start.Sub(r.start),
+		Duration: time.Since(start),
+	}
+	for i, param := range params {
+		raw, mErr := json.Marshal(param)
+		if mErr != nil {
+			r.logger.Warn("[EngineRecorder] can't marshal params", "method", method, "err", mErr)
+			return
+		}
+		entry.Params[i] = raw
+	}
+	if err == nil {
+		raw, mErr := json.Marshal(result)
+		if mErr != nil {
+			r.logger.Warn("[EngineRecorder] can't marshal result", "method", method, "err", mErr)
+			return
+		}
+		entry.Result = raw
+	}
+	if wErr := r.w.Write(&entry); wErr != nil && !errors.Is(wErr, os.ErrClosed) {
+		r.logger.Warn("[EngineRecorder] write failed", "method", method, "err", wErr)
+	}
+}
+
+func (r *Recorder) Close() error {
+	return r.w.Close()
+}
+
+type Reader = jsonl.Reader[Entry]
+
+// NewReader - Reader.Next returns io.EOF at the end of recording, io.ErrUnexpectedEOF if recording was cut (for example by crash of node)
+func NewReader(path string) (*Reader, error) {
+	r, err := jsonl.NewReader[Entry](path)
+	if err != nil {
+		return nil, fmt.Errorf("engine api recording: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_recorder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/rpc"
+)
+
+type fcuResponse struct {
+	PayloadStatus map[string]string `json:"payloadStatus"`
+	PayloadId     *string           `json:"payloadId"`
+}
+
+func record(t *testing.T, path string, calls func(r *Recorder)) {
+	t.Helper()
+	r, err := NewRecorder(path, log.New())
+	require.NoError(t, err)
+	calls(r)
+	require.NoError(t, r.Close())
+}
+
+func readAll(t *testing.T, path string) []*Entry {
+	t.Helper()
+	reader, err := NewReader(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	var entries []*Entry
+	for {
+		entry, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "engine.rec.gz")
+	id := "0x0000000000000001"
+	record(t, path, func(r *Recorder) {
+		r.Record("engine_exchangeCapabilities", time.Now(), []any{[]string{"engine_newPayloadV4"}}, []string{"engine_newPayloadV4"}, nil)
+		r.Record("engine_forkchoiceUpdatedV3", time.Now(), []any{map[string]string{"headBlockHash": "0x01"}, nil},
+			fcuResponse{PayloadStatus: map[string]string{"status": "VALID"}, PayloadId: &id}, nil)
+	})
+	// restart of node: new session is appended
+	record(t, path, func(r *Recorder) {
+		r.Record("engine_getPayloadV4", time.Now(), []any{id}, nil, &rpc.CustomError{Code: -38001, Message: "Unknown payload"})
+	})
+
+	entries := readAll(t, path)
+	require.Len(t, entries, 3)
+	require.Equal(t, "engine_exchangeCapabilities", entries[0].Method)
+	require.JSONEq(t, `["engine_newPayloadV4"]`, string(entries[0].Params[0]))
+	require.JSONEq(t, `["engine_newPayloadV4"]`, string(entries[0].Result))
+	require.Nil(t, entries[0].Error)
+
+	require.Len(t, entries[1].Params, 2)
+	require.JSONEq(t, `null`, string(entries[1].Params[1]))
+	require.GreaterOrEqual(t, entries[1].Offset, entries[0].Offset)
+
+	require.Equal(t, "engine_getPayloadV4", entries[2].Method)
+	require.Nil(t, entries[2].Result)
+	require.Equal(t, &Error{Code: -38001, Message: "Unknown payload"}, entries[2].Error)
+}
+
+// fakeEL - answers as EL which assigns other payload ids than recorded ones
+type fakeEL struct {
+	calls []string
+}
+
+func (el *fakeEL) Call(_ context.Context, result any, method string, args ...any) error {
+	el.calls = append(el.calls, method)
+	var res any
+	switch method {
+	case "engine_forkchoiceUpdatedV3":
+		id := "0x00000000000000ff"
+		res = fcuResponse{PayloadStatus: map[string]string{"status": "VALID"}, PayloadId: &id}
+	case "engine_getPayloadV4":
+		if args[0] != "0x00000000000000ff" {
+			return &rpc.CustomError{Code: -38001, Message: "Unknown payload"}
+		}
+		res = map[string]string{"blockValue": "0x1"}
+	case "engine_newPayloadV4":
+		res = map[string]string{"status": "INVALID"}
+	default:
+		return errors.New("connection refused")
+	}
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	*result.(*json.RawMessage) = raw
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "engine.rec.gz")
+	id := "0x0000000000000001"
+	record(t, path, func(r *Recorder) {
+		r.Record("engine_forkchoiceUpdatedV3", time.Now(), []any{map[string]string{"headBlockHash": "0x01"}, map[string]string{"timestamp": "0x10"}},
+			fcuResponse{PayloadStatus: map[string]string{"status": "VALID"}, PayloadId: &id}, nil)
+		r.Record("engine_getPayloadV4", time.Now(), []any{id}, map[string]string{"blockValue": "0x1"}, nil)
+		r.Record("engine_newPayloadV4", time.Now(), []any{map[string]string{"blockHash": "0x02"}, nil, nil, nil}, map[string]string{"status": "VALID"}, nil)
+	})
+
+	reader, err := NewReader(path)
+	require.NoError(t, err)
+	defer reader.Close()
+	el := &fakeEL{}
+	res, err := Replay(context.Background(), reader, el, false, log.New())
+	require.NoError(t, err)
+	require.Equal(t, []string{"engine_forkchoiceUpdatedV3", "engine_getPayloadV4", "engine_newPayloadV4"}, el.calls)
+	require.Equal(t, 3, res.Calls)
+	require.False(t, res.Truncated)
+	// other payload id is not a mismatch, and getPayload is sent with id assigned by replayed EL
+	require.Len(t, res.Mismatches, 1)
+	require.Equal(t, 2, res.Mismatches[0].Index)
+	require.Equal(t, "engine_newPayloadV4", res.Mismatches[0].Method)
+	require.JSONEq(t, `{"status":"VALID"}`, res.Mismatches[0].Expected)
+	require.JSONEq(t, `{"status":"INVALID"}`, res.Mismatches[0].Actual)
+
+	// not a response of EL: replay stops
+	path = filepath.Join(t.TempDir(), "engine2.rec.gz")
+	record(t, path, func(r *Recorder) {
+		r.Record("engine_getBlobsV1", time.Now(), []any{[]string{}}, []string{}, nil)
+	})
+	reader2, err := NewReader(path)
+	require.NoError(t, err)
+	defer reader2.Close()
+	_, err = Replay(context.Background(), reader2, el, false, log.New())
+	require.ErrorContains(t, err, "connection refused")
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_recorder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// Caller - Engine API client of replayed EL (see engineapi.JsonRpcClient)
+type Caller interface {
+	Call(ctx context.Context, result any, method string, args ...any) error
+}
+
+// Mismatch - response of replayed EL differs from recorded one
+type Mismatch struct {
+	Index    int // of call in recording
+	Method   string
+	Expected string
+	Actual   string
+}
+
+type ReplayResult struct {
+	Calls      int
+	Mismatches []Mismatch
+	Truncated  bool // recording ends with partially written call
+}
+
+// Replay - sends recorded calls one by one, in recorded order, and compares responses with recorded ones.
+// Payload IDs returned by forkchoiceUpdated are remapped: replayed EL may assign different ones,
+// and following getPayload calls must refer to them.
+// If keepTiming is set - waits between calls as long as CL did, otherwise sends calls back-to-back.
+func Replay(ctx context.Context, reader *Reader, caller Caller, keepTiming bool, logger log.Logger) (ReplayResult, error) {
+	var res ReplayResult
+	payloadIDs := map[string]string{} // recorded => replayed
+	var sessionStart time.Time
+	var prevOffset time.Duration
+	for i := 0; ; i++ {
+		entry, err := reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return res, nil
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				res.Truncated = true
+				return res, nil
+			}
+			return res, err
+		}
+
+		if keepTiming {
+			if i == 0 || entry.Offset < prevOffset { // new recording session
+				sessionStart = time.Now().Add(-entry.Offset)
+			}
+			prevOffset = entry.Offset
+			select {
+			case <-ctx.Done():
+				return res, ctx.Err()
+			case <-time.After(time.Until(sessionStart.Add(entry.Offset))):
+			}
+		}
+
+		mismatch, err := replayCall(ctx, caller, entry, payloadIDs)
+		if err != nil {
+			return res, fmt.Errorf("call %d %s: %w", i, entry.Method, err)
+		}
+		res.Calls++
+		if mismatch != nil {
+			mismatch.Index = i
+			res.Mismatches = append(res.Mismatches, *mismatch)
+			logger.Warn("[EngineReplay] response mismatch", "call", i, "method", entry.Method)
+		} else {
+			logger.Debug("[EngineReplay] response match", "call", i, "method", entry.Method)
+		}
+	}
+}
+
+func replayCall(ctx context.Context, caller Caller, entry *Entry, payloadIDs map[string]string) (*Mismatch, error) {
+	args := make([]any, len(entry.Params))
+	for i, param := range entry.Params {
+		args[i] = param
+	}
+	if strings.HasPrefix(entry.Method, "engine_getPayloadV") && len(args) == 1 {
+		var recordedID string
+		if err := json.Unmarshal(entry.Params[0], &recordedID); err == nil {
+			if replayedID, ok := payloadIDs[recordedID]; ok {
+				args[0] = replayedID
+			}
+		}
+	}
+
+	var result json.RawMessage
+	var actual any
+	if err := caller.Call(ctx, &result, entry.Method, args...); err != nil {
+		var rpcErr rpc.Error
+		if !errors.As(err, &rpcErr) {
+			return nil, err // not a response of EL: connectivity, etc.
+		}
+		actual = map[string]any{"error": Error{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}}
+	} else if err := json.Unmarshal(result, &actual); err != nil {
+		return nil, err
+	}
+
+	var expected any
+	if entry.Error != nil {
+		expected = map[string]any{"error": *entry.Error}
+	} else if err := json.Unmarshal(entry.Result, &expected); err != nil {
+		return nil, fmt.Errorf("bad recorded result: %w", err)
+	}
+
+	if strings.HasPrefix(entry.Method, "engine_forkchoiceUpdatedV") {
+		expectedFcu, ok1 := expected.(map[string]any)
+		actualFcu, ok2 := actual.(map[string]any)
+		if ok1 && ok2 {
+			recordedID, ok1 := expectedFcu["payloadId"].(string)
+			replayedID, ok2 := actualFcu["payloadId"].(string)
+			if ok1 && ok2 {
+				payloadIDs[recordedID] = replayedID
+				actualFcu["payloadId"] = recordedID // same payload, other id - not a mismatch
+			}
+		}
+	}
+
+	expectedJson, err := json.Marshal(expected)
+	if err != nil {
+		return nil, err
+	}
+	actualJson, err := json.Marshal(actual)
+	if err != nil {
+		return nil, err
+	}
+	// round-trip via json: to compare error structs with decoded responses
+	var expectedCmp, actualCmp any
+	if err := json.Unmarshal(expectedJson, &expectedCmp); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actualJson, &actualCmp); err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(expectedCmp, actualCmp) {
+		return nil, nil
+	}
+	return &Mismatch{Method: entry.Method, Expected: string(expectedJson), Actual: string(actualJson)}, nil
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/execution/engineapi/engine_block_downloader"
 	"github.com/erigontech/erigon/execution/engineapi/engine_helpers"
 	"github.com/erigontech/erigon/execution/engineapi/engine_logs_spammer"
+	"github.com/erigontech/erigon/execution/engineapi/engine_recorder"
 	"github.com/erigontech/erigon/execution/engineapi/engine_types"
 	"github.com/erigontech/erigon/execution/execmodule"
 	"github.com/erigontech/erigon/execution/execmodule/chainreader"
@@ -79,6 +80,7 @@ type EngineServer struct {
 	logger  log.Logger
 
 	engineLogSpamer *engine_logs_spammer.EngineLogsSpammer
+	recordPath      string // file to record Engine API calls to, empty - no recording
 	// TODO Remove this on next release
 	printPectraBanner bool
 }
@@ -95,6 +97,7 @@ func NewEngineServer(
 	consuming bool,
 	txPool txpoolproto.TxpoolClient,
 	fcuTimeout time.Duration,
+	recordPath string,
 ) *EngineServer {
 	if fcuTimeout == 0 {
 		fcuTimeout = DefaultFcuTimeout
@@ -111,6 +114,7 @@ func NewEngineServer(
 		engineLogSpamer:   engine_logs_spammer.NewEngineLogsSpammer(logger, config),
 		printPectraBanner: true,
 		txpool:            txPool,
+		recordPath:        recordPath,
 	}
 
 	srv.consuming.Store(consuming)
@@ -129,6 +133,17 @@ func (e *EngineServer) Start(
 	eth rpchelper.ApiBackend,
 	mining txpoolproto.MiningClient,
 ) error {
+	var engineImpl any = EngineAPI(e)
+	if e.recordPath != "" {
+		recorder, err := engine_recorder.NewRecorder(e.recordPath, e.logger)
+		if err != nil {
+			return err
+		}
+		defer recorder.Close()
+		e.logger.Info("[EngineServer] recording Engine API calls", "file", e.recordPath)
+		engineImpl = &recordingEngineAPI{e: e, recorder: recorder}
+	}
+
 	var eg errgroup.Group
 	if !e.caplin {
 		eg.Go(func() error {
@@ -149,7 +164,7 @@ func (e *EngineServer) Start(
 		}, {
 			Namespace: "engine",
 			Public:    true,
-			Service:   EngineAPI(e),
+			Service:   engineImpl,
 			Version:   "1.0",
 		}}
 
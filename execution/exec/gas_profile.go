@@ -0,0 +1,63 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/tracing/tracers/native"
+)
+
+// ProfileGas re-executes the blocks [fromBlock, toBlock] on historical state and aggregates the gas their
+// txns used by contract, selector, opcode and precompile, see native.GasProfile
+func ProfileGas(ctx context.Context, fromBlock, toBlock uint64, tx kv.TemporalTx, cfg ExecArgs, logger log.Logger) (*native.GasProfile, error) {
+	if fromBlock == 0 {
+		return nil, errors.New("genesis block has no txns to profile")
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("from block (%d) must not be later than to block (%d)", fromBlock, toBlock)
+	}
+
+	cfg.CustomTraces = []string{native.GasProfileTrace}
+	profile := native.NewGasProfile()
+	if err := CustomTraceMapReduce(ctx, fromBlock, toBlock+1, TraceConsumerFunc(func(_ *BlockResult, result *TxResult, _ kv.TemporalTx) error {
+		if result.Err != nil {
+			return result.Err
+		}
+		if len(result.CustomTraces) == 0 { // system txns
+			return nil
+		}
+		res, err := result.CustomTraces[0].GetResult()
+		if err != nil {
+			return err
+		}
+		var txProfile native.GasProfile
+		if err := json.Unmarshal(res, &txProfile); err != nil {
+			return err
+		}
+		profile.Merge(&txProfile)
+		return nil
+	}), tx, &cfg, logger); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -43,6 +43,8 @@ import (
 	"github.com/erigontech/erigon/execution/state/genesiswrite"
 	"github.com/erigontech/erigon/execution/tracing"
 	"github.com/erigontech/erigon/execution/tracing/calltracer"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
+	"github.com/erigontech/erigon/execution/tracing/tracers/native"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/execution/vm"
 	"github.com/erigontech/erigon/execution/vm/evmtypes"
@@ -199,7 +201,20 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *TxTask) *TxResult {
 	case txTask.IsBlockEnd():
 		// this is handled by the reducer in process results
 	default:
-		tracer := calltracer.NewCallTracer(nil)
+		customTraces, err := rw.newCustomTraces(txTask)
+		if err != nil {
+			result.Err = err
+			break
+		}
+		var customHooks *tracing.Hooks
+		if len(customTraces) > 0 {
+			customHooks = native.NewMuxTracer(rw.execArgs.CustomTraces, customTraces).Hooks
+			ibs.SetHooks(customHooks)
+			defer ibs.SetHooks(hooks)
+		}
+		result.CustomTraces = customTraces
+
+		tracer := calltracer.NewCallTracer(customHooks)
 		result.Err = func() error {
 			rw.taskGasPool.Reset(txTask.Tx().GetGasLimit(), cc.GetMaxBlobGasPerBlock(header.Time))
 			rw.vmCfg.Tracer = tracer.Tracer().Hooks
@@ -233,6 +248,9 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *TxTask) *TxResult {
 			if hooks != nil && hooks.OnTxStart != nil {
 				hooks.OnTxStart(rw.evm.GetVMContext(), txn, msg.From())
 			}
+			if customHooks != nil {
+				customHooks.OnTxStart(rw.evm.GetVMContext(), txn, msg.From())
+			}
 
 			// MA applytx
 			applyRes, err := protocol.ApplyMessage(rw.evm, msg, rw.taskGasPool, true /* refunds */, false /* gasBailout */, rw.execArgs.Engine)
@@ -254,6 +272,27 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *TxTask) *TxResult {
 	return &result
 }
 
+// newCustomTraces creates the tracers of ExecArgs.CustomTraces for the txn of the task
+func (rw *HistoricalTraceWorker) newCustomTraces(txTask *TxTask) ([]*tracers.Tracer, error) {
+	if len(rw.execArgs.CustomTraces) == 0 {
+		return nil, nil
+	}
+	tctx := &tracers.Context{BlockHash: txTask.BlockHash(), TxIndex: txTask.TxIndex, TxHash: txTask.TxHash()}
+	customTraces := make([]*tracers.Tracer, len(rw.execArgs.CustomTraces))
+	for i, name := range rw.execArgs.CustomTraces {
+		ctor, ok := tracers.LookupCustomTrace(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown custom trace: %s", name)
+		}
+		t, err := ctor(tctx)
+		if err != nil {
+			return nil, fmt.Errorf("custom trace %s: %w", name, err)
+		}
+		customTraces[i] = t
+	}
+	return customTraces, nil
+}
+
 func (rw *HistoricalTraceWorker) execAATxn(txTask *TxTask, tracer *calltracer.CallTracer) *TxResult {
 	result := &TxResult{}
 
@@ -347,6 +386,8 @@ type ExecArgs struct {
 	Dirs        datadir.Dirs
 	ChainConfig *chain.Config
 	Workers     int
+
+	CustomTraces []string // names of the registered custom traces run over every txn, see tracers.RegisterCustomTrace
 }
 
 func NewHistoricalTraceWorkers(consumer TraceConsumer, cfg *ExecArgs, ctx context.Context, toTxNum uint64, in *QueueWithRetry, workerCount int, outputTxNum *atomic.Uint64, logger log.Logger) *errgroup.Group {
@@ -469,6 +510,11 @@ func (p *historicalResultProcessor) processResults(consumer TraceConsumer, cfg *
 		if hooks != nil && hooks.OnTxEnd != nil {
 			hooks.OnTxEnd(receipt, err)
 		}
+		for _, t := range result.CustomTraces {
+			if t.OnTxEnd != nil {
+				t.OnTxEnd(receipt, err)
+			}
+		}
 
 		if err != nil {
 			return outputTxNum, false, fmt.Errorf("bn=%d, tn=%d: %w", result.BlockNumber(), result.Version().TxNum, result.Err)
@@ -39,6 +39,7 @@ import (
 	"github.com/erigontech/erigon/execution/state/genesiswrite"
 	"github.com/erigontech/erigon/execution/tracing"
 	"github.com/erigontech/erigon/execution/tracing/calltracer"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/execution/vm"
 	"github.com/erigontech/erigon/execution/vm/evmtypes"
@@ -111,6 +112,8 @@ type TxResult struct {
 	TraceFroms        map[common.Address]struct{}
 	TraceTos          map[common.Address]struct{}
 	AccessedAddresses map[common.Address]struct{}
+
+	CustomTraces []*tracers.Tracer // tracers of ExecArgs.CustomTraces which ran the txn, in the same order
 }
 
 func (r *TxResult) compare(other *TxResult) int {
@@ -0,0 +1,163 @@
+package stagedsync
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// balDependencies derives, from a block access list, the dependencies between the
+// transactions of a block which have to be respected to execute them in parallel
+// without speculation. Indices are block access list indices: 0 is the pre-execution
+// system calls, i is transaction i-1 and len(txs)+1 the post-execution calls, which
+// is also the index of the corresponding exec task.
+//
+// The access list seeds the version map with every storage, balance, nonce and code
+// write, so later readers of those never conflict with the writer. What it can't
+// seed is the account object itself and its code hash and size, which are only known
+// once the writing transaction has run: a transaction writing an account therefore
+// waits for the last earlier transaction which created it (absent reports accounts
+// which don't exist before the block) or changed its code.
+//
+// Transactions which only read such an account aren't indexed by the access list and
+// so remain speculative; they are re-executed if validation finds them stale.
+func balDependencies(bal types.BlockAccessList, absent func(common.Address) bool) map[int][]int {
+	deps := map[int][]int{}
+
+	for _, account := range bal {
+		writers := balWriters(account)
+		if len(writers) == 0 {
+			continue
+		}
+
+		creator := -1
+		if absent != nil && absent(account.Address) {
+			creator = writers[0]
+		}
+
+		codeChanges := make([]int, 0, len(account.CodeChanges))
+		for _, change := range account.CodeChanges {
+			codeChanges = append(codeChanges, int(change.Index))
+		}
+		slices.Sort(codeChanges)
+
+		for _, writer := range writers {
+			dep := creator
+			for _, index := range codeChanges {
+				if index >= writer {
+					break
+				}
+				dep = max(dep, index)
+			}
+			if dep >= 0 && dep < writer && !slices.Contains(deps[writer], dep) {
+				deps[writer] = append(deps[writer], dep)
+			}
+		}
+	}
+
+	for _, d := range deps {
+		slices.Sort(d)
+	}
+
+	return deps
+}
+
+// balWriters returns the sorted access list indices which change the account
+func balWriters(account *types.AccountChanges) []int {
+	var writers []int
+	for _, slot := range account.StorageChanges {
+		for _, change := range slot.Changes {
+			writers = append(writers, int(change.Index))
+		}
+	}
+	for _, change := range account.BalanceChanges {
+		writers = append(writers, int(change.Index))
+	}
+	for _, change := range account.NonceChanges {
+		writers = append(writers, int(change.Index))
+	}
+	for _, change := range account.CodeChanges {
+		writers = append(writers, int(change.Index))
+	}
+	slices.Sort(writers)
+	return slices.Compact(writers)
+}
+
+// prefetchBAL reads every account, code and storage slot the block access list names
+// so that the domain files and db pages the block's transactions need are warm by
+// the time they execute. Values still in the shared domains' memory batch don't need
+// warming, so it reads straight from tx.
+func prefetchBAL(ctx context.Context, tx kv.TemporalTx, bal types.BlockAccessList) error {
+	var composite [20 + 32]byte
+
+	for _, account := range bal {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if _, _, err := tx.GetLatest(kv.AccountsDomain, account.Address[:]); err != nil {
+			return err
+		}
+		if _, _, err := tx.GetLatest(kv.CodeDomain, account.Address[:]); err != nil {
+			return err
+		}
+
+		copy(composite[:20], account.Address[:])
+		for _, slot := range account.StorageChanges {
+			copy(composite[20:], slot.Slot[:])
+			if _, _, err := tx.GetLatest(kv.StorageDomain, composite[:]); err != nil {
+				return err
+			}
+		}
+		for _, slot := range account.StorageReads {
+			copy(composite[20:], slot[:])
+			if _, _, err := tx.GetLatest(kv.StorageDomain, composite[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pe *parallelExecutor) balDependencies(accessList types.BlockAccessList) map[int][]int {
+	pe.RLock()
+	applyTx := pe.applyTx
+	pe.RUnlock()
+
+	var absent func(common.Address) bool
+	if applyTx != nil {
+		getter := pe.rs.Domains().AsGetter(applyTx)
+		absent = func(addr common.Address) bool {
+			// if the account can't be read assume it is created, which only
+			// costs some parallelism
+			enc, _, err := getter.GetLatest(kv.AccountsDomain, addr[:])
+			return err != nil || len(enc) == 0
+		}
+	}
+
+	return balDependencies(accessList, absent)
+}
+
+func (pe *parallelExecutor) prefetchBAL(ctx context.Context, accessList types.BlockAccessList) {
+	temporalDb, ok := pe.cfg.db.(kv.TemporalRoDB)
+	if !ok {
+		return
+	}
+
+	go func() {
+		tx, err := temporalDb.BeginTemporalRo(ctx) //nolint:gocritic
+		if err != nil {
+			return
+		}
+		defer tx.Rollback()
+
+		if err := prefetchBAL(ctx, tx, accessList); err != nil && !errors.Is(err, context.Canceled) {
+			pe.logger.Debug("["+pe.logPrefix+"] block access list prefetch failed", "err", err)
+		}
+	}()
+}
@@ -0,0 +1,86 @@
+package stagedsync
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+func TestBALDependencies(t *testing.T) {
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	contract := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	fresh := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	token := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	bal := types.BlockAccessList{
+		{
+			// fee recipient: written by every transaction but never created
+			Address: coinbase,
+			BalanceChanges: []*types.BalanceChange{
+				{Index: 1, Value: *uint256.NewInt(1)},
+				{Index: 2, Value: *uint256.NewInt(2)},
+				{Index: 3, Value: *uint256.NewInt(3)},
+				{Index: 4, Value: *uint256.NewInt(4)},
+			},
+		},
+		{
+			// deployed by tx 1, called by txs 2 and 4
+			Address:     contract,
+			CodeChanges: []*types.CodeChange{{Index: 1, Data: []byte{0x60}}},
+			NonceChanges: []*types.NonceChange{
+				{Index: 1, Value: 1},
+			},
+			StorageChanges: []*types.SlotChanges{{
+				Slot: common.Hash{1},
+				Changes: []*types.StorageChange{
+					{Index: 2, Value: *uint256.NewInt(1)},
+					{Index: 4, Value: *uint256.NewInt(2)},
+				},
+			}},
+		},
+		{
+			// created by a transfer in tx 2 and paid again in tx 3
+			Address: fresh,
+			BalanceChanges: []*types.BalanceChange{
+				{Index: 2, Value: *uint256.NewInt(1)},
+				{Index: 3, Value: *uint256.NewInt(2)},
+			},
+		},
+		{
+			// existing contract: storage writes alone don't order transactions
+			Address: token,
+			StorageChanges: []*types.SlotChanges{{
+				Slot: common.Hash{2},
+				Changes: []*types.StorageChange{
+					{Index: 1, Value: *uint256.NewInt(1)},
+					{Index: 3, Value: *uint256.NewInt(2)},
+				},
+			}},
+			StorageReads: []common.Hash{{3}},
+		},
+	}
+
+	absent := func(addr common.Address) bool {
+		return addr == contract || addr == fresh
+	}
+
+	deps := balDependencies(bal, absent)
+	require.Equal(t, map[int][]int{
+		2: {1},
+		3: {2},
+		4: {1},
+	}, deps)
+
+	// without pre-state only code changes order transactions
+	deps = balDependencies(bal, nil)
+	require.Equal(t, map[int][]int{
+		2: {1},
+		4: {1},
+	}, deps)
+
+	require.Empty(t, balDependencies(nil, absent))
+}
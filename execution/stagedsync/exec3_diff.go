@@ -0,0 +1,614 @@
+package stagedsync
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/exec"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+)
+
+// TxExecution is what executing a transaction produced, as recorded for ExecDiff. The
+// system calls at the start and the end of a block are recorded as the transactions
+// with index -1 and len(txs), the indexes of their tasks.
+//
+// Writes are the calls the executor made to its state writer for the transaction, so
+// they are comparable between the serial and the parallel executor. Reads are the
+// serial executor's state reads, and the version map reads of the parallel executor:
+// both name the keys a transaction read, but not necessarily at the same path.
+type TxExecution struct {
+	BlockNum uint64
+	TxIndex  int
+	TxNum    uint64
+	GasUsed  uint64
+	Receipt  *types.Receipt
+	Logs     []*types.Log
+	Reads    state.ReadSet
+	Writes   state.VersionedWrites
+}
+
+// ExecutionRecord is the record of executing a range of blocks with one of the executors
+type ExecutionRecord struct {
+	Parallel bool
+	Txs      []*TxExecution // in block and transaction order
+	Err      error          // the error the execution stopped with, if any
+
+	// the block executors' VersionedIO and VersionMap, parallel execution only
+	blockIO     map[uint64]*state.VersionedIO
+	versionMaps map[uint64]*state.VersionMap
+}
+
+func (r *ExecutionRecord) versionedIO(blockNum uint64) *state.VersionedIO {
+	if txIO, ok := r.blockIO[blockNum]; ok {
+		return txIO
+	}
+	txIO := &state.VersionedIO{}
+	for _, txn := range r.Txs {
+		if txn.BlockNum == blockNum {
+			version := state.Version{BlockNum: blockNum, TxIndex: txn.TxIndex, TxNum: txn.TxNum}
+			txIO.RecordReads(version, txn.Reads)
+			txIO.RecordWrites(version, txn.Writes)
+		}
+	}
+	return txIO
+}
+
+// execRecorder records the transactions an executor runs. The executors call it from
+// their apply loops through ExecuteBlockCfg.recorder, which only RecordExecution sets,
+// its methods are no-ops on a nil recorder.
+type execRecorder struct {
+	mu          sync.Mutex
+	record      *ExecutionRecord
+	current     *TxExecution
+	keepVersion bool // keep the parallel executor's version maps
+}
+
+func newExecRecorder(parallel bool, keepVersionMaps bool) *execRecorder {
+	return &execRecorder{
+		record: &ExecutionRecord{
+			Parallel:    parallel,
+			blockIO:     map[uint64]*state.VersionedIO{},
+			versionMaps: map[uint64]*state.VersionMap{},
+		},
+		keepVersion: keepVersionMaps,
+	}
+}
+
+// begin starts recording the reads and writes of a transaction
+func (r *execRecorder) begin(blockNum uint64, txIndex int, txNum uint64) *TxExecution {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = &TxExecution{BlockNum: blockNum, TxIndex: txIndex, TxNum: txNum, Reads: state.ReadSet{}}
+	return r.current
+}
+
+// finish completes the record of a transaction begun with begin, adding the reads of the
+// parallel executor. It finishes its transactions when it publishes them, after it has
+// finalized later ones, and the end of a block once it has finalized the block.
+func (r *execRecorder) finish(txn *TxExecution, result *exec.TxResult, receipt *types.Receipt, reads ...state.ReadSet) {
+	if r == nil || txn == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, readSet := range reads {
+		readSet.Scan(func(read *state.VersionedRead) bool {
+			txn.Reads.Set(*read)
+			return true
+		})
+	}
+	// the parallel executor drops the coinbase and burnt contract from a transaction's
+	// read set to re-read them when it finalizes the transaction, so ignore them in both
+	delete(txn.Reads, result.Coinbase)
+	delete(txn.Reads, result.ExecutionResult.BurntContractAddress)
+
+	txn.GasUsed = result.ExecutionResult.GasUsed
+	txn.Receipt = receipt
+	txn.Logs = result.Logs
+	r.record.Txs = append(r.record.Txs, txn)
+	if r.current == txn {
+		r.current = nil
+	}
+}
+
+func (r *execRecorder) parallelBlock(blockNum uint64, blockIO *state.VersionedIO, versionMap *state.VersionMap) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record.blockIO[blockNum] = blockIO
+	if r.keepVersion {
+		r.record.versionMaps[blockNum] = versionMap
+	}
+}
+
+func (r *execRecorder) read(addr common.Address, path state.AccountPath, key common.Hash, val any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil {
+		r.current.Reads.Set(state.VersionedRead{Address: addr, Path: path, Key: key, Source: state.StorageRead, Val: val})
+	}
+}
+
+func (r *execRecorder) write(txn *TxExecution, addr common.Address, path state.AccountPath, key common.Hash, val any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if txn == nil {
+		txn = r.current
+	}
+	if txn != nil {
+		txn.Writes = append(txn.Writes, &state.VersionedWrite{Address: addr, Path: path, Key: key, Version: state.Version{BlockNum: txn.BlockNum, TxIndex: txn.TxIndex, TxNum: txn.TxNum}, Val: val})
+	}
+}
+
+// recordingReader records the reads of the serial executor into its current transaction
+type recordingReader struct {
+	state.StateReader
+	recorder *execRecorder
+}
+
+func (r *recordingReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	account, err := r.StateReader.ReadAccountData(address)
+	if err == nil {
+		var val any
+		if account != nil {
+			val = *account
+		}
+		r.recorder.read(address, state.AddressPath, common.Hash{}, val)
+	}
+	return account, err
+}
+
+func (r *recordingReader) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	value, ok, err := r.StateReader.ReadAccountStorage(address, key)
+	if err == nil {
+		r.recorder.read(address, state.StoragePath, key, value)
+	}
+	return value, ok, err
+}
+
+func (r *recordingReader) ReadAccountCode(address common.Address) ([]byte, error) {
+	code, err := r.StateReader.ReadAccountCode(address)
+	if err == nil {
+		r.recorder.read(address, state.CodePath, common.Hash{}, common.CopyBytes(code))
+	}
+	return code, err
+}
+
+func (r *recordingReader) ReadAccountCodeSize(address common.Address) (int, error) {
+	size, err := r.StateReader.ReadAccountCodeSize(address)
+	if err == nil {
+		r.recorder.read(address, state.CodeSizePath, common.Hash{}, size)
+	}
+	return size, err
+}
+
+func (r *recordingReader) SetTx(tx kv.Tx) {
+	if resettable, ok := r.StateReader.(interface{ SetTx(kv.Tx) }); ok {
+		resettable.SetTx(tx)
+	}
+}
+
+func (r *recordingReader) SetTxNum(txNum uint64) {
+	if resettable, ok := r.StateReader.(interface{ SetTxNum(uint64) }); ok {
+		resettable.SetTxNum(txNum)
+	}
+}
+
+// recordingWriter records the writes of a transaction, into the transaction it was
+// created for or, if none, into the current transaction of the recorder.
+type recordingWriter struct {
+	state.StateWriter
+	recorder *execRecorder
+	txn      *TxExecution
+}
+
+func (w *recordingWriter) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	w.recorder.write(w.txn, address, state.AddressPath, common.Hash{}, *account)
+	return w.StateWriter.UpdateAccountData(address, original, account)
+}
+
+func (w *recordingWriter) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	w.recorder.write(w.txn, address, state.CodePath, common.Hash{}, common.CopyBytes(code))
+	return w.StateWriter.UpdateAccountCode(address, incarnation, codeHash, code)
+}
+
+func (w *recordingWriter) DeleteAccount(address common.Address, original *accounts.Account) error {
+	w.recorder.write(w.txn, address, state.SelfDestructPath, common.Hash{}, true)
+	return w.StateWriter.DeleteAccount(address, original)
+}
+
+func (w *recordingWriter) WriteAccountStorage(address common.Address, incarnation uint64, key common.Hash, original, value uint256.Int) error {
+	w.recorder.write(w.txn, address, state.StoragePath, key, value)
+	return w.StateWriter.WriteAccountStorage(address, incarnation, key, original, value)
+}
+
+func (w *recordingWriter) SetTxNum(txNum uint64) {
+	if resettable, ok := w.StateWriter.(interface{ SetTxNum(uint64) }); ok {
+		resettable.SetTxNum(txNum)
+	}
+}
+
+func (r *execRecorder) reader(reader state.StateReader) state.StateReader {
+	if r == nil {
+		return reader
+	}
+	return &recordingReader{StateReader: reader, recorder: r}
+}
+
+func (r *execRecorder) writer(writer state.StateWriter, txn *TxExecution) state.StateWriter {
+	if r == nil {
+		return writer
+	}
+	return &recordingWriter{StateWriter: writer, recorder: r, txn: txn}
+}
+
+// RecordExecution executes the blocks from fromBlock to toBlock in tx with the serial or
+// the parallel executor and records what each of their transactions read, wrote and
+// produced. Nothing is committed, the caller is expected to roll tx back.
+//
+// The executed state has to be at fromBlock-1: the parallel executor's workers read
+// from their own transactions, so they wouldn't see the state unwound in tx.
+// An execution that fails is recorded up to the failure, with the error in the record.
+func RecordExecution(ctx context.Context, syncer *Sync, tx kv.TemporalRwTx, cfg ExecuteBlockCfg, parallel bool, workerCount int, fromBlock, toBlock uint64, keepVersionMaps bool, logger log.Logger) (*ExecutionRecord, error) {
+	s, err := syncer.StageState(stages.Execution, tx, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if s.BlockNumber+1 != fromBlock {
+		return nil, fmt.Errorf("%w: state is at block %d, can't execute from block %d", ErrExecDiffRange, s.BlockNumber, fromBlock)
+	}
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("%w: block %d is before block %d", ErrExecDiffRange, toBlock, fromBlock)
+	}
+
+	recorder := newExecRecorder(parallel, keepVersionMaps)
+	cfg.recorder = recorder
+	err = ExecV3(ctx, s, nil, workerCount, cfg, nil, tx, parallel, toBlock, logger, nil, false, false)
+	var errExhausted *ErrLoopExhausted
+	if errors.As(err, &errExhausted) {
+		err = nil
+	}
+	recorder.record.Err = err
+	slices.SortStableFunc(recorder.record.Txs, func(a, b *TxExecution) int {
+		return cmp.Or(cmp.Compare(a.BlockNum, b.BlockNum), cmp.Compare(a.TxIndex, b.TxIndex))
+	})
+	return recorder.record, nil
+}
+
+var ErrExecDiffRange = errors.New("blocks can't be executed")
+
+// ExecDiff executes the blocks from fromBlock to toBlock twice, with the serial and the
+// parallel executor, each in its own transaction which is rolled back, and returns the
+// first transaction for which the two executions differ, or nil if they agree.
+func ExecDiff(ctx context.Context, db kv.TemporalRwDB, syncer *Sync, cfg ExecuteBlockCfg, workerCount int, fromBlock, toBlock uint64, dumpVersionMap bool, logger log.Logger) (*ExecDivergence, error) {
+	record := func(parallel bool) (*ExecutionRecord, error) {
+		tx, err := db.BeginTemporalRw(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		return RecordExecution(ctx, syncer, tx, cfg, parallel, workerCount, fromBlock, toBlock, dumpVersionMap, logger)
+	}
+
+	serial, err := record(false)
+	if err != nil {
+		return nil, err
+	}
+	parallel, err := record(true)
+	if err != nil {
+		return nil, err
+	}
+	return DiffExecutions(serial, parallel), nil
+}
+
+// ExecDivergence is the first transaction for which a serial and a parallel execution
+// differ. Serial or Parallel is nil if only one of the executions got to it.
+type ExecDivergence struct {
+	BlockNum    uint64
+	TxIndex     int
+	Differences []string
+	Serial      *TxExecution
+	Parallel    *TxExecution
+
+	serialIO, parallelIO *state.VersionedIO
+	versionMap           *state.VersionMap
+	addresses            []common.Address // the addresses whose reads or writes differ
+}
+
+// DiffExecutions compares two records of executing the same blocks transaction by
+// transaction and returns the first difference, or nil if there are none.
+func DiffExecutions(serial, parallel *ExecutionRecord) *ExecDivergence {
+	n := max(len(serial.Txs), len(parallel.Txs))
+	for i := 0; i < n; i++ {
+		var s, p *TxExecution
+		if i < len(serial.Txs) {
+			s = serial.Txs[i]
+		}
+		if i < len(parallel.Txs) {
+			p = parallel.Txs[i]
+		}
+
+		d := &ExecDivergence{Serial: s, Parallel: p}
+		switch {
+		case s == nil:
+			d.BlockNum, d.TxIndex = p.BlockNum, p.TxIndex
+			d.Differences = append(d.Differences, fmt.Sprintf("serial execution stopped before it: %v", serial.Err))
+		case p == nil:
+			d.BlockNum, d.TxIndex = s.BlockNum, s.TxIndex
+			d.Differences = append(d.Differences, fmt.Sprintf("parallel execution stopped before it: %v", parallel.Err))
+		case s.BlockNum != p.BlockNum || s.TxIndex != p.TxIndex:
+			d.BlockNum, d.TxIndex = s.BlockNum, s.TxIndex
+			d.Differences = append(d.Differences, fmt.Sprintf("parallel execution has txn %d.%d in its place", p.BlockNum, p.TxIndex))
+		default:
+			d.BlockNum, d.TxIndex = s.BlockNum, s.TxIndex
+			d.diff(s, p)
+		}
+
+		if len(d.Differences) > 0 {
+			d.serialIO = serial.versionedIO(d.BlockNum)
+			d.parallelIO = parallel.versionedIO(d.BlockNum)
+			d.versionMap = parallel.versionMaps[d.BlockNum]
+			return d
+		}
+	}
+
+	if serial.Err != nil || parallel.Err != nil {
+		if (serial.Err == nil) != (parallel.Err == nil) || serial.Err.Error() != parallel.Err.Error() {
+			return &ExecDivergence{
+				Differences: []string{fmt.Sprintf("executions ended with different errors: serial %v, parallel %v", serial.Err, parallel.Err)},
+			}
+		}
+	}
+	return nil
+}
+
+func (d *ExecDivergence) diff(s, p *TxExecution) {
+	if s.GasUsed != p.GasUsed {
+		d.Differences = append(d.Differences, fmt.Sprintf("gas used: serial %d, parallel %d", s.GasUsed, p.GasUsed))
+	}
+	if diff := diffReceipts(s.Receipt, p.Receipt); diff != "" {
+		d.Differences = append(d.Differences, "receipt "+diff)
+	}
+	if diff := diffLogs(s.Logs, p.Logs); diff != "" {
+		d.Differences = append(d.Differences, "logs "+diff)
+	}
+
+	addresses := map[common.Address]struct{}{}
+
+	// the serial executor knows that the storage of an account it found absent is empty,
+	// without reading it
+	absent := map[common.Address]struct{}{}
+	s.Reads.Scan(func(read *state.VersionedRead) bool {
+		if read.Path == state.AddressPath && read.Val == nil {
+			absent[read.Address] = struct{}{}
+		}
+		return true
+	})
+	// an absent account the transaction only deletes was merely touched, which the executors
+	// do differently: deleting it changes nothing
+	touched := map[common.Address]struct{}{}
+	for addr := range absent {
+		touched[addr] = struct{}{}
+	}
+	for _, write := range s.Writes {
+		if write.Path != state.SelfDestructPath || write.Val != true {
+			delete(touched, write.Address)
+		}
+	}
+	serialReads, parallelReads := readKeys(s.Reads), readKeys(p.Reads)
+	for key := range parallelReads {
+		if _, ok := absent[key.address]; ok && key.Path == state.StoragePath {
+			delete(parallelReads, key)
+		}
+	}
+	for _, keys := range []map[ioKey]struct{}{serialReads, parallelReads} {
+		for key := range keys {
+			if _, ok := touched[key.address]; ok {
+				delete(keys, key)
+			}
+		}
+	}
+	for key := range serialReads {
+		if _, ok := parallelReads[key]; !ok {
+			d.Differences = append(d.Differences, fmt.Sprintf("read only by serial: %x %s", key.address, key.AccountKey))
+			addresses[key.address] = struct{}{}
+		}
+	}
+	for key := range parallelReads {
+		if _, ok := serialReads[key]; !ok {
+			d.Differences = append(d.Differences, fmt.Sprintf("read only by parallel: %x %s", key.address, key.AccountKey))
+			addresses[key.address] = struct{}{}
+		}
+	}
+
+	serialWrites, parallelWrites := writeValues(s.Writes), writeValues(p.Writes)
+	for _, values := range []map[ioKey]*state.VersionedWrite{serialWrites, parallelWrites} {
+		for key := range values {
+			if _, ok := touched[key.address]; ok && key.Path == state.SelfDestructPath {
+				delete(values, key)
+			}
+		}
+	}
+	for key, sv := range serialWrites {
+		pv, ok := parallelWrites[key]
+		switch {
+		case !ok:
+			d.Differences = append(d.Differences, fmt.Sprintf("written only by serial: %x %s", key.address, key.AccountKey))
+		case !sameValue(sv.Val, pv.Val):
+			d.Differences = append(d.Differences, fmt.Sprintf("written differently: serial %s, parallel %s", sv, pv))
+		default:
+			continue
+		}
+		addresses[key.address] = struct{}{}
+	}
+	for key := range parallelWrites {
+		if _, ok := serialWrites[key]; !ok {
+			d.Differences = append(d.Differences, fmt.Sprintf("written only by parallel: %x %s", key.address, key.AccountKey))
+			addresses[key.address] = struct{}{}
+		}
+	}
+
+	slices.Sort(d.Differences)
+	for addr := range addresses {
+		d.addresses = append(d.addresses, addr)
+	}
+	slices.SortFunc(d.addresses, func(a, b common.Address) int { return a.Cmp(b) })
+}
+
+type ioKey struct {
+	address common.Address
+	state.AccountKey
+}
+
+// readKeys returns the keys of the reads of a transaction, by account and storage slot:
+// the serial executor reads whole accounts where the parallel one reads fields, and
+// code, which the serial executor doesn't read when it is empty, comes with the code
+// hash of the account
+func readKeys(reads state.ReadSet) map[ioKey]struct{} {
+	keys := map[ioKey]struct{}{}
+	reads.Scan(func(read *state.VersionedRead) bool {
+		key := ioKey{address: read.Address, AccountKey: state.AccountKey{Path: state.AddressPath}}
+		if read.Path == state.StoragePath {
+			key.AccountKey = state.AccountKey{Path: state.StoragePath, Key: read.Key}
+		}
+		keys[key] = struct{}{}
+		return true
+	})
+	return keys
+}
+
+// writeValues returns the final value of each key a transaction wrote
+func writeValues(writes state.VersionedWrites) map[ioKey]*state.VersionedWrite {
+	values := map[ioKey]*state.VersionedWrite{}
+	for _, write := range writes {
+		values[ioKey{write.Address, state.AccountKey{Path: write.Path, Key: write.Key}}] = write
+	}
+	return values
+}
+
+// sameValue compares written values, accounts by nonce, balance and code hash: their
+// incarnations are bookkeeping the executors don't keep alike and not part of the state
+func sameValue(a, b any) bool {
+	if a, ok := a.(accounts.Account); ok {
+		b, ok := b.(accounts.Account)
+		return ok && a.Nonce == b.Nonce && a.Balance == b.Balance && a.CodeHash == b.CodeHash
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func diffReceipts(s, p *types.Receipt) string {
+	switch {
+	case s == nil && p == nil:
+		return ""
+	case s == nil || p == nil:
+		return fmt.Sprintf("serial %v, parallel %v", s != nil, p != nil)
+	}
+	var diffs []string
+	if s.Status != p.Status {
+		diffs = append(diffs, fmt.Sprintf("status %d/%d", s.Status, p.Status))
+	}
+	if s.CumulativeGasUsed != p.CumulativeGasUsed {
+		diffs = append(diffs, fmt.Sprintf("cumulative gas used %d/%d", s.CumulativeGasUsed, p.CumulativeGasUsed))
+	}
+	if s.GasUsed != p.GasUsed {
+		diffs = append(diffs, fmt.Sprintf("gas used %d/%d", s.GasUsed, p.GasUsed))
+	}
+	if s.ContractAddress != p.ContractAddress {
+		diffs = append(diffs, fmt.Sprintf("contract address %x/%x", s.ContractAddress, p.ContractAddress))
+	}
+	if s.Bloom != p.Bloom {
+		diffs = append(diffs, "bloom")
+	}
+	if len(diffs) == 0 {
+		return ""
+	}
+	return "(serial/parallel): " + strings.Join(diffs, ", ")
+}
+
+func diffLogs(s, p []*types.Log) string {
+	if len(s) != len(p) {
+		return fmt.Sprintf("count: serial %d, parallel %d", len(s), len(p))
+	}
+	for i := range s {
+		if s[i].Address != p[i].Address || !slices.Equal(s[i].Topics, p[i].Topics) || !bytes.Equal(s[i].Data, p[i].Data) || s[i].Index != p[i].Index {
+			return fmt.Sprintf("%d: serial %x %x %x (index %d), parallel %x %x %x (index %d)", i,
+				s[i].Address, s[i].Topics, s[i].Data, s[i].Index, p[i].Address, p[i].Topics, p[i].Data, p[i].Index)
+		}
+	}
+	return ""
+}
+
+func (d *ExecDivergence) String() string {
+	var b strings.Builder
+	d.Dump(&b, false)
+	return b.String()
+}
+
+// Dump writes the divergence, the serial and parallel VersionedIO of the diverging
+// transaction and, if asked for and recorded, the entries of the parallel executor's
+// VersionMap the transaction read for the addresses whose reads or writes differ.
+func (d *ExecDivergence) Dump(w io.Writer, versionMap bool) {
+	fmt.Fprintf(w, "block %d txn %d diverges:\n", d.BlockNum, d.TxIndex)
+	for _, difference := range d.Differences {
+		fmt.Fprintf(w, "  %s\n", difference)
+	}
+
+	dumpIO := func(name string, txIO *state.VersionedIO) {
+		if txIO == nil {
+			return
+		}
+		prefix := fmt.Sprintf("%s %d (%d.%d)", name, d.BlockNum, d.TxIndex, txIO.ReadSetIncarnation(d.TxIndex))
+
+		var reads []*state.VersionedRead
+		txIO.ReadSet(d.TxIndex).Scan(func(vr *state.VersionedRead) bool {
+			reads = append(reads, vr)
+			return true
+		})
+		slices.SortFunc(reads, func(a, b *state.VersionedRead) int { return a.Address.Cmp(b.Address) })
+		for _, vr := range reads {
+			fmt.Fprintln(w, prefix, "RD", vr.String())
+		}
+
+		writes := slices.Clone(txIO.WriteSet(d.TxIndex))
+		slices.SortStableFunc(writes, func(a, b *state.VersionedWrite) int { return a.Address.Cmp(b.Address) })
+		for _, vw := range writes {
+			fmt.Fprintln(w, prefix, "WRT", vw.String())
+		}
+	}
+	dumpIO("serial", d.serialIO)
+	dumpIO("parallel", d.parallelIO)
+
+	if !versionMap || d.versionMap == nil || d.parallelIO == nil {
+		return
+	}
+	d.parallelIO.ReadSet(d.TxIndex).Scan(func(vr *state.VersionedRead) bool {
+		if _, ok := slices.BinarySearchFunc(d.addresses, vr.Address, func(a, b common.Address) int { return a.Cmp(b) }); !ok {
+			return true
+		}
+		res := d.versionMap.Read(vr.Address, vr.Path, vr.Key, d.TxIndex)
+		fmt.Fprintf(w, "versionmap %d (%d) %x %s: written by %d.%d: %v\n", d.BlockNum, d.TxIndex, vr.Address,
+			state.AccountKey{Path: vr.Path, Key: vr.Key}, res.DepIdx(), res.Incarnation(), res.Value())
+		return true
+	})
+}
@@ -0,0 +1,86 @@
+package stagedsync_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/vm"
+)
+
+func TestExecDiff(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+
+	cfg := stagedsync.StageExecuteBlocksCfg(m.DB, m.Cfg().Prune, m.Cfg().BatchSize, m.ChainConfig, m.Engine, &vm.Config{}, m.Notifications,
+		false /* stateStream */, true /* badBlockHalt */, m.Dirs, m.BlockReader, nil, m.Cfg().Genesis, m.Cfg().Sync, nil, false /* experimentalBAL */)
+
+	// unwind the executed chain, the executions compared run on top of the state it leaves
+	tx, err := m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	s, err := m.Sync.StageState(stages.Execution, tx, nil, false, false)
+	require.NoError(t, err)
+	u := m.Sync.NewUnwindState(stages.Execution, 0, s.BlockNumber, false, false)
+	require.NoError(t, stagedsync.UnwindExecutionStage(u, s, nil, tx, m.Ctx, cfg, m.Log))
+	require.NoError(t, tx.Commit())
+
+	tx, err = m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = stagedsync.RecordExecution(m.Ctx, m.Sync, tx, cfg, false, 2, 2, 11, false, m.Log)
+	require.ErrorIs(t, err, stagedsync.ErrExecDiffRange)
+	serial, err := stagedsync.RecordExecution(m.Ctx, m.Sync, tx, cfg, false, 2, 1, 11, true, m.Log)
+	require.NoError(t, err)
+	require.NoError(t, serial.Err)
+	tx.Rollback()
+
+	tx, err = m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	parallel, err := stagedsync.RecordExecution(m.Ctx, m.Sync, tx, cfg, true, 2, 1, 11, true, m.Log)
+	require.NoError(t, err)
+	require.NoError(t, parallel.Err)
+	tx.Rollback()
+
+	// every block has its system calls recorded on top of its transactions
+	require.Greater(t, len(serial.Txs), 22)
+	require.Len(t, parallel.Txs, len(serial.Txs))
+	require.Nil(t, stagedsync.DiffExecutions(serial, parallel))
+
+	// a transaction writing something else is reported with both executions' IO
+	var txn *stagedsync.TxExecution
+	for _, recorded := range parallel.Txs {
+		if recorded.TxIndex == 0 && len(recorded.Writes) > 0 {
+			txn = recorded
+			break
+		}
+	}
+	require.NotNil(t, txn)
+	writes := txn.Writes
+	txn.GasUsed++
+	txn.Writes = txn.Writes[1:]
+
+	divergence := stagedsync.DiffExecutions(serial, parallel)
+	require.NotNil(t, divergence)
+	require.Equal(t, txn.BlockNum, divergence.BlockNum)
+	require.Equal(t, 0, divergence.TxIndex)
+	require.Contains(t, divergence.Differences[0], "gas used")
+
+	var dump strings.Builder
+	divergence.Dump(&dump, true)
+	require.Contains(t, dump.String(), "written only by serial")
+	require.Contains(t, dump.String(), "serial ")
+	require.Contains(t, dump.String(), "parallel ")
+
+	// the serial execution stopping early is a divergence as well
+	txn.GasUsed--
+	txn.Writes = writes
+	serial.Txs = serial.Txs[:3]
+	divergence = stagedsync.DiffExecutions(serial, parallel)
+	require.NotNil(t, divergence)
+	require.Equal(t, parallel.Txs[3].BlockNum, divergence.BlockNum)
+}
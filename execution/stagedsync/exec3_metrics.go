@@ -32,6 +32,15 @@ var (
 
 	mxExecBlockDuration = metrics.NewGauge("exec_block_dur")
 
+	// parallel execution scheduled from block access lists vs speculatively
+	mxExecBALBlocks                = metrics.NewCounter(`exec_parallel_blocks{mode="bal"}`)
+	mxExecSpeculativeBlocks        = metrics.NewCounter(`exec_parallel_blocks{mode="speculative"}`)
+	mxExecBALReexecutions          = metrics.NewCounter(`exec_parallel_reexecutions{mode="bal"}`)
+	mxExecSpeculativeReexecutions  = metrics.NewCounter(`exec_parallel_reexecutions{mode="speculative"}`)
+	mxExecBALBlockDuration         = metrics.NewSummary(`exec_parallel_block_seconds{mode="bal"}`)
+	mxExecSpeculativeBlockDuration = metrics.NewSummary(`exec_parallel_block_seconds{mode="speculative"}`)
+	mxExecBALMismatches            = metrics.NewCounter(`exec_bal_mismatches`)
+
 	mxExecTxnDuration             = metrics.NewGauge("exec_txn_dur")
 	mxExecTxnExecDuration         = metrics.NewGauge("exec_txn_exec_dur")
 	mxExecTxnReadDuration         = metrics.NewGauge("exec_txn_read_dur")
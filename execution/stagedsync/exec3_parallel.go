@@ -200,11 +200,24 @@ func (pe *parallelExecutor) exec(ctx context.Context, execStage *StageState, u U
 							return fmt.Errorf("block %d: applyCount mismatch: got: %d expected %d", applyResult.BlockNum, blockUpdateCount, applyResult.ApplyCount)
 						}
 
-						if pe.cfg.experimentalBAL {
-							bal := CreateBAL(applyResult.BlockNum, applyResult.TxIO, pe.cfg.dirs.DataDir)
+						// a block which ships an access list has been scheduled from it, so
+						// the access list must be exactly what its execution did
+						blockBAL := b.BlockAccessList()
+						if pe.cfg.experimentalBAL || len(blockBAL) != 0 {
+							var dataDir string
+							if pe.cfg.experimentalBAL {
+								dataDir = pe.cfg.dirs.DataDir
+							}
+							bal := CreateBAL(applyResult.BlockNum, applyResult.TxIO, dataDir)
 							log.Debug("bal", "blockNum", applyResult.BlockNum, "hash", bal.Hash(), "valid", bal.Validate() == nil)
 
-							if pe.cfg.chainConfig.IsGlamsterdam(applyResult.BlockTime) {
+							if len(blockBAL) != 0 && blockBAL.Hash() != bal.Hash() {
+								mxExecBALMismatches.Inc()
+								dumpTxIODebug(applyResult.BlockNum, applyResult.TxIO)
+								return fmt.Errorf("%w: block %d: block access list doesn't match execution: got %s expected %s", rules.ErrInvalidBlock, applyResult.BlockNum, blockBAL.Hash(), bal.Hash())
+							}
+
+							if pe.cfg.experimentalBAL && pe.cfg.chainConfig.IsGlamsterdam(applyResult.BlockTime) {
 								headerBALHash := *lastHeader.BlockAccessListHash
 								if headerBALHash != b.BlockAccessList().Hash() {
 									return fmt.Errorf("block %d: invalid block access list, hash mismatch: got %s expected %s", applyResult.BlockNum, headerBALHash, b.BlockAccessList().Hash())
@@ -701,9 +714,10 @@ func (pe *parallelExecutor) execLoop(ctx context.Context) (err error) {
 						}
 
 						stateWriter := state.NewBufferedWriter(pe.rs, nil)
-						if err = ibs.MakeWriteSet(txTask.EvmBlockContext.Rules(txTask.Config), stateWriter); err != nil {
+						if err = ibs.MakeWriteSet(txTask.EvmBlockContext.Rules(txTask.Config), pe.cfg.recorder.writer(stateWriter, result.recorded)); err != nil {
 							return state.StateUpdates{}, err
 						}
+						pe.cfg.recorder.finish(result.recorded, result.TxResult, nil, result.TxIn, ibs.VersionedReads())
 
 						return stateWriter.WriteSet(), nil
 					}()
@@ -732,6 +746,7 @@ func (pe *parallelExecutor) execLoop(ctx context.Context) (err error) {
 				if !blockExecutor.execStarted.IsZero() {
 					pe.blockExecMetrics.Duration.Add(time.Since(blockExecutor.execStarted))
 					pe.blockExecMetrics.BlockCount.Add(1)
+					updateScheduleModeMetrics(blockExecutor)
 				}
 				blockExecutor.applyResults <- blockResult
 				pe.Lock()
@@ -757,6 +772,17 @@ func (pe *parallelExecutor) processRequest(ctx context.Context, execRequest *exe
 	var scheduleable *blockExecutor
 	var executor *blockExecutor
 
+	accessList := execRequest.accessList
+	if err := accessList.Validate(); err != nil {
+		// don't seed the version map from a malformed list, the block is rejected
+		// once its execution doesn't reproduce it
+		pe.logger.Warn("["+pe.logPrefix+"] ignoring invalid block access list", "block", execRequest.blockNum, "err", err)
+		accessList = nil
+	}
+
+	var balDeps map[int][]int
+	var balOffset int
+
 	for i, txTask := range execRequest.tasks {
 		t := &execTask{
 			Task:               txTask,
@@ -771,7 +797,16 @@ func (pe *parallelExecutor) processRequest(ctx context.Context, execRequest *exe
 			executor, ok = pe.blockExecutors[blockNum]
 
 			if !ok {
-				executor = newBlockExec(blockNum, execRequest.blockHash, execRequest.gasPool, execRequest.accessList, execRequest.applyResults, execRequest.profile, execRequest.exhausted)
+				executor = newBlockExec(blockNum, execRequest.blockHash, execRequest.gasPool, accessList, execRequest.applyResults, execRequest.profile, execRequest.exhausted)
+
+				if len(accessList) != 0 {
+					executor.balScheduled = true
+					balDeps = pe.balDependencies(accessList)
+					// the access list index of task i, this differs from i if
+					// execution starts part way through the block
+					balOffset = t.Version().TxIndex + 1 - i
+					pe.prefetchBAL(ctx, accessList)
+				}
 			}
 		}
 
@@ -793,14 +828,16 @@ func (pe *parallelExecutor) processRequest(ctx context.Context, execRequest *exe
 				executor.execTasks.addDependency(depTxIndex+1, i)
 			}
 			executor.execTasks.clearPending(i)
-		case len(execRequest.accessList) != 0:
-			// if we have an access list we can assume that all
-			// writes are already in the shared memory map so
-			// we can go ahead and schedule all tx jobs
-			// optimistically without needing to worry about
-			// clashes, this should signifigatly improve tx
-			// concurrency
-			break
+		case executor.balScheduled:
+			// the access list has seeded the version map with all of
+			// the block's writes, so tasks only need to wait for the
+			// writes it can't describe - see balDependencies
+			for _, dep := range balDeps[i+balOffset] {
+				if depTask := dep - balOffset; depTask >= 0 {
+					executor.execTasks.addDependency(depTask, i)
+					executor.execTasks.clearPending(i)
+				}
+			}
 		default:
 			sender, err := t.TxSender()
 			if err != nil {
@@ -972,6 +1009,7 @@ type execTask struct {
 type execResult struct {
 	*exec.TxResult
 	stateUpdates *state.StateUpdates
+	recorded     *TxExecution
 }
 
 func (result *execResult) finalize(prevReceipt *types.Receipt, engine rules.Engine, vm *state.VersionMap, stateReader state.StateReader, stateWriter state.StateWriter) (*types.Receipt, error) {
@@ -1173,6 +1211,21 @@ func newBlockExecMetrics() *blockExecMetrics {
 	}
 }
 
+// updateScheduleModeMetrics records a completed block against the way its tasks were
+// scheduled so that access list driven and speculative execution can be compared
+func updateScheduleModeMetrics(be *blockExecutor) {
+	reexecutions := be.cntAbort + be.cntValidationFail
+	if be.balScheduled {
+		mxExecBALBlocks.Inc()
+		mxExecBALReexecutions.AddInt(reexecutions)
+		mxExecBALBlockDuration.ObserveDuration(be.execStarted)
+		return
+	}
+	mxExecSpeculativeBlocks.Inc()
+	mxExecSpeculativeReexecutions.AddInt(reexecutions)
+	mxExecSpeculativeBlockDuration.ObserveDuration(be.execStarted)
+}
+
 type blockDuration struct {
 	atomic.Int64
 	Ema *metrics.EMA[time.Duration]
@@ -1237,6 +1290,9 @@ type blockExecutor struct {
 	// Enable profiling
 	profile bool
 
+	// Whether tasks were scheduled from the block access list rather than speculatively
+	balScheduled bool
+
 	// Stats for debugging purposes
 	cntExec, cntSpecExec, cntSuccess, cntAbort, cntTotalValidations, cntValidationFail, cntFinalized int
 
@@ -1284,7 +1340,7 @@ func (be *blockExecutor) nextResult(ctx context.Context, pe *parallelExecutor, r
 	}
 
 	tx := task.index
-	be.results[tx] = &execResult{res, nil}
+	be.results[tx] = &execResult{TxResult: res}
 	if res.Err != nil {
 		if execErr, ok := res.Err.(protocol.ErrExecAbortError); ok {
 			if execErr.OriginError != nil && be.skipCheck[tx] {
@@ -1491,7 +1547,8 @@ func (be *blockExecutor) nextResult(ctx context.Context, pe *parallelExecutor, r
 
 				stateWriter := state.NewBufferedWriter(pe.rs, nil)
 
-				_, err = txResult.finalize(prevReceipt, pe.cfg.engine, be.versionMap, stateReader, stateWriter)
+				txResult.recorded = pe.cfg.recorder.begin(be.blockNum, txVersion.TxIndex, txVersion.TxNum)
+				_, err = txResult.finalize(prevReceipt, pe.cfg.engine, be.versionMap, stateReader, pe.cfg.recorder.writer(stateWriter, txResult.recorded))
 
 				if err != nil {
 					return nil, err
@@ -1551,6 +1608,10 @@ func (be *blockExecutor) nextResult(ctx context.Context, pe *parallelExecutor, r
 			}
 
 			applyResult.logs = append(applyResult.logs, result.Logs...)
+			if !task.IsBlockEnd() || be.blockNum == 0 {
+				// the block end is finished by execLoop, once it has finalized the block
+				pe.cfg.recorder.finish(result.recorded, result.TxResult, result.Receipt, result.TxIn)
+			}
 			maps.Copy(applyResult.traceFroms, result.TraceFroms)
 			maps.Copy(applyResult.traceTos, result.TraceTos)
 			be.cntFinalized++
@@ -1583,6 +1644,7 @@ func (be *blockExecutor) nextResult(ctx context.Context, pe *parallelExecutor, r
 		}
 
 		isPartial := len(be.tasks) > 0 && be.tasks[0].Version().TxIndex != -1
+		pe.cfg.recorder.parallelBlock(be.blockNum, be.blockIO, be.versionMap)
 
 		txTask := be.tasks[len(be.tasks)-1].Task
 
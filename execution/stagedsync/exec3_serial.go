@@ -346,7 +346,14 @@ func (se *serialExecutor) resetWorkers(ctx context.Context, rs *state.StateV3Buf
 		}
 	}
 
-	se.worker.ResetState(rs, se.applyTx, nil, nil, nil)
+	var stateReader state.StateReader
+	var stateWriter state.StateWriter
+	if se.cfg.recorder != nil {
+		stateReader = se.cfg.recorder.reader(state.NewBufferedReader(rs, state.NewReaderV3(rs.Domains().AsGetter(se.applyTx))))
+		stateWriter = se.cfg.recorder.writer(state.NewWriter(rs.Domains().AsPutDel(se.applyTx), nil, 0), nil)
+	}
+
+	se.worker.ResetState(rs, se.applyTx, stateReader, stateWriter, nil)
 
 	return nil
 }
@@ -383,6 +390,7 @@ func (se *serialExecutor) executeBlock(ctx context.Context, tasks []exec.Task, i
 		txTask.Config = se.cfg.chainConfig
 		txTask.Engine = se.cfg.engine
 
+		recorded := se.cfg.recorder.begin(txTask.BlockNumber(), txTask.TxIndex, txTask.TxNum)
 		result := se.worker.RunTxTask(txTask)
 
 		if err := func() error {
@@ -404,7 +412,7 @@ func (se *serialExecutor) executeBlock(ctx context.Context, tasks []exec.Task, i
 			if txTask.IsBlockEnd() && txTask.BlockNumber() > 0 {
 				//fmt.Printf("txNum=%d, blockNum=%d, finalisation of the block\n", txTask.TxNum, txTask.BlockNum)
 				// End of block transaction in a block
-				ibs := state.New(state.NewReaderV3(se.rs.Domains().AsGetter(se.applyTx)))
+				ibs := state.New(se.cfg.recorder.reader(state.NewReaderV3(se.rs.Domains().AsGetter(se.applyTx))))
 				ibs.SetTxContext(txTask.BlockNumber(), txTask.TxIndex)
 				syscall := func(contract common.Address, data []byte) ([]byte, error) {
 					ret, err := protocol.SysCallContract(contract, data, se.cfg.chainConfig, ibs, txTask.Header, se.cfg.engine, false /* constCall */, *se.cfg.vmConfig)
@@ -442,7 +450,7 @@ func (se *serialExecutor) executeBlock(ctx context.Context, tasks []exec.Task, i
 					}
 				}
 
-				stateWriter := state.NewWriter(se.doms.AsPutDel(se.applyTx), nil, txTask.TxNum)
+				stateWriter := se.cfg.recorder.writer(state.NewWriter(se.doms.AsPutDel(se.applyTx), nil, txTask.TxNum), nil)
 
 				if err = ibs.MakeWriteSet(txTask.Rules(), stateWriter); err != nil {
 					panic(err)
@@ -569,6 +577,7 @@ func (se *serialExecutor) executeBlock(ctx context.Context, tasks []exec.Task, i
 		if txTask.TxIndex >= 0 && txTask.TxIndex < len(blockReceipts) {
 			applyReceipt = blockReceipts[txTask.TxIndex]
 		}
+		se.cfg.recorder.finish(recorded, result, applyReceipt, nil)
 
 		if err := se.rs.ApplyTxState(ctx, se.applyTx, txTask.BlockNumber(), txTask.TxNum, state.StateUpdates{},
 			txTask.BalanceIncreaseSet, applyReceipt, result.Logs, result.TraceFroms, result.TraceTos,
@@ -0,0 +1,161 @@
+package stagedsync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/length"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/commitment/trie"
+	witnesstypes "github.com/erigontech/erigon/execution/commitment/witness"
+	"github.com/erigontech/erigon/execution/protocol"
+	"github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/vm"
+)
+
+// ExecutionWitness is the witness of a block in geth's JSON format: the pre-state trie
+// nodes (account and storage tries), the contract codes and the preimages of the keys
+// (addresses and storage slots) the block touches, plus the headers it needs - its
+// parent first, back to the oldest block whose hash it reads with BLOCKHASH.
+type ExecutionWitness struct {
+	State   []hexutil.Bytes `json:"state"`
+	Codes   []hexutil.Bytes `json:"codes"`
+	Keys    []hexutil.Bytes `json:"keys"`
+	Headers []*types.Header `json:"headers"`
+}
+
+// NewExecutionWitness converts a witness trie, as built by the commitment context for
+// the touched keys of a block, to an ExecutionWitness.
+func NewExecutionWitness(witnessTrie *trie.Trie, touchedPlainKeys [][]byte, codeReads map[common.Hash]witnesstypes.CodeWithHash, headers []*types.Header) (*ExecutionWitness, error) {
+	nodes, err := witnessTrie.StateNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ExecutionWitness{
+		State:   make([]hexutil.Bytes, 0, len(nodes)),
+		Codes:   make([]hexutil.Bytes, 0, len(codeReads)),
+		Headers: headers,
+	}
+	for _, node := range nodes {
+		w.State = append(w.State, node)
+	}
+	for _, code := range codeReads {
+		w.Codes = append(w.Codes, code.Code)
+	}
+	slices.SortFunc(w.Codes, func(a, b hexutil.Bytes) int { return bytes.Compare(a, b) })
+
+	var addresses, slots [][]byte
+	for _, key := range touchedPlainKeys {
+		if len(key) <= length.Addr {
+			addresses = append(addresses, key)
+			continue
+		}
+		addresses = append(addresses, key[:length.Addr])
+		slots = append(slots, key[len(key)-length.Hash:])
+	}
+	for _, keys := range [][][]byte{addresses, slots} {
+		slices.SortFunc(keys, bytes.Compare)
+		for _, key := range slices.CompactFunc(keys, bytes.Equal) {
+			w.Keys = append(w.Keys, common.CopyBytes(key))
+		}
+	}
+	return w, nil
+}
+
+// ExecuteBlockWithExecutionWitness executes block statelessly, reading the pre-state
+// from the trie built out of the witness and block hashes from its headers, and
+// returns the resulting state root. Receipts, gas used and bloom are checked against
+// the block's header as part of the execution; the state root is left to the caller.
+func ExecuteBlockWithExecutionWitness(chainConfig *chain.Config, engine rules.Engine, block *types.Block, witness *ExecutionWitness, logger log.Logger) (common.Hash, error) {
+	if len(witness.Headers) == 0 {
+		return common.Hash{}, errors.New("witness has no parent header")
+	}
+	parent := witness.Headers[0]
+	if parent.Hash() != block.ParentHash() {
+		return common.Hash{}, fmt.Errorf("witness parent header %x doesn't match block parent %x", parent.Hash(), block.ParentHash())
+	}
+
+	headers := make(map[common.Hash]*types.Header, len(witness.Headers))
+	for i, header := range witness.Headers {
+		if i > 0 && witness.Headers[i-1].ParentHash != header.Hash() {
+			return common.Hash{}, fmt.Errorf("witness header %d isn't the parent of header %d", header.Number.Uint64(), witness.Headers[i-1].Number.Uint64())
+		}
+		headers[header.Hash()] = header
+	}
+
+	nodes := make([][]byte, len(witness.State))
+	for i, node := range witness.State {
+		nodes[i] = node
+	}
+	codes := make([][]byte, len(witness.Codes))
+	for i, code := range witness.Codes {
+		codes[i] = code
+	}
+	t, err := trie.BuildTrieFromNodes(parent.Root, nodes, codes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	stateless, err := state.NewStatelessFromTrie(parent.Root, t, parent.Number.Uint64(), false /* trace */)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	getHeader := func(hash common.Hash, number uint64) (*types.Header, error) {
+		if header, ok := headers[hash]; ok && header.Number.Uint64() == number {
+			return header, nil
+		}
+		return nil, fmt.Errorf("header %d (%x) is not in the witness", number, hash)
+	}
+	chainReader := witnessChainReader{config: chainConfig, headers: headers}
+
+	_, err = protocol.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, protocol.GetHashFn(block.Header(), getHeader), engine, block, stateless, stateless, chainReader, nil, logger)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return stateless.Finalize(), nil
+}
+
+// witnessChainReader implements rules.ChainReader over the headers of an ExecutionWitness
+type witnessChainReader struct {
+	config  *chain.Config
+	headers map[common.Hash]*types.Header
+}
+
+func (cr witnessChainReader) Config() *chain.Config                     { return cr.config }
+func (cr witnessChainReader) CurrentHeader() *types.Header              { return nil }
+func (cr witnessChainReader) CurrentFinalizedHeader() *types.Header     { return nil }
+func (cr witnessChainReader) CurrentSafeHeader() *types.Header          { return nil }
+func (cr witnessChainReader) GetTd(common.Hash, uint64) *big.Int        { return nil }
+func (cr witnessChainReader) GetBlock(common.Hash, uint64) *types.Block { return nil }
+func (cr witnessChainReader) HasBlock(common.Hash, uint64) bool         { return false }
+func (cr witnessChainReader) FrozenBlocks() uint64                      { return 0 }
+func (cr witnessChainReader) FrozenBorBlocks(bool) uint64               { return 0 }
+
+func (cr witnessChainReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := cr.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (cr witnessChainReader) GetHeaderByNumber(number uint64) *types.Header {
+	for _, header := range cr.headers {
+		if header.Number.Uint64() == number {
+			return header
+		}
+	}
+	return nil
+}
+
+func (cr witnessChainReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	return cr.headers[hash]
+}
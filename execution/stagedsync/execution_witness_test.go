@@ -0,0 +1,111 @@
+package stagedsync
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/commitment/trie"
+	"github.com/erigontech/erigon/execution/protocol/rules/ethash"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+)
+
+func TestExecuteBlockWithExecutionWitness(t *testing.T) {
+	config := chain.TestChainConfig
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000bee")
+	coinbase := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+
+	newAccount := func(nonce uint64, balance *uint256.Int) *accounts.Account {
+		return &accounts.Account{
+			Initialised: true,
+			Nonce:       nonce,
+			Balance:     *balance,
+			Root:        trie.EmptyRoot,
+			CodeHash:    crypto.Keccak256Hash(nil),
+		}
+	}
+	update := func(tr *trie.Trie, addr common.Address, acc *accounts.Account) {
+		addrHash := crypto.Keccak256Hash(addr[:])
+		tr.UpdateAccount(addrHash[:], acc)
+	}
+
+	// pre-state: the funded sender among unrelated accounts, so that the trie has branches
+	initial := uint256.NewInt(1_000_000_000_000_000_000)
+	pre := trie.New(trie.EmptyRoot)
+	update(pre, sender, newAccount(0, initial))
+	for i := byte(1); i <= 16; i++ {
+		update(pre, common.Address{i}, newAccount(1, uint256.NewInt(uint64(i))))
+	}
+
+	parent := &types.Header{
+		Number:     big.NewInt(0),
+		Root:       pre.Hash(),
+		Difficulty: big.NewInt(1),
+		GasLimit:   10_000_000,
+	}
+
+	gasPrice, value := uint256.NewInt(1_000_000_000), uint256.NewInt(1000)
+	signer := types.MakeSigner(config, 1, 0)
+	txn, err := types.SignTx(types.NewTransaction(0, recipient, value, 21000, gasPrice, nil), *signer, key)
+	require.NoError(t, err)
+
+	// post-state: the transfer, its fee and the block reward go to the coinbase
+	fee := new(uint256.Int).Mul(gasPrice, uint256.NewInt(21000))
+	post := trie.New(trie.EmptyRoot)
+	update(post, sender, newAccount(1, new(uint256.Int).Sub(new(uint256.Int).Sub(initial, fee), value)))
+	update(post, recipient, newAccount(0, value))
+	update(post, coinbase, newAccount(0, new(uint256.Int).Add(fee, uint256.NewInt(2_000_000_000_000_000_000))))
+	for i := byte(1); i <= 16; i++ {
+		update(post, common.Address{i}, newAccount(1, uint256.NewInt(uint64(i))))
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   coinbase,
+		Root:       post.Hash(),
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		GasLimit:   10_000_000,
+		GasUsed:    21000,
+		Time:       10,
+	}
+	receipts := types.Receipts{{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000}}
+	block := types.NewBlock(header, types.Transactions{txn}, nil, receipts, nil)
+
+	nodes, err := pre.StateNodes()
+	require.NoError(t, err)
+	witness := &ExecutionWitness{Headers: []*types.Header{parent}}
+	for _, node := range nodes {
+		witness.State = append(witness.State, hexutil.Bytes(node))
+	}
+
+	enc, err := json.Marshal(witness)
+	require.NoError(t, err)
+	var decoded ExecutionWitness
+	require.NoError(t, json.Unmarshal(enc, &decoded))
+
+	root, err := ExecuteBlockWithExecutionWitness(config, ethash.NewFaker(), block, &decoded, log.New())
+	require.NoError(t, err)
+	require.Equal(t, block.Root(), root)
+
+	// without the nodes on the sender's path its balance can't be read
+	partial := &ExecutionWitness{State: witness.State[:1], Headers: witness.Headers}
+	_, err = ExecuteBlockWithExecutionWitness(config, ethash.NewFaker(), block, partial, log.New())
+	require.Error(t, err)
+
+	// the parent header must be the block's parent
+	_, err = ExecuteBlockWithExecutionWitness(config, ethash.NewFaker(), block, &ExecutionWitness{State: witness.State, Headers: []*types.Header{header}}, log.New())
+	require.Error(t, err)
+}
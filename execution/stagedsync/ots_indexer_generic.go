@@ -251,8 +251,14 @@ func unwindAddress(tx kv.RwTx, target, targetDel kv.RwCursor, counter kv.RwCurso
 			return err
 		}
 	} else {
-		// Rewrite the last remaining chunk as the last
-		k, v, err := target.Prev()
+		// Rewrite the last remaining chunk as the last; if the deletion loop reached the
+		// end of the table, the cursor is unpositioned, so seek the last record instead
+		var v []byte
+		if k == nil {
+			k, v, err = target.Last()
+		} else {
+			k, v, err = target.Prev()
+		}
 		if err != nil {
 			return err
 		}
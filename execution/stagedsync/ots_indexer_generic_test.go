@@ -0,0 +1,143 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/length"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/etl"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/memdb"
+)
+
+func touchTransferIndex(t *testing.T, tx kv.RwTx, matches map[common.Address][]uint64) {
+	t.Helper()
+	collector := etl.NewCollector("test", t.TempDir(), etl.NewSortableBuffer(etl.BufferOptimalSize), log.New())
+	h := &StandardIndexHandler{kv.OtsERC20TransferIndex, kv.OtsERC20TransferCounter, collector, map[string]*roaring64.Bitmap{}}
+	defer h.Close()
+	for addr, idxs := range matches {
+		for _, idx := range idxs {
+			h.TouchIndex(addr, idx)
+		}
+	}
+	require.NoError(t, h.Flush(true))
+	require.NoError(t, h.Load(context.Background(), tx))
+}
+
+// readTransferIndex returns the index chunks of addr, keyed by chunk suffix, and
+// its counters
+func readTransferIndex(t *testing.T, tx kv.Tx, addr common.Address) (map[uint64][]uint64, [][]byte) {
+	t.Helper()
+	chunks := map[uint64][]uint64{}
+	it, err := tx.Prefix(kv.OtsERC20TransferIndex, addr.Bytes())
+	require.NoError(t, err)
+	defer it.Close()
+	for it.HasNext() {
+		k, v, err := it.Next()
+		require.NoError(t, err)
+		var idxs []uint64
+		for i := 0; i < len(v); i += 8 {
+			idxs = append(idxs, binary.BigEndian.Uint64(v[i:i+8]))
+		}
+		chunks[binary.BigEndian.Uint64(k[length.Addr:])] = idxs
+	}
+
+	var counters [][]byte
+	c, err := tx.CursorDupSort(kv.OtsERC20TransferCounter)
+	require.NoError(t, err)
+	defer c.Close()
+	k, v, err := c.SeekExact(addr.Bytes())
+	for ; k != nil; k, v, err = c.NextDup() {
+		require.NoError(t, err)
+		counters = append(counters, common.CopyBytes(v))
+	}
+	require.NoError(t, err)
+	return chunks, counters
+}
+
+func seq(from, to uint64) []uint64 {
+	s := make([]uint64, 0, to-from)
+	for i := from; i < to; i++ {
+		s = append(s, i)
+	}
+	return s
+}
+
+func TestUnwindAddressAtTableEnd(t *testing.T) {
+	first := common.HexToAddress("0x01")
+	last := common.HexToAddress("0x02")
+
+	t.Run("optimized counter", func(t *testing.T) {
+		_, tx := memdb.NewTestTx(t)
+		touchTransferIndex(t, tx, map[common.Address][]uint64{first: {7}, last: {5, 10}})
+
+		u, err := NewTransferLogIndexerUnwinder(tx, kv.OtsERC20TransferIndex, kv.OtsERC20TransferCounter, false)
+		require.NoError(t, err)
+		defer u.Dispose()
+
+		// partial unwind keeps the chunk
+		require.NoError(t, u.UnwindAddress(tx, last, 10))
+		chunks, counters := readTransferIndex(t, tx, last)
+		require.Equal(t, map[uint64][]uint64{^uint64(0): {5}}, chunks)
+		require.Equal(t, [][]byte{{0}}, counters)
+
+		// the only chunk of the last address in the table is removed
+		require.NoError(t, u.UnwindAddress(tx, last, 5))
+		chunks, counters = readTransferIndex(t, tx, last)
+		require.Empty(t, chunks)
+		require.Empty(t, counters)
+
+		chunks, counters = readTransferIndex(t, tx, first)
+		require.Equal(t, map[uint64][]uint64{^uint64(0): {7}}, chunks)
+		require.Equal(t, [][]byte{{0}}, counters)
+	})
+
+	t.Run("regular counters", func(t *testing.T) {
+		_, tx := memdb.NewTestTx(t)
+		touchTransferIndex(t, tx, map[common.Address][]uint64{first: {7}, last: seq(0, 600)})
+
+		chunks, counters := readTransferIndex(t, tx, last)
+		require.Greater(t, len(chunks), 1)
+		require.Len(t, counters, len(chunks))
+		lastChunk := chunks[^uint64(0)]
+		require.NotEmpty(t, lastChunk)
+
+		u, err := NewTransferLogIndexerUnwinder(tx, kv.OtsERC20TransferIndex, kv.OtsERC20TransferCounter, false)
+		require.NoError(t, err)
+		defer u.Dispose()
+
+		// unwinding the whole last chunk promotes the previous one as the last
+		require.NoError(t, u.UnwindAddress(tx, last, lastChunk[0]))
+		chunks, counters = readTransferIndex(t, tx, last)
+		require.Equal(t, seq(lastChunk[0]-uint64(len(chunks[^uint64(0)])), lastChunk[0]), chunks[^uint64(0)])
+		require.Len(t, counters, len(chunks))
+		lastCounter := counters[len(counters)-1]
+		require.Equal(t, lastChunk[0], binary.BigEndian.Uint64(lastCounter[:length.Counter]))
+		require.Equal(t, ^uint64(0), binary.BigEndian.Uint64(lastCounter[length.Counter:]))
+
+		chunks, _ = readTransferIndex(t, tx, first)
+		require.Equal(t, map[uint64][]uint64{^uint64(0): {7}}, chunks)
+	})
+}
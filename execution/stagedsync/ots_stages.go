@@ -18,15 +18,17 @@ package stagedsync
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/RoaringBitmap/roaring/v2/roaring64"
 	"github.com/erigontech/erigon/db/kv"
 	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/ots/events"
 )
 
 // Standard Otterscan V2 stages; if opted-in, they must be inserted before finish stage.
 func OtsStages(ctx context.Context, caCfg ContractAnalyzerCfg) []*Stage {
-	return []*Stage{
+	otsStages := []*Stage{
 		{
 			ID:          stages.OtsContractIndexer,
 			Description: "Index contract creation",
@@ -179,4 +181,21 @@ func OtsStages(ctx context.Context, caCfg ContractAnalyzerCfg) []*Stage {
 			Prune:       NoopStagePrune(ctx, caCfg),
 		},
 	}
+	return append(otsStages, OtsEventStages(ctx, caCfg)...)
+}
+
+// OtsEventStages returns one stage per registered event indexer.
+func OtsEventStages(ctx context.Context, caCfg ContractAnalyzerCfg) []*Stage {
+	defs := events.All()
+	ret := make([]*Stage, 0, len(defs))
+	for _, def := range defs {
+		ret = append(ret, &Stage{
+			ID:          def.StageID(),
+			Description: fmt.Sprintf("%s event indexer", def.Name),
+			Forward:     GenericStageForwardFunc(ctx, caCfg, stages.Execution, NewEventIndexerExecutor(def)),
+			Unwind:      GenericStageUnwindFunc(ctx, caCfg, NewEventIndexerUnwinder(def)),
+			Prune:       NoopStagePrune(ctx, caCfg),
+		})
+	}
+	return ret
 }
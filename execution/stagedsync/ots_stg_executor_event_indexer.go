@@ -0,0 +1,152 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/etl"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/kvcfg"
+	"github.com/erigontech/erigon/db/kv/order"
+	"github.com/erigontech/erigon/db/rawdb"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/ots/events"
+)
+
+// NewEventIndexerExecutor indexes the txNums of all logs matching def, keyed by
+// def's key. Logs are read from the receipts cache, so it requires
+// --persist.receipts.
+func NewEventIndexerExecutor(def *events.Definition) StageExecutor {
+	return func(ctx context.Context, db kv.RoDB, tx kv.RwTx, isInternalTx bool, tmpDir string, chainConfig *chain.Config, blockReader services.FullBlockReader, engine rules.Engine, startBlock, endBlock uint64, isShortInterval bool, logEvery *time.Ticker, s *StageState, logger log.Logger) (uint64, error) {
+		ttx, ok := tx.(kv.TemporalTx)
+		if !ok {
+			return startBlock, fmt.Errorf("[%s] event indexer requires a temporal tx, got %T", s.LogPrefix(), tx)
+		}
+		if err := kvcfg.PersistReceipts.MustBeEnabled(tx, "event indexers require the `--persist.receipts` flag"); err != nil {
+			return startBlock, err
+		}
+
+		collector := etl.NewCollector(s.LogPrefix(), tmpDir, etl.NewSortableBuffer(etl.BufferOptimalSize), logger)
+		handler := &StandardIndexHandler{def.IndexTable(), def.CounterTable(), collector, map[string]*roaring64.Bitmap{}}
+		defer handler.Close()
+
+		flushEvery := time.NewTicker(bitmapsFlushEvery)
+		defer flushEvery.Stop()
+
+		totalMatch := uint64(0)
+		err := scanEventLogs(ctx, ttx, blockReader, def, startBlock, endBlock, func(key common.Address, txNum uint64) error {
+			totalMatch++
+			handler.TouchIndex(key, txNum)
+
+			select {
+			default:
+			case <-logEvery.C:
+				log.Info(fmt.Sprintf("[%s] Scanning logs", s.LogPrefix()), "txNum", txNum, "matches", totalMatch)
+			case <-flushEvery.C:
+				if err := handler.Flush(false); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return startBlock, err
+		}
+
+		// Last (forced) flush and batch load (if applicable)
+		if err := handler.Flush(true); err != nil {
+			return startBlock, err
+		}
+		if err := handler.Load(ctx, tx); err != nil {
+			return startBlock, err
+		}
+
+		if !isShortInterval && totalMatch > 0 {
+			log.Info(fmt.Sprintf("[%s] Totals", s.LogPrefix()), "matches", totalMatch)
+		}
+
+		return endBlock, nil
+	}
+}
+
+// scanEventLogs calls fn for each (key, txNum) pair matching def in the block
+// range [startBlock, endBlock]; pairs are unique, ordered by txNum.
+func scanEventLogs(ctx context.Context, tx kv.TemporalTx, blockReader services.FullBlockReader, def *events.Definition, startBlock, endBlock uint64, fn func(key common.Address, txNum uint64) error) error {
+	txNumReader := blockReader.TxnumReader(ctx)
+	fromTxNum, err := txNumReader.Min(tx, startBlock)
+	if err != nil {
+		return err
+	}
+	toTxNum, err := txNumReader.Max(tx, endBlock)
+	if err != nil {
+		return err
+	}
+
+	// topic0 candidates may match on any topic position, they are confirmed by
+	// def.Match against the actual receipt logs
+	it, err := tx.IndexRange(kv.LogTopicIdx, def.Topic0.Bytes(), int(fromTxNum), int(toTxNum+1), order.Asc, kv.Unlim)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	seen := make(map[common.Address]struct{})
+	for it.HasNext() {
+		txNum, err := it.Next()
+		if err != nil {
+			return err
+		}
+		receipt, ok, err := rawdb.ReadReceiptCacheV2(tx, rawdb.RCacheV2Query{TxNum: txNum, DontCalcBloom: true})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		clear(seen)
+		for _, l := range receipt.Logs {
+			key, ok := def.Match(l)
+			if !ok {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if err := fn(key, txNum); err != nil {
+				return err
+			}
+		}
+
+		select {
+		default:
+		case <-ctx.Done():
+			return common.ErrStopped
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/ots/events"
+)
+
+// NewEventIndexerUnwinder rescans the logs of the unwound blocks and cuts the
+// index of each matched key at its first unwound occurrence.
+//
+// It relies on the receipts cache and log indexes of the unwound blocks being
+// still available, which is true as long as the stage unwinds before Execution.
+func NewEventIndexerUnwinder(def *events.Definition) UnwindExecutor {
+	return func(ctx context.Context, tx kv.RwTx, u *UnwindState, blockReader services.FullBlockReader, isShortInterval bool, logEvery *time.Ticker) error {
+		ttx, ok := tx.(kv.TemporalTx)
+		if !ok {
+			return fmt.Errorf("[%s] event indexer requires a temporal tx, got %T", u.LogPrefix(), tx)
+		}
+
+		unwinder, err := newBlockIndexerUnwinder(tx, def.IndexTable(), def.CounterTable())
+		if err != nil {
+			return err
+		}
+		defer unwinder.Dispose()
+
+		// The unwind interval is ]u.UnwindPoint, EOF]
+		return scanEventLogs(ctx, ttx, blockReader, def, u.UnwindPoint+1, u.CurrentBlockNumber, func(key common.Address, txNum uint64) error {
+			select {
+			default:
+			case <-logEvery.C:
+				log.Info(fmt.Sprintf("[%s] Unwinding event indexer", u.LogPrefix()), "txNum", txNum)
+			}
+			return unwinder.UnwindAddress(tx, key, txNum)
+		})
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/erigontech/erigon/diagnostics/diaglib"
 	"github.com/erigontech/erigon/execution/stagedsync/stages"
 	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/ots/events"
 )
 
 func ResetState(db kv.TemporalRwDB, ctx context.Context) error {
@@ -180,6 +181,21 @@ var Tables = map[stages.SyncStage][]string{
 	stages.OtsBlocksRewarded:       {kv.OtsBlocksRewardedIndex, kv.OtsBlocksRewardedCounter},
 	stages.OtsWithdrawals:          {kv.OtsWithdrawalIdx2Block, kv.OtsWithdrawalsIndex, kv.OtsWithdrawalsCounter},
 }
+
+// stageTables returns the tables of stage st, including the ones of registered
+// event indexers: those are known only after startup.
+func stageTables(st stages.SyncStage) []string {
+	if tables, ok := Tables[st]; ok {
+		return tables
+	}
+	for _, def := range events.All() {
+		if def.StageID() == st {
+			return []string{def.IndexTable(), def.CounterTable()}
+		}
+	}
+	return nil
+}
+
 var stateBuckets = []string{
 	kv.Epoch, kv.PendingEpoch,
 }
@@ -203,7 +219,7 @@ func clearStageProgress(tx kv.RwTx, stagesList ...stages.SyncStage) error {
 func Reset(ctx context.Context, db kv.RwDB, stagesList ...stages.SyncStage) error {
 	return db.Update(ctx, func(tx kv.RwTx) error {
 		for _, st := range stagesList {
-			if err := backup.ClearTables(ctx, tx, Tables[st]...); err != nil {
+			if err := backup.ClearTables(ctx, tx, stageTables(st)...); err != nil {
 				return err
 			}
 			if err := clearStageProgress(tx, stagesList...); err != nil {
@@ -0,0 +1,92 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/services"
+	"github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/db/state/statecfg"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+)
+
+// RetentionTxNum - resolves retention (in blocks and/or age) to first txNum which must be kept. Relative to Execution stage progress.
+func RetentionTxNum(ctx context.Context, blockReader services.FullBlockReader) state.RetentionTxNum {
+	return func(tx kv.Tx, r statecfg.Retention) (uint64, error) {
+		head, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return 0, err
+		}
+		if head == 0 {
+			return 0, nil
+		}
+		keepFromBlock := head + 1
+		if r.Blocks > 0 {
+			keepFromBlock = head + 1 - min(r.Blocks, head+1)
+		}
+		if r.Age > 0 {
+			ageBlock, err := firstBlockNotOlderThan(ctx, tx, blockReader, head, time.Now().Add(-r.Age))
+			if err != nil {
+				return 0, err
+			}
+			if r.Blocks > 0 {
+				keepFromBlock = min(keepFromBlock, ageBlock)
+			} else {
+				keepFromBlock = ageBlock
+			}
+		}
+
+		txNumsReader := blockReader.TxnumReader(ctx)
+		if keepFromBlock > head {
+			maxTxNum, err := txNumsReader.Max(tx, head)
+			if err != nil {
+				return 0, err
+			}
+			return maxTxNum + 1, nil
+		}
+		return txNumsReader.Min(tx, keepFromBlock)
+	}
+}
+
+// firstBlockNotOlderThan - binary search by header timestamps in [0, head]. Returns `head+1` if all blocks are older.
+func firstBlockNotOlderThan(ctx context.Context, tx kv.Tx, blockReader services.FullBlockReader, head uint64, t time.Time) (uint64, error) {
+	var searchErr error
+	blockNum := sort.Search(int(head+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		h, err := blockReader.HeaderByNumber(ctx, tx, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		if h == nil {
+			searchErr = fmt.Errorf("header %d not found", i)
+			return true
+		}
+		return h.Time >= uint64(t.Unix())
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return uint64(blockNum), nil
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -43,6 +44,7 @@ import (
 	"github.com/erigontech/erigon/execution/exec"
 	"github.com/erigontech/erigon/execution/protocol/rules"
 	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/node/ethconfig"
 )
@@ -61,6 +63,10 @@ type Produce struct {
 	LogTopic      bool
 	TraceFrom     bool
 	TraceTo       bool
+
+	// CustomTraces - names of the registered custom traces, all of them are produced into kv.CustomTraceDomain,
+	// each with its own progress, see rawdb.ReadCustomTraceProgress
+	CustomTraces []string
 }
 
 func NewProduce(produceList []string) Produce {
@@ -81,6 +87,10 @@ func NewProduce(produceList []string) Produce {
 		case kv.TracesToIdx.String():
 			produce.TraceTo = true
 		default:
+			if _, ok := tracers.LookupCustomTrace(p); ok {
+				produce.CustomTraces = append(produce.CustomTraces, p)
+				continue
+			}
 			panic(fmt.Errorf("assert: unknown Produce %#v", p))
 		}
 	}
@@ -90,19 +100,21 @@ func NewProduce(produceList []string) Produce {
 func StageCustomTraceCfg(produce []string, db kv.TemporalRwDB, dirs datadir.Dirs, br services.FullBlockReader,
 	cc *chain.Config, engine rules.Engine,
 	genesis *types.Genesis, syncCfg ethconfig.Sync) CustomTraceCfg {
+	p := NewProduce(produce)
 	execArgs := &exec.ExecArgs{
-		ChainDB:     db,
-		BlockReader: br,
-		ChainConfig: cc,
-		Dirs:        dirs,
-		Engine:      engine,
-		Genesis:     genesis,
-		Workers:     syncCfg.ExecWorkerCount,
+		ChainDB:      db,
+		BlockReader:  br,
+		ChainConfig:  cc,
+		Dirs:         dirs,
+		Engine:       engine,
+		Genesis:      genesis,
+		Workers:      syncCfg.ExecWorkerCount,
+		CustomTraces: p.CustomTraces,
 	}
 	return CustomTraceCfg{
 		db:       db,
 		ExecArgs: execArgs,
-		Produce:  NewProduce(produce),
+		Produce:  p,
 	}
 }
 
@@ -114,6 +126,14 @@ func SpawnCustomTrace(cfg CustomTraceCfg, ctx context.Context, logger log.Logger
 			panic(err)
 		}
 	}
+	if len(cfg.Produce.CustomTraces) > 0 {
+		// the history of custom traces is opened only on the dbs marked to have them, see statecfg.EnableCustomTraces
+		if err := cfg.db.Update(ctx, func(tx kv.RwTx) error {
+			return kvcfg.CustomTraces.ForceWrite(tx, true)
+		}); err != nil {
+			return err
+		}
+	}
 
 	log.Info("[stage_custom_trace] start params", "produce", cfg.Produce)
 	txNumsReader := cfg.ExecArgs.BlockReader.TxnumReader(ctx)
@@ -190,7 +210,8 @@ Loop:
 	log.Info("SpawnCustomTrace finish",
 		"accounts", tx.Debug().DomainProgress(kv.AccountsDomain),
 		"receipts", tx.Debug().DomainProgress(kv.ReceiptDomain),
-		"rcache", tx.Debug().DomainProgress(kv.RCacheDomain))
+		"rcache", tx.Debug().DomainProgress(kv.RCacheDomain),
+		"customtrace", tx.Debug().DomainProgress(kv.CustomTraceDomain))
 
 	if cfg.Produce.ReceiptDomain {
 		if err := integrity.ValidateDomainProgress(cfg.db, kv.ReceiptDomain, txNumsReader); err != nil {
@@ -202,6 +223,11 @@ Loop:
 			return err
 		}
 	}
+	if len(cfg.Produce.CustomTraces) > 0 {
+		if err := integrity.ValidateDomainProgress(cfg.db, kv.CustomTraceDomain, txNumsReader); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -235,6 +261,9 @@ func customTraceBatchProduce(ctx context.Context, produce Produce, cfg *exec.Exe
 		if err := customTraceBatch(ctx, produce, cfg, tx, doms, fromBlock, toBlock, logPrefix, logger); err != nil {
 			return err
 		}
+		if err := customTracesProgress(ctx, produce, cfg, tx, doms, fromBlock, logger); err != nil {
+			return err
+		}
 
 		if err := doms.Flush(ctx, tx); err != nil {
 			return err
@@ -283,6 +312,36 @@ func customTraceBatchProduce(ctx context.Context, produce Produce, cfg *exec.Exe
 	return nil
 }
 
+// customTracesProgress - the custom traces share the domain, but not the progress. The domain can't get history under
+// the one it has: a trace added later is produced from the point the domain is at, and the blocks before it must not
+// look like the ones the trace had nothing for
+func customTracesProgress(ctx context.Context, produce Produce, cfg *exec.ExecArgs, tx kv.TemporalRwTx, doms *execctx.SharedDomains, fromBlock uint64, logger log.Logger) error {
+	if len(produce.CustomTraces) == 0 {
+		return nil
+	}
+	fromTxNum, err := cfg.BlockReader.TxnumReader(ctx).Min(tx, fromBlock)
+	if err != nil {
+		return err
+	}
+	putter := doms.AsPutDel(tx)
+	for _, name := range produce.CustomTraces {
+		from, to, ok, err := rawdb.ReadCustomTraceProgress(tx, name)
+		if err != nil {
+			return err
+		}
+		if !ok || to+1 < fromTxNum { // not produced yet, or skipped by previous runs
+			if fromBlock > 0 {
+				logger.Warn("[custom_trace] trace is produced from this block only: to produce its history, re-produce the domain with all its traces (--reset)", "trace", name, "block", fromBlock)
+			}
+			from = fromTxNum
+		}
+		if err := rawdb.WriteCustomTraceProgress(putter, name, from, doms.TxNum()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func AssertReceipts(ctx context.Context, cfg *exec.ExecArgs, tx kv.TemporalTx, fromBlock, toBlock uint64) (err error) {
 	if !dbg.AssertEnabled {
 		return
@@ -374,6 +433,19 @@ func customTraceBatch(ctx context.Context, produce Produce, cfg *exec.ExecArgs,
 				}
 			}
 
+			for i, name := range produce.CustomTraces {
+				var res []byte
+				if i < len(result.CustomTraces) { // system txns are not traced
+					var err error
+					if res, err = result.CustomTraces[i].GetResult(); err != nil {
+						return fmt.Errorf("custom trace %s: %w", name, err)
+					}
+				}
+				if err := rawdb.WriteCustomTrace(putter, name, res, txTask.TxNum); err != nil {
+					return err
+				}
+			}
+
 			if produce.LogAddr {
 				for _, lg := range result.Logs {
 					if err := doms.IndexAdd(kv.LogAddrIdx, lg.Address[:], txTask.TxNum); err != nil {
@@ -433,6 +505,9 @@ func progressOfDomains(tx kv.TemporalTx, produce Produce) uint64 {
 	if produce.RCacheDomain {
 		txNum = min(txNum, dbg.DomainProgress(kv.RCacheDomain))
 	}
+	if len(produce.CustomTraces) > 0 { // traces behind the domain are produced from its progress, see customTracesProgress
+		txNum = min(txNum, dbg.DomainProgress(kv.CustomTraceDomain))
+	}
 	if produce.LogAddr {
 		txNum = min(txNum, dbg.IIProgress(kv.LogAddrIdx))
 	}
@@ -458,6 +533,9 @@ func firstStepNotInFiles(tx kv.TemporalTx, produce Produce) kv.Step {
 	if produce.RCacheDomain {
 		fromStep = min(fromStep, kv.Step(ac.DbgDomain(kv.RCacheDomain).FirstStepNotInFiles()))
 	}
+	if len(produce.CustomTraces) > 0 {
+		fromStep = min(fromStep, kv.Step(ac.DbgDomain(kv.CustomTraceDomain).FirstStepNotInFiles()))
+	}
 	if produce.LogAddr {
 		fromStep = min(fromStep, ac.DbgII(kv.LogAddrIdx).FirstStepNotInFiles())
 	}
@@ -487,6 +565,17 @@ func StageCustomTraceReset(ctx context.Context, db kv.TemporalRwDB, produce Prod
 	if produce.RCacheDomain {
 		tables = append(tables, db.Debug().DomainTables(kv.RCacheDomain)...)
 	}
+	if len(produce.CustomTraces) > 0 {
+		others, err := otherProducedCustomTraces(tx, produce.CustomTraces)
+		if err != nil {
+			return err
+		}
+		if len(others) == 0 {
+			tables = append(tables, db.Debug().DomainTables(kv.CustomTraceDomain)...)
+		} else if err := resetCustomTraces(ctx, tx, produce.CustomTraces); err != nil { // the domain keeps the other traces
+			return err
+		}
+	}
 	if produce.LogAddr {
 		tables = append(tables, db.Debug().InvertedIdxTables(kv.LogAddrIdx)...)
 	}
@@ -504,3 +593,37 @@ func StageCustomTraceReset(ctx context.Context, db kv.TemporalRwDB, produce Prod
 	}
 	return tx.Commit()
 }
+
+// otherProducedCustomTraces - the registered custom traces, other than `names`, the domain has progress of
+func otherProducedCustomTraces(tx kv.TemporalTx, names []string) (others []string, err error) {
+	for _, name := range tracers.CustomTraceNames() {
+		if slices.Contains(names, name) {
+			continue
+		}
+		_, _, ok, err := rawdb.ReadCustomTraceProgress(tx, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			others = append(others, name)
+		}
+	}
+	return others, nil
+}
+
+// resetCustomTraces - drops progress of the traces: their results are not served anymore, and they are produced again
+// from the point the domain is at
+func resetCustomTraces(ctx context.Context, tx kv.TemporalRwTx, names []string) error {
+	doms, err := execctx.NewSharedDomains(tx, log.Root())
+	if err != nil {
+		return err
+	}
+	defer doms.Close()
+	txNum := tx.Debug().DomainProgress(kv.CustomTraceDomain)
+	for _, name := range names {
+		if err := rawdb.DeleteCustomTraceProgress(doms.AsPutDel(tx), name, txNum); err != nil {
+			return err
+		}
+	}
+	return doms.Flush(ctx, tx)
+}
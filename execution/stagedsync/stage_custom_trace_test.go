@@ -80,6 +80,11 @@ func TestCustomTraceInvalidProduceMode(t *testing.T) {
 	require.False(produce.LogTopic)
 	require.False(produce.TraceFrom)
 	require.False(produce.TraceTo)
+	require.Empty(produce.CustomTraces)
+
+	produce = stagedsync.NewProduce([]string{"receipt", "sstoreCounts"})
+	require.True(produce.ReceiptDomain)
+	require.Equal([]string{"sstoreCounts"}, produce.CustomTraces)
 
 	require.Panics(func() {
 		stagedsync.NewProduce([]string{"invalid_mode"})
@@ -88,6 +88,8 @@ type ExecuteBlockCfg struct {
 	silkworm        *silkworm.Silkworm
 	blockProduction bool
 	experimentalBAL bool
+
+	recorder *execRecorder // records executed transactions, see RecordExecution
 }
 
 func StageExecuteBlocksCfg(
@@ -0,0 +1,145 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"fmt"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/protocol"
+	"github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/types/accounts"
+	"github.com/erigontech/erigon/execution/vm"
+	"github.com/erigontech/erigon/txnprovider/bundle"
+)
+
+// addBundlesToMiningBlock - includes bundles at the top of the block: each bundle atomically and in order, or not at all.
+// IntraBlockState can't revert across transactions - so bundle is applied on a copy of state, which replaces state only if all its txns succeed.
+// Returns state with included bundles and senders of included txns.
+func addBundlesToMiningBlock(
+	logPrefix string,
+	current *MiningBlock,
+	chainConfig *chain.Config,
+	vmConfig *vm.Config,
+	getHeader func(hash common.Hash, number uint64) (*types.Header, error),
+	engine rules.Engine,
+	bundles []*bundle.Bundle,
+	coinbase common.Address,
+	ibs *state.IntraBlockState,
+	logger log.Logger,
+) (_ *state.IntraBlockState, logs types.Logs, senders mapset.Set[common.Address]) {
+	header := current.Header
+	signer := types.MakeSigner(chainConfig, header.Number.Uint64(), header.Time)
+	senders = mapset.NewThreadUnsafeSet[common.Address]()
+
+	for _, b := range bundles {
+		bundleIbs := ibs.Copy()
+		receipts, gasUsed, blobGasUsed, err := applyBundle(current, chainConfig, vmConfig, getHeader, engine, b, coinbase, bundleIbs, signer)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("[%s] Skipping bundle", logPrefix), "hash", b.Hash(), "block", b.BlockNumber, "err", err)
+			continue
+		}
+
+		ibs = bundleIbs
+		header.GasUsed, header.BlobGasUsed = gasUsed, blobGasUsed
+		for i, txn := range b.Txns {
+			current.AddTxn(txn)
+			current.Receipts = append(current.Receipts, receipts[i])
+			logs = append(logs, receipts[i].Logs...)
+			from, _ := txn.Sender(*signer)
+			senders.Add(from)
+		}
+		logger.Debug(fmt.Sprintf("[%s] Added bundle", logPrefix), "hash", b.Hash(), "txns", len(b.Txns))
+	}
+	return ibs, logs, senders
+}
+
+// applyBundle - applies all txns of bundle to ibs. Returns error if any txn fails or reverts without being allowed to.
+// Block gas counters are not changed: new values are returned.
+func applyBundle(
+	current *MiningBlock,
+	chainConfig *chain.Config,
+	vmConfig *vm.Config,
+	getHeader func(hash common.Hash, number uint64) (*types.Header, error),
+	engine rules.Engine,
+	b *bundle.Bundle,
+	coinbase common.Address,
+	ibs *state.IntraBlockState,
+	signer *types.Signer,
+) (receipts types.Receipts, gasUsed uint64, blobGasUsed *uint64, err error) {
+	header := current.Header
+	if rlpSpace := current.AvailableRlpSpace(chainConfig, b.Txns...); rlpSpace < 0 {
+		return nil, 0, nil, fmt.Errorf("doesn't fit in available rlp space: %d", rlpSpace)
+	}
+	gasUsed = header.GasUsed
+	gasPool := new(protocol.GasPool).AddGas(header.GasLimit - header.GasUsed)
+	if header.BlobGasUsed != nil {
+		blobGasUsed = new(uint64)
+		*blobGasUsed = *header.BlobGasUsed
+		gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(header.Time) - *header.BlobGasUsed)
+	}
+	for _, txn := range b.Txns {
+		if _, err := txn.Sender(*signer); err != nil {
+			return nil, 0, nil, fmt.Errorf("txn %x: %w", txn.Hash(), err)
+		}
+		ibs.SetTxContext(header.Number.Uint64(), ibs.TxnIndex()+1)
+		receipt, _, err := protocol.ApplyTransaction(chainConfig, protocol.GetHashFn(header, getHeader), engine, &coinbase, gasPool, ibs, state.NewNoopWriter(), header, txn, &gasUsed, blobGasUsed, *vmConfig)
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("txn %x: %w", txn.Hash(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !b.CanRevert(txn.Hash()) {
+			return nil, 0, nil, fmt.Errorf("txn %x: reverted", txn.Hash())
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, gasUsed, blobGasUsed, nil
+}
+
+// updateSimulatedSenders - filterBadTransactions checks nonces and balances of pool txns against simulated state:
+// it must see changes of senders which txns were included by bundles.
+func updateSimulatedSenders(senders mapset.Set[common.Address], ibs *state.IntraBlockState, simStateReader state.StateReader, simStateWriter state.StateWriter) error {
+	for _, sender := range senders.ToSlice() {
+		original, err := simStateReader.ReadAccountData(sender)
+		if err != nil {
+			return err
+		}
+		if original == nil {
+			continue
+		}
+		nonce, err := ibs.GetNonce(sender)
+		if err != nil {
+			return err
+		}
+		balance, err := ibs.GetBalance(sender)
+		if err != nil {
+			return err
+		}
+		account := new(accounts.Account)
+		*account = *original
+		account.Nonce, account.Balance = nonce, balance
+		if err := simStateWriter.UpdateAccountData(sender, original, account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
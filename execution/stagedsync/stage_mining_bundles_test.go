@@ -0,0 +1,78 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync_test
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/protocol/params"
+	"github.com/erigontech/erigon/execution/stagedsync/stageloop"
+	"github.com/erigontech/erigon/execution/tests/mock"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/txnprovider/bundle"
+)
+
+func TestMiningBundles(t *testing.T) {
+	require := require.New(t)
+	m := mock.MockWithTxPool(t)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	gasPrice := uint256.NewInt(common.GWei)
+	transfer := func(nonce uint64, to common.Address) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, to, uint256.NewInt(1), params.TxGas, gasPrice, nil), *signer, m.Key)
+		require.NoError(err)
+		return txn
+	}
+	// init code: PUSH1 0 PUSH1 0 REVERT
+	reverting := func(nonce uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewContractCreation(nonce, uint256.NewInt(0), 100_000, gasPrice, common.FromHex("60006000fd")), *signer, m.Key)
+		require.NoError(err)
+		return txn
+	}
+
+	included := &bundle.Bundle{BlockNumber: 1, Txns: []types.Transaction{transfer(0, common.Address{2}), transfer(1, common.Address{3})}}
+	// skipped as a whole: its first txn is valid, but second reverts and is not allowed to
+	skipped := &bundle.Bundle{BlockNumber: 1, Txns: []types.Transaction{transfer(2, common.Address{4}), reverting(3)}}
+	// applies on top of state of included bundle: nonces 2 and 3 are free again
+	allowedRevert := &bundle.Bundle{BlockNumber: 1, Txns: []types.Transaction{transfer(2, common.Address{5}), reverting(3)}}
+	allowedRevert.RevertingTxHashes = []common.Hash{allowedRevert.Txns[1].Hash()}
+	for _, b := range []*bundle.Bundle{included, skipped, allowedRevert} {
+		_, err := m.Bundles.Add(b)
+		require.NoError(err)
+	}
+
+	require.NoError(stageloop.MiningStep(m.Ctx, m.DB, m.MiningSync, "", log.Root()))
+	block := <-m.MinedBlocks
+
+	var expected []common.Hash
+	for _, txn := range append(included.Txns, allowedRevert.Txns...) {
+		expected = append(expected, txn.Hash())
+	}
+	var got []common.Hash
+	for _, txn := range block.Block.Transactions() {
+		got = append(got, txn.Hash())
+	}
+	require.Equal(expected, got)
+	require.Len(block.Receipts, 4)
+	require.Equal(types.ReceiptStatusSuccessful, block.Receipts[2].Status)
+	require.Equal(types.ReceiptStatusFailed, block.Receipts[3].Status)
+	require.Equal(block.Receipts[3].CumulativeGasUsed, block.Block.GasUsed())
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/erigontech/erigon/execution/vm"
 	"github.com/erigontech/erigon/execution/vm/evmtypes"
 	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/bundle"
 )
 
 type MiningExecCfg struct {
@@ -60,6 +61,7 @@ type MiningExecCfg struct {
 	interrupt   *atomic.Bool
 	payloadId   uint64
 	txnProvider txnprovider.TxnProvider
+	bundles     *bundle.Pool
 }
 
 func StageMiningExecCfg(
@@ -73,6 +75,7 @@ func StageMiningExecCfg(
 	interrupt *atomic.Bool,
 	payloadId uint64,
 	txnProvider txnprovider.TxnProvider,
+	bundles *bundle.Pool, // optional
 	blockReader services.FullBlockReader,
 ) MiningExecCfg {
 	return MiningExecCfg{
@@ -87,6 +90,7 @@ func StageMiningExecCfg(
 		interrupt:   interrupt,
 		payloadId:   payloadId,
 		txnProvider: txnProvider,
+		bundles:     bundles,
 	}
 }
 
@@ -132,6 +136,16 @@ func SpawnMiningExecStage(s *StageState, sd *execctx.SharedDomains, tx kv.Tempor
 
 	protocol.InitializeBlockExecution(cfg.engine, chainReader, current.Header, cfg.chainConfig, ibs, &state.NoopWriter{}, logger, nil)
 
+	// bundles go to the top of the block: before prepared txns and before txns from pool
+	var bundleSenders mapset.Set[common.Address]
+	if cfg.bundles != nil {
+		if bundles := cfg.bundles.Bundles(current.Header.Number.Uint64(), current.Header.Time); len(bundles) > 0 {
+			var logs types.Logs
+			ibs, logs, bundleSenders = addBundlesToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, bundles, cfg.miningState.MiningConfig.Etherbase, ibs, logger)
+			NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+		}
+	}
+
 	if len(preparedTxns) > 0 {
 		logs, _, err := addTransactionsToMiningBlock(ctx, logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, preparedTxns, cfg.miningState.MiningConfig.Etherbase, ibs, cfg.interrupt, cfg.payloadId, logger)
 		if err != nil {
@@ -152,6 +166,15 @@ func SpawnMiningExecStage(s *StageState, sd *execctx.SharedDomains, tx kv.Tempor
 			return err
 		}
 
+		if bundleSenders != nil {
+			for _, txn := range current.Txns {
+				yielded.Add(txn.Hash())
+			}
+			if err := updateSimulatedSenders(bundleSenders, ibs, simStateReader, simStateWriter); err != nil {
+				return err
+			}
+		}
+
 		interrupt := cfg.interrupt
 		const amount = 50
 		for {
@@ -68,6 +68,19 @@ func NewStateless(stateRoot common.Hash, blockWitness *trie.Witness, blockNr uin
 			return nil, fmt.Errorf("state root mistmatch when creating Stateless2, got %x, expected %x", t.Hash(), stateRoot)
 		}
 	}
+	return newStateless(t, blockNr, trace), nil
+}
+
+// NewStatelessFromTrie creates a new instance of Stateless on top of an already built state trie,
+// e.g. one built out of the nodes of an ExecutionWitness, checking that its root matches `stateRoot`
+func NewStatelessFromTrie(stateRoot common.Hash, t *trie.Trie, blockNr uint64, trace bool) (*Stateless, error) {
+	if root := t.Hash(); root != stateRoot {
+		return nil, fmt.Errorf("state root mismatch when creating Stateless, got %x, expected %x", root, stateRoot)
+	}
+	return newStateless(t, blockNr, trace), nil
+}
+
+func newStateless(t *trie.Trie, blockNr uint64, trace bool) *Stateless {
 	return &Stateless{
 		t:              t,
 		codeUpdates:    make(map[common.Hash][]byte),
@@ -78,7 +91,7 @@ func NewStateless(stateRoot common.Hash, blockWitness *trie.Witness, blockNr uin
 		created:        make(map[common.Hash]struct{}),
 		blockNr:        blockNr,
 		trace:          trace,
-	}, nil
+	}
 }
 
 func (s *Stateless) SetTrace(trace bool, _ string) {
@@ -126,6 +126,11 @@ func withTrace(t *testing.T, test func(vm.Config) error) {
 	config := vm.Config{}
 	err := test(config)
 	if err == nil {
+		// Same again in basic block mode, which must not change the outcome.
+		config.BlockAnalysis = vm.NewBlockAnalysisCache(vm.BlockAnalysisCacheLimit)
+		if err = test(config); err != nil {
+			t.Errorf("basic block mode: %v", err)
+		}
 		return
 	}
 
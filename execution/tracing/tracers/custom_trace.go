@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// CustomTraceCtor creates the tracer of a custom trace for one transaction. The result of
+// the tracer is what the custom trace stores for the transaction, nothing if it is empty
+// or null.
+type CustomTraceCtor func(ctx *Context) (*Tracer, error)
+
+var (
+	customTracesMu sync.RWMutex
+	customTraces   = map[string]CustomTraceCtor{}
+)
+
+// RegisterCustomTrace registers a tracer which can be run over the history with
+// `integration stage_custom_trace --domain=<name>`, to store its results in the custom
+// trace domain, and whose results are then served by erigon_getCustomTrace. It panics
+// if a custom trace with the same name is registered already.
+func RegisterCustomTrace(name string, ctor CustomTraceCtor) {
+	customTracesMu.Lock()
+	defer customTracesMu.Unlock()
+	if _, ok := customTraces[name]; ok {
+		panic(fmt.Sprintf("custom trace %q is registered already", name))
+	}
+	customTraces[name] = ctor
+}
+
+// LookupCustomTrace returns the tracer constructor of a registered custom trace
+func LookupCustomTrace(name string) (CustomTraceCtor, bool) {
+	customTracesMu.RLock()
+	defer customTracesMu.RUnlock()
+	ctor, ok := customTraces[name]
+	return ctor, ok
+}
+
+// CustomTraceNames returns the names of the registered custom traces, sorted
+func CustomTraceNames() []string {
+	customTracesMu.RLock()
+	defer customTracesMu.RUnlock()
+	names := make([]string, 0, len(customTraces))
+	for name := range customTraces {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/execution/tracing"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/vm"
+)
+
+func init() {
+	tracers.RegisterCustomTrace("sstoreCounts", newSstoreCountsTracer)
+	tracers.RegisterCustomTrace("gasRefunds", newGasRefundsTracer)
+}
+
+// sstoreCountsTracer counts the SSTOREs a transaction executes per contract, including
+// those of calls which are reverted
+type sstoreCountsTracer struct {
+	counts map[common.Address]hexutil.Uint64
+}
+
+func newSstoreCountsTracer(_ *tracers.Context) (*tracers.Tracer, error) {
+	t := &sstoreCountsTracer{counts: map[common.Address]hexutil.Uint64{}}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnOpcode: t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      func(error) {},
+	}, nil
+}
+
+func (t *sstoreCountsTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if vm.OpCode(op) == vm.SSTORE && err == nil {
+		t.counts[scope.Address()]++
+	}
+}
+
+func (t *sstoreCountsTracer) GetResult() (json.RawMessage, error) {
+	if len(t.counts) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(t.counts)
+}
+
+// gasRefundsTracer reports the gas refund a transaction accrues, before it is capped
+// to a fraction of the gas used
+type gasRefundsTracer struct {
+	env    *tracing.VMContext
+	refund uint64
+}
+
+func newGasRefundsTracer(_ *tracers.Context) (*tracers.Tracer, error) {
+	t := &gasRefundsTracer{}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnExit:    t.OnExit,
+		},
+		GetResult: t.GetResult,
+		Stop:      func(error) {},
+	}, nil
+}
+
+func (t *gasRefundsTracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	t.env = env
+}
+
+func (t *gasRefundsTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if depth == 0 && t.env != nil {
+		t.refund = t.env.IntraBlockState.GetRefund()
+	}
+}
+
+func (t *gasRefundsTracer) GetResult() (json.RawMessage, error) {
+	if t.refund == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[string]hexutil.Uint64{"refund": hexutil.Uint64(t.refund)})
+}
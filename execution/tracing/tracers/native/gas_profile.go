@@ -0,0 +1,331 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/protocol/params"
+	"github.com/erigontech/erigon/execution/tracing"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/vm"
+)
+
+// GasProfileTrace is the name of the custom trace producing the GasProfile of each txn
+const GasProfileTrace = "gasProfile"
+
+func init() {
+	register("gasProfileTracer", func(ctx *tracers.Context, _ json.RawMessage) (*tracers.Tracer, error) {
+		return newGasProfileTracer(ctx)
+	})
+	tracers.RegisterCustomTrace(GasProfileTrace, newGasProfileTracer)
+}
+
+// GasStat is the gas used by something, and how many times it was used
+type GasStat struct {
+	Gas   uint64 `json:"gas"`
+	Count uint64 `json:"count"`
+}
+
+func (s *GasStat) add(o GasStat) {
+	s.Gas += o.Gas
+	s.Count += o.Count
+}
+
+// GasProfile aggregates the gas used by txns. The gas of contracts, selectors and call stacks is the
+// execution gas spent running their code, without the gas of the calls they make: it doesn't include
+// the intrinsic gas of the txns and is before refunds, which Gas (from the receipts) does include.
+type GasProfile struct {
+	Txs uint64 `json:"txs"`
+	Gas uint64 `json:"gas"`
+
+	Contracts   map[common.Address]*GasStat            `json:"contracts"`
+	Selectors   map[common.Address]map[string]*GasStat `json:"selectors"` // "create" for contract creation
+	Opcodes     map[string]*GasStat                    `json:"opcodes"`   // calls are charged without the gas they pass on
+	Precompiles map[common.Address]*GasStat            `json:"precompiles"`
+	Stacks      map[string]uint64                      `json:"stacks"` // "address:selector" frames, outermost first, separated by ";"
+}
+
+func NewGasProfile() *GasProfile {
+	return &GasProfile{
+		Contracts:   map[common.Address]*GasStat{},
+		Selectors:   map[common.Address]map[string]*GasStat{},
+		Opcodes:     map[string]*GasStat{},
+		Precompiles: map[common.Address]*GasStat{},
+		Stacks:      map[string]uint64{},
+	}
+}
+
+func statOf[K comparable](m map[K]*GasStat, k K) *GasStat {
+	s, ok := m[k]
+	if !ok {
+		s = &GasStat{}
+		m[k] = s
+	}
+	return s
+}
+
+// Merge adds the gas of another profile to this one
+func (p *GasProfile) Merge(o *GasProfile) {
+	p.Txs += o.Txs
+	p.Gas += o.Gas
+	for addr, s := range o.Contracts {
+		statOf(p.Contracts, addr).add(*s)
+	}
+	for addr, selectors := range o.Selectors {
+		if p.Selectors[addr] == nil {
+			p.Selectors[addr] = map[string]*GasStat{}
+		}
+		for selector, s := range selectors {
+			statOf(p.Selectors[addr], selector).add(*s)
+		}
+	}
+	for op, s := range o.Opcodes {
+		statOf(p.Opcodes, op).add(*s)
+	}
+	for addr, s := range o.Precompiles {
+		statOf(p.Precompiles, addr).add(*s)
+	}
+	for stack, gas := range o.Stacks {
+		p.Stacks[stack] += gas
+	}
+}
+
+// GasProfileEntry is a line of a GasProfileReport
+type GasProfileEntry struct {
+	Contract *common.Address `json:"contract,omitempty"`
+	Selector string          `json:"selector,omitempty"`
+	Opcode   string          `json:"opcode,omitempty"`
+	GasStat
+}
+
+// GasProfileReport lists what used the most gas in a GasProfile
+type GasProfileReport struct {
+	Txs         uint64             `json:"txs"`
+	Gas         uint64             `json:"gas"`
+	Contracts   []*GasProfileEntry `json:"contracts"`
+	Selectors   []*GasProfileEntry `json:"selectors"`
+	Opcodes     []*GasProfileEntry `json:"opcodes"`
+	Precompiles []*GasProfileEntry `json:"precompiles"`
+}
+
+// Top returns the report of the n contracts, selectors, opcodes and precompiles which used the most gas,
+// all of them if n is 0
+func (p *GasProfile) Top(n int) *GasProfileReport {
+	top := func(entries []*GasProfileEntry) []*GasProfileEntry {
+		slices.SortFunc(entries, func(a, b *GasProfileEntry) int {
+			if c := cmp.Compare(b.Gas, a.Gas); c != 0 {
+				return c
+			}
+			if a.Contract != nil && b.Contract != nil {
+				if c := a.Contract.Cmp(*b.Contract); c != 0 {
+					return c
+				}
+			}
+			return cmp.Or(strings.Compare(a.Selector, b.Selector), strings.Compare(a.Opcode, b.Opcode))
+		})
+		if n > 0 && len(entries) > n {
+			entries = entries[:n]
+		}
+		return entries
+	}
+	byAddress := func(m map[common.Address]*GasStat) []*GasProfileEntry {
+		entries := make([]*GasProfileEntry, 0, len(m))
+		for addr, s := range m {
+			entries = append(entries, &GasProfileEntry{Contract: &addr, GasStat: *s})
+		}
+		return top(entries)
+	}
+
+	r := &GasProfileReport{Txs: p.Txs, Gas: p.Gas, Contracts: byAddress(p.Contracts), Precompiles: byAddress(p.Precompiles)}
+	for addr, selectors := range p.Selectors {
+		for selector, s := range selectors {
+			r.Selectors = append(r.Selectors, &GasProfileEntry{Contract: &addr, Selector: selector, GasStat: *s})
+		}
+	}
+	r.Selectors = top(r.Selectors)
+	for op, s := range p.Opcodes {
+		r.Opcodes = append(r.Opcodes, &GasProfileEntry{Opcode: op, GasStat: *s})
+	}
+	r.Opcodes = top(r.Opcodes)
+	return r
+}
+
+// WritePprof writes the call stacks of the profile in the pprof format, with contract frames as functions
+// and gas as the sample value. See `go tool pprof`
+func (p *GasProfile) WritePprof(w io.Writer) error {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "gas", Unit: "gas"}},
+		PeriodType: &profile.ValueType{Type: "gas", Unit: "gas"},
+		Period:     1,
+	}
+	locations := map[string]*profile.Location{}
+	location := func(frame string) *profile.Location {
+		if loc, ok := locations[frame]; ok {
+			return loc
+		}
+		fn := &profile.Function{ID: uint64(len(prof.Function) + 1), Name: frame, SystemName: frame}
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Line: []profile.Line{{Function: fn}}}
+		prof.Function = append(prof.Function, fn)
+		prof.Location = append(prof.Location, loc)
+		locations[frame] = loc
+		return loc
+	}
+
+	stacks := make([]string, 0, len(p.Stacks))
+	for stack := range p.Stacks {
+		stacks = append(stacks, stack)
+	}
+	slices.Sort(stacks)
+	for _, stack := range stacks {
+		frames := strings.Split(stack, ";")
+		sample := &profile.Sample{Location: make([]*profile.Location, 0, len(frames)), Value: []int64{int64(p.Stacks[stack])}}
+		for i := len(frames) - 1; i >= 0; i-- { // pprof lists the leaf first
+			sample.Location = append(sample.Location, location(frames[i]))
+		}
+		prof.Sample = append(prof.Sample, sample)
+	}
+	return prof.Write(w)
+}
+
+type gasProfileFrame struct {
+	address    common.Address
+	selector   string
+	label      string
+	code       bool // frames of accounts without code and of precompiles run no opcodes
+	precompile bool
+	childGas   uint64   // gas used by the calls the frame made
+	call       *GasStat // the stat of the call opcode the frame is executing, charged with the gas it passes on
+}
+
+// gasProfileTracer builds the GasProfile of a txn
+type gasProfileTracer struct {
+	profile *GasProfile
+	frames  []*gasProfileFrame
+}
+
+func newGasProfileTracer(_ *tracers.Context) (*tracers.Tracer, error) {
+	t := &gasProfileTracer{profile: NewGasProfile()}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxEnd:  t.OnTxEnd,
+			OnEnter:  t.OnEnter,
+			OnExit:   t.OnExit,
+			OnOpcode: t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      func(error) {},
+	}, nil
+}
+
+func isCall(op vm.OpCode) bool {
+	return op == vm.CALL || op == vm.CALLCODE || op == vm.DELEGATECALL || op == vm.STATICCALL
+}
+
+func (t *gasProfileTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if receipt != nil {
+		t.profile.Txs++
+		t.profile.Gas += receipt.GasUsed
+	}
+}
+
+func (t *gasProfileTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value uint256.Int, code []byte) {
+	op := vm.OpCode(typ)
+	if len(t.frames) > 0 {
+		if parent := t.frames[len(t.frames)-1]; parent.call != nil && isCall(op) {
+			passed := gas
+			if (op == vm.CALL || op == vm.CALLCODE) && !value.IsZero() {
+				passed -= min(passed, params.CallStipend) // the stipend is given on top of the gas charged
+			}
+			parent.call.Gas -= min(parent.call.Gas, passed)
+			parent.call = nil
+		}
+	}
+
+	frame := &gasProfileFrame{address: to, code: len(code) > 0, precompile: precompile}
+	switch {
+	case op == vm.CREATE || op == vm.CREATE2:
+		frame.selector = "create"
+		frame.code = true // runs the init code, given as the input
+	case len(input) >= 4 && !precompile:
+		frame.selector = bytesToHex(input[:4])
+	}
+	frame.label = to.Hex()
+	if frame.selector != "" {
+		frame.label += ":" + frame.selector
+	}
+	t.frames = append(t.frames, frame)
+}
+
+func (t *gasProfileTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	s := statOf(t.profile.Opcodes, vm.OpCode(op).String())
+	s.Gas += cost
+	s.Count++
+	frame := t.frames[len(t.frames)-1]
+	frame.call = nil
+	if isCall(vm.OpCode(op)) && err == nil {
+		frame.call = s
+	}
+}
+
+func (t *gasProfileTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].childGas += gasUsed
+	}
+
+	switch {
+	case frame.precompile:
+		statOf(t.profile.Precompiles, frame.address).add(GasStat{Gas: gasUsed, Count: 1})
+		return
+	case !frame.code:
+		return
+	}
+	self := gasUsed - min(gasUsed, frame.childGas)
+	statOf(t.profile.Contracts, frame.address).add(GasStat{Gas: self, Count: 1})
+	if t.profile.Selectors[frame.address] == nil {
+		t.profile.Selectors[frame.address] = map[string]*GasStat{}
+	}
+	statOf(t.profile.Selectors[frame.address], frame.selector).add(GasStat{Gas: self, Count: 1})
+	if self > 0 {
+		labels := make([]string, 0, len(t.frames)+1)
+		for _, f := range t.frames {
+			labels = append(labels, f.label)
+		}
+		t.profile.Stacks[strings.Join(append(labels, frame.label), ";")] += self
+	}
+}
+
+func (t *gasProfileTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.profile)
+}
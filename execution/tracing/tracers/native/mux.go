@@ -59,7 +59,12 @@ func newMuxTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, e
 		objects = append(objects, t)
 		names = append(names, k)
 	}
+	return NewMuxTracer(names, objects), nil
+}
 
+// NewMuxTracer returns a tracer running the given tracers in one go, its result
+// is the object of their results by name.
+func NewMuxTracer(names []string, objects []*tracers.Tracer) *tracers.Tracer {
 	t := &muxTracer{names: names, tracers: objects}
 	return &tracers.Tracer{
 		Hooks: &tracing.Hooks{
@@ -78,7 +83,7 @@ func newMuxTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, e
 		},
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
-	}, nil
+	}
 }
 
 func (t *muxTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
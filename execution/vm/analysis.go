@@ -19,6 +19,17 @@
 
 package vm
 
+import (
+	"cmp"
+	"math"
+	"sort"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/dbg"
+)
+
 // codeBitmap collects data locations in code.
 func codeBitmap(code []byte) bitvec {
 	// The bitmap is 4 bytes longer than necessary, in case the code
@@ -65,3 +76,184 @@ func (bits bitvec) setN(flag uint64, pc uint64) {
 func (bits bitvec) codeSegment(pos uint64) bool {
 	return ((bits[pos/64] >> (pos % 64)) & 1) == 0
 }
+
+// basicBlock is a straight run of instructions that is entered only at its
+// first instruction. Every instruction but the last one has constant gas only,
+// does not change control flow and does not observe the remaining gas, so the
+// constant gas of the whole block can be charged on entry.
+type basicBlock struct {
+	start uint64 // pc of the first instruction
+	last  uint64 // pc of the last instruction
+	end   uint64 // pc following the last instruction
+
+	gas      uint64 // sum of the constant gas of all instructions
+	minStack int    // stack length required on entry to not underflow
+	maxStack int    // stack length allowed on entry to not overflow
+}
+
+// Superinstructions, replacing a pair of instructions inside a basic block.
+const (
+	fuseNone       byte = iota
+	fusePushJump        // PUSHn dest, JUMP
+	fusePushJumpi       // PUSHn dest, JUMPI
+	fusePushMstore      // PUSHn offset, MSTORE
+	fuseDupSwap         // DUPn, SWAPm
+)
+
+// maxFusedPush is the widest PUSH fused with a JUMP, JUMPI or MSTORE. Wider
+// immediates can't be valid jump destinations or affordable memory offsets.
+const maxFusedPush = 4
+
+// blockAnalysis is the result of the basic block analysis of a piece of code
+// for a given instruction set.
+type blockAnalysis struct {
+	blocks []basicBlock // sorted by start
+	fused  []byte       // superinstruction starting at each pc, fuseNone if none
+}
+
+// blockIndex returns the index of the block starting at pc, or -1.
+func (a *blockAnalysis) blockIndex(pc uint64) int {
+	i, found := sort.Find(len(a.blocks), func(i int) int { return cmp.Compare(pc, a.blocks[i].start) })
+	if !found {
+		return -1
+	}
+	return i
+}
+
+// endsBlock tells whether the instruction must be the last one of its block:
+// it changes control flow, halts, has a dynamic gas cost or reads the
+// remaining gas.
+func endsBlock(op OpCode, operation *operation) bool {
+	switch op {
+	case JUMP, JUMPI, STOP, RETURN, REVERT, SELFDESTRUCT, INVALID, GAS:
+		return true
+	}
+	return operation.dynamicGas != nil
+}
+
+// pushImmediate returns the immediate of the PUSHn at pc, provided it fits in
+// maxFusedPush bytes and lies entirely within the code.
+func pushImmediate(code []byte, pc uint64) (uint64, bool) {
+	n := uint64(OpCode(code[pc]) - PUSH1 + 1)
+	if n > maxFusedPush || pc+n >= uint64(len(code)) {
+		return 0, false
+	}
+	var v uint64
+	for _, b := range code[pc+1 : pc+1+n] {
+		v = v<<8 | uint64(b)
+	}
+	return v, true
+}
+
+// analyseBlocks splits code into basic blocks, computing their constant gas
+// and stack bounds against the instruction set, and marks the superinstructions.
+func analyseBlocks(code []byte, jt *JumpTable) *blockAnalysis {
+	a := &blockAnalysis{fused: make([]byte, len(code))}
+	jumpdests := codeBitmap(code)
+	isJumpdest := func(dest uint64) bool {
+		return dest < uint64(len(code)) && OpCode(code[dest]) == JUMPDEST && jumpdests.codeSegment(dest)
+	}
+
+	b := basicBlock{maxStack: math.MaxInt}
+	var height int // stack height relative to the block entry
+	for pc := uint64(0); pc < uint64(len(code)); {
+		op := OpCode(code[pc])
+		if op == JUMPDEST && pc != b.start {
+			a.blocks = append(a.blocks, b)
+			b, height = basicBlock{start: pc, maxStack: math.MaxInt}, 0
+		}
+		operation := jt[op]
+		b.gas += operation.constantGas
+		b.minStack = max(b.minStack, operation.numPop-height)
+		b.maxStack = min(b.maxStack, operation.maxStack-height)
+		height += operation.numPush - operation.numPop
+
+		next := pc + 1
+		if op.IsPushWithImmediateArgs() {
+			next += uint64(op - PUSH0)
+		}
+		if next < uint64(len(code)) {
+			a.fused[pc] = fusion(code, pc, next, isJumpdest)
+		}
+		b.last, b.end = pc, next
+		if endsBlock(op, operation) {
+			a.blocks = append(a.blocks, b)
+			b, height = basicBlock{start: next, maxStack: math.MaxInt}, 0
+		}
+		pc = next
+	}
+	if b.end > b.start {
+		a.blocks = append(a.blocks, b)
+	}
+	return a
+}
+
+// fusion returns the superinstruction made of the instructions at pc and next.
+func fusion(code []byte, pc, next uint64, isJumpdest func(uint64) bool) byte {
+	op, nextOp := OpCode(code[pc]), OpCode(code[next])
+	switch {
+	case op.IsPushWithImmediateArgs():
+		imm, ok := pushImmediate(code, pc)
+		if !ok {
+			return fuseNone
+		}
+		switch nextOp {
+		case JUMP:
+			if isJumpdest(imm) {
+				return fusePushJump
+			}
+		case JUMPI:
+			if isJumpdest(imm) {
+				return fusePushJumpi
+			}
+		case MSTORE:
+			return fusePushMstore
+		}
+	case op >= DUP1 && op <= DUP16:
+		if nextOp >= SWAP1 && nextOp <= SWAP16 {
+			return fuseDupSwap
+		}
+	}
+	return fuseNone
+}
+
+// BlockAnalysisCache holds the basic block analysis of contract code. Constant
+// gas differs between forks, so entries are keyed by code hash and instruction set.
+type BlockAnalysisCache struct {
+	*simplelru.LRU[blockAnalysisKey, *blockAnalysis]
+}
+
+type blockAnalysisKey struct {
+	codeHash common.Hash
+	jt       *JumpTable
+}
+
+var (
+	BlockAnalysisCacheLimit = dbg.EnvInt("BB_LRU", 128)
+	// BlockAnalysisEnabled makes every EVM run in basic block mode unless its
+	// config already carries a cache.
+	BlockAnalysisEnabled = dbg.EnvBool("EVM_BASIC_BLOCKS", false)
+)
+
+func NewBlockAnalysisCache(limit int) *BlockAnalysisCache {
+	c, err := simplelru.NewLRU[blockAnalysisKey, *blockAnalysis](limit, nil)
+	if err != nil {
+		panic(err)
+	}
+	return &BlockAnalysisCache{LRU: c}
+}
+
+// analysis returns the block analysis of the contract code. Code without a hash
+// (initcode) is analysed but not cached.
+func (c *BlockAnalysisCache) analysis(contract *Contract, jt *JumpTable) *blockAnalysis {
+	if contract.CodeHash == (common.Hash{}) {
+		return analyseBlocks(contract.Code, jt)
+	}
+	key := blockAnalysisKey{codeHash: contract.CodeHash, jt: jt}
+	a, ok := c.Get(key)
+	if !ok {
+		a = analyseBlocks(contract.Code, jt)
+		c.Add(key, a)
+	}
+	return a
+}
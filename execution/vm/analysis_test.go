@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
 
 	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/common/crypto"
@@ -57,6 +58,36 @@ func TestJumpDestAnalysis(t *testing.T) {
 	}
 }
 
+func TestBlockAnalysis(t *testing.T) {
+	t.Parallel()
+	code := []byte{
+		byte(PUSH1), 1, byte(PUSH1), 6, byte(JUMPI),
+		byte(STOP),
+		byte(JUMPDEST), byte(DUP1), byte(SWAP1), byte(ADD), byte(GAS),
+		byte(PUSH1), 0x20, byte(MSTORE),
+	}
+	a := analyseBlocks(code, &londonInstructionSet)
+	require.Equal(t, []basicBlock{
+		{start: 0, last: 4, end: 5, gas: 16, minStack: 0, maxStack: 1022},
+		{start: 5, last: 5, end: 6, gas: 0, minStack: 0, maxStack: 1024},
+		{start: 6, last: 10, end: 11, gas: 12, minStack: 1, maxStack: 1023},
+		{start: 11, last: 13, end: 14, gas: 6, minStack: 1, maxStack: 1023},
+	}, a.blocks)
+	require.Equal(t, []byte{
+		fuseNone, fuseNone, fusePushJumpi, fuseNone, fuseNone,
+		fuseNone,
+		fuseNone, fuseDupSwap, fuseNone, fuseNone, fuseNone,
+		fusePushMstore, fuseNone, fuseNone,
+	}, a.fused)
+	require.Equal(t, 2, a.blockIndex(6))
+	require.Equal(t, -1, a.blockIndex(7))
+
+	// a jump to a JUMPDEST inside push data is not fused
+	a = analyseBlocks([]byte{byte(PUSH1), 3, byte(JUMP), byte(PUSH1), byte(JUMPDEST)}, &londonInstructionSet)
+	require.Equal(t, fuseNone, a.fused[0])
+	require.Len(t, a.blocks, 2)
+}
+
 func BenchmarkJumpdestAnalysisEmpty_1200k(bench *testing.B) {
 	// 1.4 ms
 	code := make([]byte, 1200000)
@@ -106,6 +106,9 @@ func NewEVM(blockCtx evmtypes.BlockContext, txCtx evmtypes.TxContext, ibs *state
 	if evm.config.JumpDestCache == nil {
 		evm.config.JumpDestCache = NewJumpDestCache(JumpDestCacheLimit)
 	}
+	if evm.config.BlockAnalysis == nil && BlockAnalysisEnabled {
+		evm.config.BlockAnalysis = NewBlockAnalysisCache(BlockAnalysisCacheLimit)
+	}
 
 	evm.interpreter = NewEVMInterpreter(evm, vmConfig)
 
@@ -134,6 +137,9 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	if vmConfig.JumpDestCache == nil && evm.config.JumpDestCache != nil {
 		vmConfig.JumpDestCache = evm.config.JumpDestCache
 	}
+	if vmConfig.BlockAnalysis == nil && evm.config.BlockAnalysis != nil {
+		vmConfig.BlockAnalysis = evm.config.BlockAnalysis
+	}
 	evm.config = vmConfig
 	evm.chainRules = chainRules
 
@@ -40,6 +40,9 @@ import (
 type Config struct {
 	Tracer        *tracing.Hooks
 	JumpDestCache *JumpDestCache
+	// BlockAnalysis, when set, makes untraced execution charge constant gas per
+	// basic block and run superinstructions.
+	BlockAnalysis *BlockAnalysisCache
 	NoRecursion   bool // Disables call, callcode, delegate call and create
 	NoBaseFee     bool // Forces the EIP-1559 baseFee to 0 (needed for 0 price calls)
 	TraceJumpDest bool // Print transaction hashes where jumpdest analysis was useful
@@ -363,6 +366,14 @@ func (in *EVMInterpreter) Run(contract Contract, gas uint64, input []byte, readO
 	// parent context.
 	steps := 0
 
+	if in.evm.config.BlockAnalysis != nil && !debug && !trace && !dbg.TraceDyanmicGas {
+		res, err = in.runBlocks(callContext, in.evm.config.BlockAnalysis.analysis(&contract, in.jt))
+		if errors.Is(err, errStopToken) {
+			err = nil // clear stop token error
+		}
+		return res, callContext.gas, err
+	}
+
 	var traceGas = func(op OpCode, callGas, cost uint64) uint64 {
 		switch op {
 		case CALL, CALLCODE, DELEGATECALL, STATICCALL:
@@ -477,6 +488,139 @@ func (in *EVMInterpreter) Run(contract Contract, gas uint64, input []byte, readO
 	return res, callContext.gas, err
 }
 
+// runBlocks is the untraced counterpart of the Run loop. The constant gas and
+// the stack bounds of a basic block are checked once on entry, and
+// superinstructions replace common pairs of instructions. A block failing
+// these checks is bound to fail, so it runs instruction by instruction to fail
+// exactly the way Run does.
+func (in *EVMInterpreter) runBlocks(callContext *CallContext, a *blockAnalysis) (res []byte, err error) {
+	var (
+		code  = callContext.Contract.Code
+		pc    uint64
+		steps int
+	)
+	for i := 0; i < len(a.blocks); {
+		b := &a.blocks[i]
+		sLen := callContext.Stack.len()
+		charged := sLen >= b.minStack && sLen <= b.maxStack && callContext.useGas(b.gas, nil, tracing.GasChangeIgnored)
+		for {
+			steps++
+			if steps%5000 == 0 && in.evm.Cancelled() {
+				return nil, nil
+			}
+			at := pc
+			if f := a.fused[pc]; charged && f != fuseNone {
+				// at becomes the pc of the second instruction of the pair
+				switch f {
+				case fusePushJump:
+					dest, _ := pushImmediate(code, pc)
+					at, pc = pc+uint64(code[pc]-byte(PUSH0))+1, dest
+				case fusePushJumpi:
+					dest, _ := pushImmediate(code, pc)
+					at = pc + uint64(code[pc]-byte(PUSH0)) + 1
+					if cond := callContext.Stack.pop(); !cond.IsZero() {
+						pc = dest
+					} else {
+						pc = at + 1
+					}
+				case fusePushMstore:
+					if err = in.pushMstore(pc, callContext); err != nil {
+						return nil, err
+					}
+					at = pc + uint64(code[pc]-byte(PUSH0)) + 1
+					pc = at + 1
+				case fuseDupSwap:
+					at = pc + 1
+					callContext.Stack.dup(int(code[pc]-byte(DUP1)) + 1)
+					callContext.Stack.swap(int(code[at]-byte(SWAP1)) + 1)
+					pc = at + 1
+				}
+				if at == b.last {
+					break
+				}
+				continue
+			}
+			op := OpCode(code[pc])
+			operation := in.jt[op]
+			if !charged {
+				if sLen := callContext.Stack.len(); sLen < operation.numPop {
+					return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.numPop}
+				} else if sLen > operation.maxStack {
+					return nil, &ErrStackOverflow{stackLen: sLen, limit: operation.maxStack}
+				}
+				if !callContext.useGas(operation.constantGas, nil, tracing.GasChangeIgnored) {
+					return nil, ErrOutOfGas
+				}
+			}
+			if operation.dynamicGas != nil {
+				if err = in.useDynamicGas(operation, callContext); err != nil {
+					return nil, err
+				}
+			}
+			pc, res, err = operation.execute(pc, in, callContext)
+			if err != nil {
+				return res, err
+			}
+			pc++
+			if at == b.last {
+				break
+			}
+		}
+		if pc == b.end {
+			i++
+		} else if i = a.blockIndex(pc); i < 0 {
+			// jumps only land on JUMPDESTs, which always start a block
+			panic(fmt.Sprintf("no basic block at pc %d", pc))
+		}
+	}
+	// Running off the end of the code is an implicit STOP
+	return nil, nil
+}
+
+// useDynamicGas charges the dynamic gas of the operation and expands the
+// memory, the same way Run does.
+func (in *EVMInterpreter) useDynamicGas(operation *operation, callContext *CallContext) error {
+	var memorySize uint64
+	if operation.memorySize != nil {
+		memSize, overflow := operation.memorySize(callContext)
+		if overflow {
+			return ErrGasUintOverflow
+		}
+		if memorySize, overflow = math.SafeMul(ToWordSize(memSize), 32); overflow {
+			return ErrGasUintOverflow
+		}
+	}
+	dynamicCost, err := operation.dynamicGas(in.evm, callContext, callContext.gas, memorySize)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOutOfGas, err)
+	}
+	if !callContext.useGas(dynamicCost, nil, tracing.GasChangeIgnored) {
+		return ErrOutOfGas
+	}
+	if memorySize > 0 {
+		callContext.Memory.Resize(memorySize)
+	}
+	return nil
+}
+
+// pushMstore runs PUSHn offset, MSTORE without putting the offset on the stack.
+// The MSTORE gas only depends on the memory size, which follows from the offset.
+func (in *EVMInterpreter) pushMstore(pc uint64, callContext *CallContext) error {
+	offset, _ := pushImmediate(callContext.Contract.Code, pc)
+	memorySize := ToWordSize(offset+32) * 32
+	dynamicCost, err := in.jt[MSTORE].dynamicGas(in.evm, callContext, callContext.gas, memorySize)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOutOfGas, err)
+	}
+	if !callContext.useGas(dynamicCost, nil, tracing.GasChangeIgnored) {
+		return ErrOutOfGas
+	}
+	callContext.Memory.Resize(memorySize)
+	val := callContext.Stack.pop()
+	callContext.Memory.Set32(offset, &val)
+	return nil
+}
+
 // Depth returns the current call stack depth.
 func (in *EVMInterpreter) Depth() int { return in.depth }
 
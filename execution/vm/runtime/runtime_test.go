@@ -715,3 +715,64 @@ func BenchmarkEVM_SWAP1(b *testing.B) {
 		}
 	})
 }
+
+// TestBlockAnalysis checks that basic block execution returns the same output,
+// gas and error as the instruction by instruction loop, for every gas limit
+// up to what the program needs.
+func TestBlockAnalysis(t *testing.T) {
+	t.Parallel()
+	db := testTemporalDB(t)
+	tx, domains := testTemporalTxSD(t, db)
+
+	programs := map[string][]byte{
+		"loop": {
+			byte(vm.PUSH1), 10,
+			byte(vm.JUMPDEST),
+			byte(vm.PUSH1), 1, byte(vm.SWAP1), byte(vm.SUB),
+			byte(vm.PUSH1), 3, byte(vm.DUP2), byte(vm.SWAP1), byte(vm.SUB),
+			byte(vm.PUSH1), 0x40, byte(vm.MSTORE),
+			byte(vm.DUP1), byte(vm.PUSH1), 2, byte(vm.JUMPI),
+			byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x40, byte(vm.RETURN),
+		},
+		"gas": {
+			byte(vm.PUSH1), 1, byte(vm.POP),
+			byte(vm.GAS), byte(vm.PUSH1), 0, byte(vm.MSTORE),
+			byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0, byte(vm.RETURN),
+		},
+		"revert": {
+			byte(vm.PUSH1), 7, byte(vm.PUSH2), 0x01, 0x00, byte(vm.MSTORE),
+			byte(vm.PUSH1), 0x20, byte(vm.PUSH2), 0x01, 0x00, byte(vm.REVERT),
+		},
+		"sstore": {
+			byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE),
+			byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+			byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+			byte(vm.PUSH1), 4, byte(vm.GAS), byte(vm.STATICCALL),
+			byte(vm.STOP),
+		},
+		"underflow":   {byte(vm.PUSH1), 1, byte(vm.ADD)},
+		"invalidJump": {byte(vm.PUSH1), 1, byte(vm.JUMP), byte(vm.JUMPDEST)},
+		"selfJump":    {byte(vm.JUMPDEST), byte(vm.PUSH1), 0, byte(vm.JUMP)},
+		"truncated":   {byte(vm.PUSH1), 1, byte(vm.PUSH2), 0xff},
+	}
+	address := common.HexToAddress("0xaa")
+	run := func(code []byte, gas uint64, blocks bool) ([]byte, uint64, error) {
+		cfg := &Config{State: state.New(state.NewReaderV3(domains.AsGetter(tx))), GasLimit: gas}
+		if blocks {
+			cfg.EVMConfig.BlockAnalysis = vm.NewBlockAnalysisCache(16)
+		}
+		cfg.State.SetCode(address, code)
+		return Call(address, nil, cfg)
+	}
+	for name, code := range programs {
+		t.Run(name, func(t *testing.T) {
+			for gas := uint64(1); gas <= 50_000; gas += 1 + gas/1000 {
+				ret, leftOver, err := run(code, gas, false)
+				blockRet, blockLeftOver, blockErr := run(code, gas, true)
+				require.Equal(t, ret, blockRet, "gas %d", gas)
+				require.Equal(t, leftOver, blockLeftOver, "gas %d", gas)
+				require.Equal(t, fmt.Sprint(err), fmt.Sprint(blockErr), "gas %d", gas)
+			}
+		})
+	}
+}
@@ -111,6 +111,11 @@ func (st *Stack) swap16() {
 	st.data[st.len()-17], st.data[st.len()-1] = st.data[st.len()-1], st.data[st.len()-17]
 }
 
+// swap exchanges the top of the stack with the n'th item below it
+func (st *Stack) swap(n int) {
+	st.data[st.len()-n-1], st.data[st.len()-1] = st.data[st.len()-1], st.data[st.len()-n-1]
+}
+
 func (st *Stack) dup(n int) {
 	st.data = append(st.data, st.data[len(st.data)-n])
 }
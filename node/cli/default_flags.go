@@ -38,9 +38,14 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolGlobalBaseFeeSlotsFlag,
 	&utils.TxPoolGlobalQueueFlag,
 	&utils.TxPoolTraceSendersFlag,
+	&utils.TxPoolOrderingFlag,
+	&utils.TxPoolPrioritySendersFlag,
+	&utils.TxPoolJournalFlag,
+	&utils.TxPoolReplicateToFlag,
 	&utils.TxPoolCommitEveryFlag,
 	&PruneDistanceFlag,
 	&PruneBlocksDistanceFlag,
+	&PruneRetentionFlag,
 	&PruneModeFlag,
 	&utils.KeepExecutionProofsFlag,
 
@@ -67,6 +72,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.AuthRpcAddr,
 	&utils.AuthRpcPort,
 	&utils.JWTSecretPath,
+	&utils.AuthRpcRecordFlag,
 	&utils.HttpCompressionFlag,
 	&utils.HTTPCORSDomainFlag,
 	&utils.HTTPVirtualHostsFlag,
@@ -142,6 +148,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.MaxPendingPeersFlag,
 	&utils.ChainFlag,
 	&utils.DeveloperPeriodFlag,
+	&utils.DeveloperPoSFlag,
 	&utils.VMEnableDebugFlag,
 	&utils.NetworkIdFlag,
 	&utils.PersistReceiptsV2Flag,
@@ -165,6 +172,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.DisableIPV6,
 	&utils.NoDownloaderFlag,
 	&utils.DownloaderVerifyFlag,
+	&utils.SnapSharedStoreFlag,
+	&utils.SnapSharedStoreModeFlag,
 	&HealthCheckFlag,
 	&utils.HeimdallURLFlag,
 	&utils.WebSeedsFlag,
@@ -193,6 +202,7 @@ var DefaultFlags = []cli.Flag{
 
 	&utils.OtsSearchMaxCapFlag,
 	&utils.OtsV2Flag,
+	&utils.OtsV2EventsFlag,
 
 	&utils.SilkwormExecutionFlag,
 	&utils.SilkwormRpcDaemonFlag,
@@ -226,6 +236,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinDisableCheckpointSyncFlag,
 	&utils.CaplinEnableSnapshotGeneration,
 	&utils.CaplinMevRelayUrl,
+	&utils.CaplinBuilderRelaysFlag,
+	&utils.CaplinBuilderSecretKeyFlag,
 	&utils.CaplinValidatorMonitorFlag,
 	&utils.CaplinCustomConfigFlag,
 	&utils.CaplinCustomGenesisFlag,
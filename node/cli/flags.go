@@ -34,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/db/kv"
 	"github.com/erigontech/erigon/db/kv/kvcache"
 	"github.com/erigontech/erigon/db/kv/prune"
+	"github.com/erigontech/erigon/db/state/statecfg"
 	"github.com/erigontech/erigon/node/ethconfig"
 	"github.com/erigontech/erigon/node/nodecfg"
 	"github.com/erigontech/erigon/rpc/rpccfg"
@@ -91,6 +92,12 @@ var (
 		Name:  "prune.distance.blocks",
 		Usage: `Keep block history for the latest N blocks (default: everything)`,
 	}
+	PruneRetentionFlag = cli.StringFlag{
+		Name: "prune.retention",
+		Usage: `Per-history retention on top of --prune.mode: comma-separated name=value. Name: domain or index (accounts, storage, code, commitment, receipt, rcache, logaddrs, logtopics, tracesfrom, tracesto).
+				Value: amount of blocks, age (90d, 12h) or "all". Example: --prune.retention=storage=0,tracesfrom=90d,tracesto=90d,logaddrs=all.
+				History is deleted by whole files: effective retention is rounded up to files boundaries`,
+	}
 	// mTLS flags
 	TLSFlag = cli.BoolFlag{
 		Name:  "tls",
@@ -262,6 +269,10 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 
 	cfg.Prune = mode
 
+	if cfg.PruneRetention, err = statecfg.ParseRetentions(ctx.String(PruneRetentionFlag.Name)); err != nil {
+		utils.Fatalf("Invalid --%s: %v", PruneRetentionFlag.Name, err)
+	}
+
 	if batchSize := ctx.String(BatchSizeFlag.Name); batchSize != "" {
 		if err := cfg.BatchSize.UnmarshalText([]byte(batchSize)); err != nil {
 			utils.Fatalf("Invalid batchSize provided: %v", err)
@@ -347,6 +358,12 @@ func ApplyFlagsForEthConfigCobra(f *pflag.FlagSet, cfg *ethconfig.Config) {
 
 	cfg.Prune = mode
 
+	if v := f.String(PruneRetentionFlag.Name, PruneRetentionFlag.Value, PruneRetentionFlag.Usage); v != nil {
+		if cfg.PruneRetention, err = statecfg.ParseRetentions(*v); err != nil {
+			utils.Fatalf("Invalid --%s: %v", PruneRetentionFlag.Name, err)
+		}
+	}
+
 	if v := f.String(BatchSizeFlag.Name, BatchSizeFlag.Value, BatchSizeFlag.Usage); v != nil {
 		err := cfg.BatchSize.UnmarshalText([]byte(*v))
 		if err != nil {
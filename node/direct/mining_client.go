@@ -213,3 +213,7 @@ func (s *MiningClient) HashRate(ctx context.Context, in *txpoolproto.HashRateReq
 func (s *MiningClient) Mining(ctx context.Context, in *txpoolproto.MiningRequest, opts ...grpc.CallOption) (*txpoolproto.MiningReply, error) {
 	return s.server.Mining(ctx, in)
 }
+
+func (s *MiningClient) SendBundle(ctx context.Context, in *txpoolproto.SendBundleRequest, opts ...grpc.CallOption) (*txpoolproto.SendBundleReply, error) {
+	return s.server.SendBundle(ctx, in)
+}
@@ -124,3 +124,78 @@ func (s *TxPoolClient) Nonce(ctx context.Context, in *txpoolproto.NonceRequest,
 func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpoolproto.GetBlobsRequest, opts ...grpc.CallOption) (*txpoolproto.GetBlobsReply, error) {
 	return s.server.GetBlobs(ctx, in)
 }
+
+func (s *TxPoolClient) TxnStatus(ctx context.Context, in *txpoolproto.TxnStatusRequest, opts ...grpc.CallOption) (*txpoolproto.TxnStatusReply, error) {
+	return s.server.TxnStatus(ctx, in)
+}
+
+func (s *TxPoolClient) AddConditional(ctx context.Context, in *txpoolproto.AddConditionalRequest, opts ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return s.server.AddConditional(ctx, in)
+}
+
+func (s *TxPoolClient) AddPrivate(ctx context.Context, in *txpoolproto.AddPrivateRequest, opts ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return s.server.AddPrivate(ctx, in)
+}
+
+// -- start Export
+
+func (s *TxPoolClient) Export(ctx context.Context, in *txpoolproto.ExportRequest, opts ...grpc.CallOption) (txpoolproto.Txpool_ExportClient, error) {
+	ch := make(chan *exportReply, 16384)
+	streamServer := &TxPoolExportS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.Export(in, streamServer))
+	}()
+	return &TxPoolExportC{ch: ch, ctx: ctx}, nil
+}
+
+type exportReply struct {
+	r   *txpoolproto.ExportedTxn
+	err error
+}
+
+type TxPoolExportS struct {
+	ch  chan *exportReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolExportS) Send(m *txpoolproto.ExportedTxn) error {
+	select {
+	case s.ch <- &exportReply{r: m}:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+func (s *TxPoolExportS) Context() context.Context { return s.ctx }
+func (s *TxPoolExportS) Err(err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case s.ch <- &exportReply{err: err}:
+	case <-s.ctx.Done():
+	}
+}
+
+type TxPoolExportC struct {
+	ch  chan *exportReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolExportC) Recv() (*txpoolproto.ExportedTxn, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolExportC) Context() context.Context { return c.ctx }
+
+// -- end Export
+
+func (s *TxPoolClient) Import(ctx context.Context, in *txpoolproto.ImportRequest, opts ...grpc.CallOption) (*txpoolproto.AddReply, error) {
+	return s.server.Import(ctx, in)
+}
@@ -131,6 +131,7 @@ import (
 	"github.com/erigontech/erigon/rpc/jsonrpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/bundle"
 	"github.com/erigontech/erigon/txnprovider/shutter"
 	"github.com/erigontech/erigon/txnprovider/txpool"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
@@ -207,6 +208,7 @@ type Ethereum struct {
 	txPoolGrpcServer          txpoolproto.TxpoolServer
 	txPoolRpcClient           txpoolproto.TxpoolClient
 	shutterPool               *shutter.Pool
+	bundlePool                *bundle.Pool
 	blockBuilderNotifyNewTxns chan struct{}
 	forkValidator             *engine_helpers.ForkValidator
 	downloader                *downloader.Downloader
@@ -315,6 +317,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if config.PersistReceiptsCacheV2 {
 			statecfg.EnableHistoricalRCache()
 		}
+		customTraces, err := kvcfg.CustomTraces.Enabled(tx)
+		if err != nil {
+			return err
+		}
+		if customTraces {
+			statecfg.EnableCustomTraces()
+		}
 
 		if err := checkAndSetCommitmentHistoryFlag(tx, logger, dirs, config); err != nil {
 			return err
@@ -327,6 +336,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if err != nil {
 			return err
 		}
+		if err := statecfg.WriteRetentions(tx, config.PruneRetention); err != nil {
+			return err
+		}
 
 		return nil
 	}); err != nil {
@@ -752,7 +764,8 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		ethashApi = casted.APIs(nil)[1].Service.(*ethash.API)
 	}
 
-	backend.miningRPC = privateapi2.NewMiningServer(ctx, backend, ethashApi, logger)
+	backend.bundlePool = bundle.NewPool(bundle.DefaultPoolLimit)
+	backend.miningRPC = privateapi2.NewMiningServer(ctx, backend, ethashApi, backend.bundlePool, logger)
 	backend.ethBackendRPC = privateapi2.NewEthBackendServer(
 		ctx,
 		backend,
@@ -903,7 +916,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 					config.ExperimentalBAL,
 				),
 				stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
-				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, txnProvider, blockReader),
+				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, txnProvider, backend.bundlePool, blockReader),
 				stagedsync.StageMiningFinishCfg(backend.chainDB, backend.chainConfig, backend.engine, miningStatePos, backend.miningSealingQuit, backend.blockReader, latestBlockBuiltStore),
 				astridEnabled,
 			), stagedsync.MiningUnwindOrder, stagedsync.MiningPruneOrder, logger, stages.ModeBlockProduction)
@@ -1020,6 +1033,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		!config.PolygonPosSingleSlotFinality,
 		backend.txPoolRpcClient,
 		config.FcuTimeout,
+		config.EngineApiRecordPath,
 	)
 	backend.engineBackendRPC = engineBackendRPC
 	// If we choose not to run a consensus layer, run our embedded.
@@ -1375,6 +1389,10 @@ func SetUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 	}
 	agg.SetSnapshotBuildSema(blockSnapBuildSema)
 	agg.SetProduceMod(snConfig.Snapshot.ProduceE3)
+	if len(snConfig.PruneRetention) > 0 {
+		logger.Info("History retention", "policy", snConfig.PruneRetention.String())
+		agg.SetRetention(snConfig.PruneRetention, stagedsync.RetentionTxNum(ctx, blockReader))
+	}
 
 	allSegmentsDownloadComplete, err := rawdb.AllSegmentsDownloadCompleteFromDB(db)
 	if err != nil {
@@ -38,6 +38,7 @@ import (
 	"github.com/erigontech/erigon/db/datadir"
 	"github.com/erigontech/erigon/db/downloader/downloadercfg"
 	"github.com/erigontech/erigon/db/kv/prune"
+	"github.com/erigontech/erigon/db/state/statecfg"
 	"github.com/erigontech/erigon/execution/builder/buildercfg"
 	"github.com/erigontech/erigon/execution/chain"
 	chainspec "github.com/erigontech/erigon/execution/chain/spec"
@@ -193,8 +194,9 @@ type Config struct {
 	// for nodes to connect to.
 	EthDiscoveryURLs []string
 
-	Prune     prune.Mode
-	BatchSize datasize.ByteSize // Batch size for execution stage
+	Prune          prune.Mode
+	PruneRetention statecfg.Retentions // per-history retention on top of Prune. see `--prune.retention`
+	BatchSize      datasize.ByteSize   // Batch size for execution stage
 
 	ImportMode bool
 
@@ -283,6 +285,9 @@ type Config struct {
 	// fork choice update timeout
 	FcuTimeout time.Duration
 
+	// file to record Engine API calls to, for replay by `integration engine_replay`
+	EngineApiRecordPath string
+
 	DisableTxPoolGossip bool
 
 	// Otterscan2 indexers
@@ -26,6 +26,13 @@ func EnableSyncCfg(chainDB kv.RoDB, syncCfg ethconfig.Sync) (ethconfig.Sync, err
 		if syncCfg.PersistReceiptsCacheV2 {
 			statecfg.EnableHistoricalRCache()
 		}
+		customTraces, err := kvcfg.CustomTraces.Enabled(tx)
+		if err != nil {
+			return err
+		}
+		if customTraces {
+			statecfg.EnableCustomTraces()
+		}
 		return nil
 	})
 	return syncCfg, err
@@ -11,6 +11,7 @@ import (
 	"github.com/erigontech/erigon/db/datadir"
 	"github.com/erigontech/erigon/db/downloader/downloadercfg"
 	"github.com/erigontech/erigon/db/kv/prune"
+	"github.com/erigontech/erigon/db/state/statecfg"
 	"github.com/erigontech/erigon/execution/builder/buildercfg"
 	"github.com/erigontech/erigon/execution/chain"
 	chainspec "github.com/erigontech/erigon/execution/chain/spec"
@@ -28,6 +29,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		NetworkID                           uint64
 		EthDiscoveryURLs                    []string
 		Prune                               prune.Mode
+		PruneRetention                      statecfg.Retentions
 		BatchSize                           datasize.ByteSize
 		ImportMode                          bool
 		BadBlockHash                        common.Hash
@@ -74,6 +76,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NetworkID = c.NetworkID
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
 	enc.Prune = c.Prune
+	enc.PruneRetention = c.PruneRetention
 	enc.BatchSize = c.BatchSize
 	enc.ImportMode = c.ImportMode
 	enc.BadBlockHash = c.BadBlockHash
@@ -124,6 +127,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		NetworkID                           *uint64
 		EthDiscoveryURLs                    []string
 		Prune                               *prune.Mode
+		PruneRetention                      statecfg.Retentions
 		BatchSize                           *datasize.ByteSize
 		ImportMode                          *bool
 		BadBlockHash                        *common.Hash
@@ -182,6 +186,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Prune != nil {
 		c.Prune = *dec.Prune
 	}
+	if dec.PruneRetention != nil {
+		c.PruneRetention = dec.PruneRetention
+	}
 	if dec.BatchSize != nil {
 		c.BatchSize = *dec.BatchSize
 	}
@@ -735,6 +735,126 @@ func (x *MiningReply) GetRunning() bool {
 	return false
 }
 
+type SendBundleRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Txs               [][]byte               `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`                                                        // rlp encoded txns, in order of inclusion
+	BlockNumber       uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`                    // target block
+	MinTimestamp      uint64                 `protobuf:"varint,3,opt,name=min_timestamp,json=minTimestamp,proto3" json:"min_timestamp,omitempty"`                 // 0 - no limit
+	MaxTimestamp      uint64                 `protobuf:"varint,4,opt,name=max_timestamp,json=maxTimestamp,proto3" json:"max_timestamp,omitempty"`                 // 0 - no limit
+	RevertingTxHashes []*typesproto.H256     `protobuf:"bytes,5,rep,name=reverting_tx_hashes,json=revertingTxHashes,proto3" json:"reverting_tx_hashes,omitempty"` // txns which are allowed to revert
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SendBundleRequest) Reset() {
+	*x = SendBundleRequest{}
+	mi := &file_txpool_mining_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBundleRequest) ProtoMessage() {}
+
+func (x *SendBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBundleRequest.ProtoReflect.Descriptor instead.
+func (*SendBundleRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SendBundleRequest) GetTxs() [][]byte {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *SendBundleRequest) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *SendBundleRequest) GetMinTimestamp() uint64 {
+	if x != nil {
+		return x.MinTimestamp
+	}
+	return 0
+}
+
+func (x *SendBundleRequest) GetMaxTimestamp() uint64 {
+	if x != nil {
+		return x.MaxTimestamp
+	}
+	return 0
+}
+
+func (x *SendBundleRequest) GetRevertingTxHashes() []*typesproto.H256 {
+	if x != nil {
+		return x.RevertingTxHashes
+	}
+	return nil
+}
+
+type SendBundleReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          *typesproto.H256       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendBundleReply) Reset() {
+	*x = SendBundleReply{}
+	mi := &file_txpool_mining_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBundleReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBundleReply) ProtoMessage() {}
+
+func (x *SendBundleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBundleReply.ProtoReflect.Descriptor instead.
+func (*SendBundleReply) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SendBundleReply) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
 var File_txpool_mining_proto protoreflect.FileDescriptor
 
 const file_txpool_mining_proto_rawDesc = "" +
@@ -774,7 +894,15 @@ const file_txpool_mining_proto_rawDesc = "" +
 	"\rMiningRequest\"A\n" +
 	"\vMiningReply\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
-	"\arunning\x18\x02 \x01(\bR\arunning2\xe2\x04\n" +
+	"\arunning\x18\x02 \x01(\bR\arunning\"\xcf\x01\n" +
+	"\x11SendBundleRequest\x12\x10\n" +
+	"\x03txs\x18\x01 \x03(\fR\x03txs\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12#\n" +
+	"\rmin_timestamp\x18\x03 \x01(\x04R\fminTimestamp\x12#\n" +
+	"\rmax_timestamp\x18\x04 \x01(\x04R\fmaxTimestamp\x12;\n" +
+	"\x13reverting_tx_hashes\x18\x05 \x03(\v2\v.types.H256R\x11revertingTxHashes\"2\n" +
+	"\x0fSendBundleReply\x12\x1f\n" +
+	"\x04hash\x18\x01 \x01(\v2\v.types.H256R\x04hash2\xa4\x05\n" +
 	"\x06Mining\x126\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x13.types.VersionReply\x12N\n" +
 	"\x0eOnPendingBlock\x12\x1d.txpool.OnPendingBlockRequest\x1a\x1b.txpool.OnPendingBlockReply0\x01\x12H\n" +
@@ -785,7 +913,9 @@ const file_txpool_mining_proto_rawDesc = "" +
 	"SubmitWork\x12\x19.txpool.SubmitWorkRequest\x1a\x17.txpool.SubmitWorkReply\x12L\n" +
 	"\x0eSubmitHashRate\x12\x1d.txpool.SubmitHashRateRequest\x1a\x1b.txpool.SubmitHashRateReply\x12:\n" +
 	"\bHashRate\x12\x17.txpool.HashRateRequest\x1a\x15.txpool.HashRateReply\x124\n" +
-	"\x06Mining\x12\x15.txpool.MiningRequest\x1a\x13.txpool.MiningReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
+	"\x06Mining\x12\x15.txpool.MiningRequest\x1a\x13.txpool.MiningReply\x12@\n" +
+	"\n" +
+	"SendBundle\x12\x19.txpool.SendBundleRequest\x1a\x17.txpool.SendBundleReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
 
 var (
 	file_txpool_mining_proto_rawDescOnce sync.Once
@@ -799,7 +929,7 @@ func file_txpool_mining_proto_rawDescGZIP() []byte {
 	return file_txpool_mining_proto_rawDescData
 }
 
-var file_txpool_mining_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_txpool_mining_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_txpool_mining_proto_goTypes = []any{
 	(*OnPendingBlockRequest)(nil),   // 0: txpool.OnPendingBlockRequest
 	(*OnPendingBlockReply)(nil),     // 1: txpool.OnPendingBlockReply
@@ -817,33 +947,40 @@ var file_txpool_mining_proto_goTypes = []any{
 	(*HashRateReply)(nil),           // 13: txpool.HashRateReply
 	(*MiningRequest)(nil),           // 14: txpool.MiningRequest
 	(*MiningReply)(nil),             // 15: txpool.MiningReply
-	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 17: types.VersionReply
+	(*SendBundleRequest)(nil),       // 16: txpool.SendBundleRequest
+	(*SendBundleReply)(nil),         // 17: txpool.SendBundleReply
+	(*typesproto.H256)(nil),         // 18: types.H256
+	(*emptypb.Empty)(nil),           // 19: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 20: types.VersionReply
 }
 var file_txpool_mining_proto_depIdxs = []int32{
-	16, // 0: txpool.Mining.Version:input_type -> google.protobuf.Empty
-	0,  // 1: txpool.Mining.OnPendingBlock:input_type -> txpool.OnPendingBlockRequest
-	2,  // 2: txpool.Mining.OnMinedBlock:input_type -> txpool.OnMinedBlockRequest
-	4,  // 3: txpool.Mining.OnPendingLogs:input_type -> txpool.OnPendingLogsRequest
-	6,  // 4: txpool.Mining.GetWork:input_type -> txpool.GetWorkRequest
-	8,  // 5: txpool.Mining.SubmitWork:input_type -> txpool.SubmitWorkRequest
-	10, // 6: txpool.Mining.SubmitHashRate:input_type -> txpool.SubmitHashRateRequest
-	12, // 7: txpool.Mining.HashRate:input_type -> txpool.HashRateRequest
-	14, // 8: txpool.Mining.Mining:input_type -> txpool.MiningRequest
-	17, // 9: txpool.Mining.Version:output_type -> types.VersionReply
-	1,  // 10: txpool.Mining.OnPendingBlock:output_type -> txpool.OnPendingBlockReply
-	3,  // 11: txpool.Mining.OnMinedBlock:output_type -> txpool.OnMinedBlockReply
-	5,  // 12: txpool.Mining.OnPendingLogs:output_type -> txpool.OnPendingLogsReply
-	7,  // 13: txpool.Mining.GetWork:output_type -> txpool.GetWorkReply
-	9,  // 14: txpool.Mining.SubmitWork:output_type -> txpool.SubmitWorkReply
-	11, // 15: txpool.Mining.SubmitHashRate:output_type -> txpool.SubmitHashRateReply
-	13, // 16: txpool.Mining.HashRate:output_type -> txpool.HashRateReply
-	15, // 17: txpool.Mining.Mining:output_type -> txpool.MiningReply
-	9,  // [9:18] is the sub-list for method output_type
-	0,  // [0:9] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	18, // 0: txpool.SendBundleRequest.reverting_tx_hashes:type_name -> types.H256
+	18, // 1: txpool.SendBundleReply.hash:type_name -> types.H256
+	19, // 2: txpool.Mining.Version:input_type -> google.protobuf.Empty
+	0,  // 3: txpool.Mining.OnPendingBlock:input_type -> txpool.OnPendingBlockRequest
+	2,  // 4: txpool.Mining.OnMinedBlock:input_type -> txpool.OnMinedBlockRequest
+	4,  // 5: txpool.Mining.OnPendingLogs:input_type -> txpool.OnPendingLogsRequest
+	6,  // 6: txpool.Mining.GetWork:input_type -> txpool.GetWorkRequest
+	8,  // 7: txpool.Mining.SubmitWork:input_type -> txpool.SubmitWorkRequest
+	10, // 8: txpool.Mining.SubmitHashRate:input_type -> txpool.SubmitHashRateRequest
+	12, // 9: txpool.Mining.HashRate:input_type -> txpool.HashRateRequest
+	14, // 10: txpool.Mining.Mining:input_type -> txpool.MiningRequest
+	16, // 11: txpool.Mining.SendBundle:input_type -> txpool.SendBundleRequest
+	20, // 12: txpool.Mining.Version:output_type -> types.VersionReply
+	1,  // 13: txpool.Mining.OnPendingBlock:output_type -> txpool.OnPendingBlockReply
+	3,  // 14: txpool.Mining.OnMinedBlock:output_type -> txpool.OnMinedBlockReply
+	5,  // 15: txpool.Mining.OnPendingLogs:output_type -> txpool.OnPendingLogsReply
+	7,  // 16: txpool.Mining.GetWork:output_type -> txpool.GetWorkReply
+	9,  // 17: txpool.Mining.SubmitWork:output_type -> txpool.SubmitWorkReply
+	11, // 18: txpool.Mining.SubmitHashRate:output_type -> txpool.SubmitHashRateReply
+	13, // 19: txpool.Mining.HashRate:output_type -> txpool.HashRateReply
+	15, // 20: txpool.Mining.Mining:output_type -> txpool.MiningReply
+	17, // 21: txpool.Mining.SendBundle:output_type -> txpool.SendBundleReply
+	12, // [12:22] is the sub-list for method output_type
+	2,  // [2:12] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_txpool_mining_proto_init() }
@@ -857,7 +994,7 @@ func file_txpool_mining_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txpool_mining_proto_rawDesc), len(file_txpool_mining_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -30,6 +30,7 @@ const (
 	Mining_SubmitHashRate_FullMethodName = "/txpool.Mining/SubmitHashRate"
 	Mining_HashRate_FullMethodName       = "/txpool.Mining/HashRate"
 	Mining_Mining_FullMethodName         = "/txpool.Mining/Mining"
+	Mining_SendBundle_FullMethodName     = "/txpool.Mining/SendBundle"
 )
 
 // MiningClient is the client API for Mining service.
@@ -68,6 +69,8 @@ type MiningClient interface {
 	HashRate(ctx context.Context, in *HashRateRequest, opts ...grpc.CallOption) (*HashRateReply, error)
 	// Mining returns an indication if this node is currently mining and its mining configuration
 	Mining(ctx context.Context, in *MiningRequest, opts ...grpc.CallOption) (*MiningReply, error)
+	// SendBundle adds bundle of txns which block builder includes atomically and in order, or not at all
+	SendBundle(ctx context.Context, in *SendBundleRequest, opts ...grpc.CallOption) (*SendBundleReply, error)
 }
 
 type miningClient struct {
@@ -195,6 +198,16 @@ func (c *miningClient) Mining(ctx context.Context, in *MiningRequest, opts ...gr
 	return out, nil
 }
 
+func (c *miningClient) SendBundle(ctx context.Context, in *SendBundleRequest, opts ...grpc.CallOption) (*SendBundleReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendBundleReply)
+	err := c.cc.Invoke(ctx, Mining_SendBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MiningServer is the server API for Mining service.
 // All implementations must embed UnimplementedMiningServer
 // for forward compatibility.
@@ -231,6 +244,8 @@ type MiningServer interface {
 	HashRate(context.Context, *HashRateRequest) (*HashRateReply, error)
 	// Mining returns an indication if this node is currently mining and its mining configuration
 	Mining(context.Context, *MiningRequest) (*MiningReply, error)
+	// SendBundle adds bundle of txns which block builder includes atomically and in order, or not at all
+	SendBundle(context.Context, *SendBundleRequest) (*SendBundleReply, error)
 	mustEmbedUnimplementedMiningServer()
 }
 
@@ -268,6 +283,9 @@ func (UnimplementedMiningServer) HashRate(context.Context, *HashRateRequest) (*H
 func (UnimplementedMiningServer) Mining(context.Context, *MiningRequest) (*MiningReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Mining not implemented")
 }
+func (UnimplementedMiningServer) SendBundle(context.Context, *SendBundleRequest) (*SendBundleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendBundle not implemented")
+}
 func (UnimplementedMiningServer) mustEmbedUnimplementedMiningServer() {}
 func (UnimplementedMiningServer) testEmbeddedByValue()                {}
 
@@ -430,6 +448,24 @@ func _Mining_Mining_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mining_SendBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiningServer).SendBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mining_SendBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiningServer).SendBundle(ctx, req.(*SendBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Mining_ServiceDesc is the grpc.ServiceDesc for Mining service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -461,6 +497,10 @@ var Mining_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Mining",
 			Handler:    _Mining_Mining_Handler,
 		},
+		{
+			MethodName: "SendBundle",
+			Handler:    _Mining_SendBundle_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -894,6 +894,576 @@ func (x *GetBlobsReply) GetBlobsWithProofs() []*BlobAndProof {
 	return nil
 }
 
+type TxnStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          *typesproto.H256       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnStatusRequest) Reset() {
+	*x = TxnStatusRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnStatusRequest) ProtoMessage() {}
+
+func (x *TxnStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnStatusRequest.ProtoReflect.Descriptor instead.
+func (*TxnStatusRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TxnStatusRequest) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type TxnStatusReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubPool       string                 `protobuf:"bytes,1,opt,name=sub_pool,json=subPool,proto3" json:"sub_pool,omitempty"`                    // "Pending", "BaseFee" or "Queued". Empty if txn is not in pool
+	NotPending    []string               `protobuf:"bytes,2,rep,name=not_pending,json=notPending,proto3" json:"not_pending,omitempty"`           // why txn is not in Pending sub-pool: nonce gap, fee too low, balance, ...
+	DiscardReason uint32                 `protobuf:"varint,3,opt,name=discard_reason,json=discardReason,proto3" json:"discard_reason,omitempty"` // why txn was dropped from the pool (txpoolcfg.DiscardReason). 0 if txn was not dropped
+	DiscardedAt   uint64                 `protobuf:"varint,4,opt,name=discarded_at,json=discardedAt,proto3" json:"discarded_at,omitempty"`       // unix time in milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxnStatusReply) Reset() {
+	*x = TxnStatusReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnStatusReply) ProtoMessage() {}
+
+func (x *TxnStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnStatusReply.ProtoReflect.Descriptor instead.
+func (*TxnStatusReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TxnStatusReply) GetSubPool() string {
+	if x != nil {
+		return x.SubPool
+	}
+	return ""
+}
+
+func (x *TxnStatusReply) GetNotPending() []string {
+	if x != nil {
+		return x.NotPending
+	}
+	return nil
+}
+
+func (x *TxnStatusReply) GetDiscardReason() uint32 {
+	if x != nil {
+		return x.DiscardReason
+	}
+	return 0
+}
+
+func (x *TxnStatusReply) GetDiscardedAt() uint64 {
+	if x != nil {
+		return x.DiscardedAt
+	}
+	return 0
+}
+
+type StorageSlot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *typesproto.H256       `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         *typesproto.H256       `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageSlot) Reset() {
+	*x = StorageSlot{}
+	mi := &file_txpool_txpool_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageSlot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageSlot) ProtoMessage() {}
+
+func (x *StorageSlot) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageSlot.ProtoReflect.Descriptor instead.
+func (*StorageSlot) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StorageSlot) GetKey() *typesproto.H256 {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StorageSlot) GetValue() *typesproto.H256 {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type KnownAccount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *typesproto.H160       `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	StorageRoot   *typesproto.H256       `protobuf:"bytes,2,opt,name=storage_root,json=storageRoot,proto3" json:"storage_root,omitempty"` // not set if storage_slots are given
+	StorageSlots  []*StorageSlot         `protobuf:"bytes,3,rep,name=storage_slots,json=storageSlots,proto3" json:"storage_slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnownAccount) Reset() {
+	*x = KnownAccount{}
+	mi := &file_txpool_txpool_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownAccount) ProtoMessage() {}
+
+func (x *KnownAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnownAccount.ProtoReflect.Descriptor instead.
+func (*KnownAccount) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KnownAccount) GetAddress() *typesproto.H160 {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *KnownAccount) GetStorageRoot() *typesproto.H256 {
+	if x != nil {
+		return x.StorageRoot
+	}
+	return nil
+}
+
+func (x *KnownAccount) GetStorageSlots() []*StorageSlot {
+	if x != nil {
+		return x.StorageSlots
+	}
+	return nil
+}
+
+// conditions of eth_sendRawTransactionConditional (ERC-7796): txn can be included only into block on top of which all of them hold
+type TxnConditions struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	KnownAccounts  []*KnownAccount        `protobuf:"bytes,1,rep,name=known_accounts,json=knownAccounts,proto3" json:"known_accounts,omitempty"`
+	BlockNumberMin *uint64                `protobuf:"varint,2,opt,name=block_number_min,json=blockNumberMin,proto3,oneof" json:"block_number_min,omitempty"`
+	BlockNumberMax *uint64                `protobuf:"varint,3,opt,name=block_number_max,json=blockNumberMax,proto3,oneof" json:"block_number_max,omitempty"`
+	TimestampMin   *uint64                `protobuf:"varint,4,opt,name=timestamp_min,json=timestampMin,proto3,oneof" json:"timestamp_min,omitempty"`
+	TimestampMax   *uint64                `protobuf:"varint,5,opt,name=timestamp_max,json=timestampMax,proto3,oneof" json:"timestamp_max,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TxnConditions) Reset() {
+	*x = TxnConditions{}
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxnConditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnConditions) ProtoMessage() {}
+
+func (x *TxnConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnConditions.ProtoReflect.Descriptor instead.
+func (*TxnConditions) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TxnConditions) GetKnownAccounts() []*KnownAccount {
+	if x != nil {
+		return x.KnownAccounts
+	}
+	return nil
+}
+
+func (x *TxnConditions) GetBlockNumberMin() uint64 {
+	if x != nil && x.BlockNumberMin != nil {
+		return *x.BlockNumberMin
+	}
+	return 0
+}
+
+func (x *TxnConditions) GetBlockNumberMax() uint64 {
+	if x != nil && x.BlockNumberMax != nil {
+		return *x.BlockNumberMax
+	}
+	return 0
+}
+
+func (x *TxnConditions) GetTimestampMin() uint64 {
+	if x != nil && x.TimestampMin != nil {
+		return *x.TimestampMin
+	}
+	return 0
+}
+
+func (x *TxnConditions) GetTimestampMax() uint64 {
+	if x != nil && x.TimestampMax != nil {
+		return *x.TimestampMax
+	}
+	return 0
+}
+
+type AddConditionalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RlpTx         []byte                 `protobuf:"bytes,1,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	Conditions    *TxnConditions         `protobuf:"bytes,2,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddConditionalRequest) Reset() {
+	*x = AddConditionalRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddConditionalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddConditionalRequest) ProtoMessage() {}
+
+func (x *AddConditionalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddConditionalRequest.ProtoReflect.Descriptor instead.
+func (*AddConditionalRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddConditionalRequest) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *AddConditionalRequest) GetConditions() *TxnConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+type AddPrivateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RlpTx          []byte                 `protobuf:"bytes,1,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	MaxBlockNumber *uint64                `protobuf:"varint,2,opt,name=max_block_number,json=maxBlockNumber,proto3,oneof" json:"max_block_number,omitempty"` // txn is dropped if it's not included up to this block
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddPrivateRequest) Reset() {
+	*x = AddPrivateRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPrivateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrivateRequest) ProtoMessage() {}
+
+func (x *AddPrivateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrivateRequest.ProtoReflect.Descriptor instead.
+func (*AddPrivateRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddPrivateRequest) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *AddPrivateRequest) GetMaxBlockNumber() uint64 {
+	if x != nil && x.MaxBlockNumber != nil {
+		return *x.MaxBlockNumber
+	}
+	return 0
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{24}
+}
+
+// txn of pool as exported by txpool_export: to be imported into pool of other node (for example hot standby)
+type ExportedTxn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RlpTx         []byte                 `protobuf:"bytes,1,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"` // blob txns are wrapped with their sidecars
+	Sender        *typesproto.H160       `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	IsLocal       bool                   `protobuf:"varint,3,opt,name=is_local,json=isLocal,proto3" json:"is_local,omitempty"`
+	ArrivalBlock  uint64                 `protobuf:"varint,4,opt,name=arrival_block,json=arrivalBlock,proto3" json:"arrival_block,omitempty"` // number of head block when txn was added
+	Arrival       uint64                 `protobuf:"varint,5,opt,name=arrival,proto3" json:"arrival,omitempty"`                               // sequence number of arrival to the pool: first-come-first-served order of txns
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedTxn) Reset() {
+	*x = ExportedTxn{}
+	mi := &file_txpool_txpool_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedTxn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedTxn) ProtoMessage() {}
+
+func (x *ExportedTxn) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedTxn.ProtoReflect.Descriptor instead.
+func (*ExportedTxn) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportedTxn) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *ExportedTxn) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *ExportedTxn) GetIsLocal() bool {
+	if x != nil {
+		return x.IsLocal
+	}
+	return false
+}
+
+func (x *ExportedTxn) GetArrivalBlock() uint64 {
+	if x != nil {
+		return x.ArrivalBlock
+	}
+	return 0
+}
+
+func (x *ExportedTxn) GetArrival() uint64 {
+	if x != nil {
+		return x.Arrival
+	}
+	return 0
+}
+
+type ImportRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Txs            []*ExportedTxn         `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	TrustedSenders bool                   `protobuf:"varint,2,opt,name=trusted_senders,json=trustedSenders,proto3" json:"trusted_senders,omitempty"` // senders of export are taken as is, not recovered from signatures: only for exports of own nodes
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportRequest) GetTxs() []*ExportedTxn {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *ImportRequest) GetTrustedSenders() bool {
+	if x != nil {
+		return x.TrustedSenders
+	}
+	return false
+}
+
 type AllReply_Tx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TxnType       AllReply_TxnType       `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
@@ -905,7 +1475,7 @@ type AllReply_Tx struct {
 
 func (x *AllReply_Tx) Reset() {
 	*x = AllReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -917,7 +1487,7 @@ func (x *AllReply_Tx) String() string {
 func (*AllReply_Tx) ProtoMessage() {}
 
 func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,7 +1535,7 @@ type PendingReply_Tx struct {
 
 func (x *PendingReply_Tx) Reset() {
 	*x = PendingReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[18]
+	mi := &file_txpool_txpool_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -977,7 +1547,7 @@ func (x *PendingReply_Tx) String() string {
 func (*PendingReply_Tx) ProtoMessage() {}
 
 func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[18]
+	mi := &file_txpool_txpool_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,14 +1642,58 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\x04blob\x18\x01 \x01(\fR\x04blob\x12\x16\n" +
 	"\x06proofs\x18\x02 \x03(\fR\x06proofs\"Q\n" +
 	"\rGetBlobsReply\x12@\n" +
-	"\x11blobs_with_proofs\x18\x01 \x03(\v2\x14.txpool.BlobAndProofR\x0fblobsWithProofs*l\n" +
+	"\x11blobs_with_proofs\x18\x01 \x03(\v2\x14.txpool.BlobAndProofR\x0fblobsWithProofs\"3\n" +
+	"\x10TxnStatusRequest\x12\x1f\n" +
+	"\x04hash\x18\x01 \x01(\v2\v.types.H256R\x04hash\"\x96\x01\n" +
+	"\x0eTxnStatusReply\x12\x19\n" +
+	"\bsub_pool\x18\x01 \x01(\tR\asubPool\x12\x1f\n" +
+	"\vnot_pending\x18\x02 \x03(\tR\n" +
+	"notPending\x12%\n" +
+	"\x0ediscard_reason\x18\x03 \x01(\rR\rdiscardReason\x12!\n" +
+	"\fdiscarded_at\x18\x04 \x01(\x04R\vdiscardedAt\"O\n" +
+	"\vStorageSlot\x12\x1d\n" +
+	"\x03key\x18\x01 \x01(\v2\v.types.H256R\x03key\x12!\n" +
+	"\x05value\x18\x02 \x01(\v2\v.types.H256R\x05value\"\x9f\x01\n" +
+	"\fKnownAccount\x12%\n" +
+	"\aaddress\x18\x01 \x01(\v2\v.types.H160R\aaddress\x12.\n" +
+	"\fstorage_root\x18\x02 \x01(\v2\v.types.H256R\vstorageRoot\x128\n" +
+	"\rstorage_slots\x18\x03 \x03(\v2\x13.txpool.StorageSlotR\fstorageSlots\"\xcc\x02\n" +
+	"\rTxnConditions\x12;\n" +
+	"\x0eknown_accounts\x18\x01 \x03(\v2\x14.txpool.KnownAccountR\rknownAccounts\x12-\n" +
+	"\x10block_number_min\x18\x02 \x01(\x04H\x00R\x0eblockNumberMin\x88\x01\x01\x12-\n" +
+	"\x10block_number_max\x18\x03 \x01(\x04H\x01R\x0eblockNumberMax\x88\x01\x01\x12(\n" +
+	"\rtimestamp_min\x18\x04 \x01(\x04H\x02R\ftimestampMin\x88\x01\x01\x12(\n" +
+	"\rtimestamp_max\x18\x05 \x01(\x04H\x03R\ftimestampMax\x88\x01\x01B\x13\n" +
+	"\x11_block_number_minB\x13\n" +
+	"\x11_block_number_maxB\x10\n" +
+	"\x0e_timestamp_minB\x10\n" +
+	"\x0e_timestamp_max\"e\n" +
+	"\x15AddConditionalRequest\x12\x15\n" +
+	"\x06rlp_tx\x18\x01 \x01(\fR\x05rlpTx\x125\n" +
+	"\n" +
+	"conditions\x18\x02 \x01(\v2\x15.txpool.TxnConditionsR\n" +
+	"conditions\"n\n" +
+	"\x11AddPrivateRequest\x12\x15\n" +
+	"\x06rlp_tx\x18\x01 \x01(\fR\x05rlpTx\x12-\n" +
+	"\x10max_block_number\x18\x02 \x01(\x04H\x00R\x0emaxBlockNumber\x88\x01\x01B\x13\n" +
+	"\x11_max_block_number\"\x0f\n" +
+	"\rExportRequest\"\xa3\x01\n" +
+	"\vExportedTxn\x12\x15\n" +
+	"\x06rlp_tx\x18\x01 \x01(\fR\x05rlpTx\x12#\n" +
+	"\x06sender\x18\x02 \x01(\v2\v.types.H160R\x06sender\x12\x19\n" +
+	"\bis_local\x18\x03 \x01(\bR\aisLocal\x12#\n" +
+	"\rarrival_block\x18\x04 \x01(\x04R\farrivalBlock\x12\x18\n" +
+	"\aarrival\x18\x05 \x01(\x04R\aarrival\"_\n" +
+	"\rImportRequest\x12%\n" +
+	"\x03txs\x18\x01 \x03(\v2\x13.txpool.ExportedTxnR\x03txs\x12'\n" +
+	"\x0ftrusted_senders\x18\x02 \x01(\bR\x0etrustedSenders*l\n" +
 	"\fImportResult\x12\v\n" +
 	"\aSUCCESS\x10\x00\x12\x12\n" +
 	"\x0eALREADY_EXISTS\x10\x01\x12\x0f\n" +
 	"\vFEE_TOO_LOW\x10\x02\x12\t\n" +
 	"\x05STALE\x10\x03\x12\v\n" +
 	"\aINVALID\x10\x04\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\x052\xa8\x04\n" +
+	"\x0eINTERNAL_ERROR\x10\x052\xd0\x06\n" +
 	"\x06Txpool\x126\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x13.types.VersionReply\x121\n" +
 	"\vFindUnknown\x12\x10.txpool.TxHashes\x1a\x10.txpool.TxHashes\x12+\n" +
@@ -1090,7 +1704,13 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\x05OnAdd\x12\x14.txpool.OnAddRequest\x1a\x12.txpool.OnAddReply0\x01\x124\n" +
 	"\x06Status\x12\x15.txpool.StatusRequest\x1a\x13.txpool.StatusReply\x121\n" +
 	"\x05Nonce\x12\x14.txpool.NonceRequest\x1a\x12.txpool.NonceReply\x12:\n" +
-	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
+	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReply\x12=\n" +
+	"\tTxnStatus\x12\x18.txpool.TxnStatusRequest\x1a\x16.txpool.TxnStatusReply\x12A\n" +
+	"\x0eAddConditional\x12\x1d.txpool.AddConditionalRequest\x1a\x10.txpool.AddReply\x129\n" +
+	"\n" +
+	"AddPrivate\x12\x19.txpool.AddPrivateRequest\x1a\x10.txpool.AddReply\x126\n" +
+	"\x06Export\x12\x15.txpool.ExportRequest\x1a\x13.txpool.ExportedTxn0\x01\x121\n" +
+	"\x06Import\x12\x15.txpool.ImportRequest\x1a\x10.txpool.AddReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
 
 var (
 	file_txpool_txpool_proto_rawDescOnce sync.Once
@@ -1105,7 +1725,7 @@ func file_txpool_txpool_proto_rawDescGZIP() []byte {
 }
 
 var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_txpool_txpool_proto_goTypes = []any{
 	(ImportResult)(0),               // 0: txpool.ImportResult
 	(AllReply_TxnType)(0),           // 1: txpool.AllReply.TxnType
@@ -1126,50 +1746,80 @@ var file_txpool_txpool_proto_goTypes = []any{
 	(*GetBlobsRequest)(nil),         // 16: txpool.GetBlobsRequest
 	(*BlobAndProof)(nil),            // 17: txpool.BlobAndProof
 	(*GetBlobsReply)(nil),           // 18: txpool.GetBlobsReply
-	(*AllReply_Tx)(nil),             // 19: txpool.AllReply.Tx
-	(*PendingReply_Tx)(nil),         // 20: txpool.PendingReply.Tx
-	(*typesproto.H256)(nil),         // 21: types.H256
-	(*typesproto.H160)(nil),         // 22: types.H160
-	(*emptypb.Empty)(nil),           // 23: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 24: types.VersionReply
+	(*TxnStatusRequest)(nil),        // 19: txpool.TxnStatusRequest
+	(*TxnStatusReply)(nil),          // 20: txpool.TxnStatusReply
+	(*StorageSlot)(nil),             // 21: txpool.StorageSlot
+	(*KnownAccount)(nil),            // 22: txpool.KnownAccount
+	(*TxnConditions)(nil),           // 23: txpool.TxnConditions
+	(*AddConditionalRequest)(nil),   // 24: txpool.AddConditionalRequest
+	(*AddPrivateRequest)(nil),       // 25: txpool.AddPrivateRequest
+	(*ExportRequest)(nil),           // 26: txpool.ExportRequest
+	(*ExportedTxn)(nil),             // 27: txpool.ExportedTxn
+	(*ImportRequest)(nil),           // 28: txpool.ImportRequest
+	(*AllReply_Tx)(nil),             // 29: txpool.AllReply.Tx
+	(*PendingReply_Tx)(nil),         // 30: txpool.PendingReply.Tx
+	(*typesproto.H256)(nil),         // 31: types.H256
+	(*typesproto.H160)(nil),         // 32: types.H160
+	(*emptypb.Empty)(nil),           // 33: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 34: types.VersionReply
 }
 var file_txpool_txpool_proto_depIdxs = []int32{
-	21, // 0: txpool.TxHashes.hashes:type_name -> types.H256
+	31, // 0: txpool.TxHashes.hashes:type_name -> types.H256
 	0,  // 1: txpool.AddReply.imported:type_name -> txpool.ImportResult
-	21, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
-	19, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
-	20, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
-	22, // 5: txpool.NonceRequest.address:type_name -> types.H160
-	21, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
+	31, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
+	29, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
+	30, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
+	32, // 5: txpool.NonceRequest.address:type_name -> types.H160
+	31, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
 	17, // 7: txpool.GetBlobsReply.blobs_with_proofs:type_name -> txpool.BlobAndProof
-	1,  // 8: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
-	22, // 9: txpool.AllReply.Tx.sender:type_name -> types.H160
-	22, // 10: txpool.PendingReply.Tx.sender:type_name -> types.H160
-	23, // 11: txpool.Txpool.Version:input_type -> google.protobuf.Empty
-	2,  // 12: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
-	3,  // 13: txpool.Txpool.Add:input_type -> txpool.AddRequest
-	5,  // 14: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
-	9,  // 15: txpool.Txpool.All:input_type -> txpool.AllRequest
-	23, // 16: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
-	7,  // 17: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
-	12, // 18: txpool.Txpool.Status:input_type -> txpool.StatusRequest
-	14, // 19: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
-	16, // 20: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
-	24, // 21: txpool.Txpool.Version:output_type -> types.VersionReply
-	2,  // 22: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
-	4,  // 23: txpool.Txpool.Add:output_type -> txpool.AddReply
-	6,  // 24: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
-	10, // 25: txpool.Txpool.All:output_type -> txpool.AllReply
-	11, // 26: txpool.Txpool.Pending:output_type -> txpool.PendingReply
-	8,  // 27: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
-	13, // 28: txpool.Txpool.Status:output_type -> txpool.StatusReply
-	15, // 29: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
-	18, // 30: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
-	21, // [21:31] is the sub-list for method output_type
-	11, // [11:21] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	31, // 8: txpool.TxnStatusRequest.hash:type_name -> types.H256
+	31, // 9: txpool.StorageSlot.key:type_name -> types.H256
+	31, // 10: txpool.StorageSlot.value:type_name -> types.H256
+	32, // 11: txpool.KnownAccount.address:type_name -> types.H160
+	31, // 12: txpool.KnownAccount.storage_root:type_name -> types.H256
+	21, // 13: txpool.KnownAccount.storage_slots:type_name -> txpool.StorageSlot
+	22, // 14: txpool.TxnConditions.known_accounts:type_name -> txpool.KnownAccount
+	23, // 15: txpool.AddConditionalRequest.conditions:type_name -> txpool.TxnConditions
+	32, // 16: txpool.ExportedTxn.sender:type_name -> types.H160
+	27, // 17: txpool.ImportRequest.txs:type_name -> txpool.ExportedTxn
+	1,  // 18: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	32, // 19: txpool.AllReply.Tx.sender:type_name -> types.H160
+	32, // 20: txpool.PendingReply.Tx.sender:type_name -> types.H160
+	33, // 21: txpool.Txpool.Version:input_type -> google.protobuf.Empty
+	2,  // 22: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
+	3,  // 23: txpool.Txpool.Add:input_type -> txpool.AddRequest
+	5,  // 24: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
+	9,  // 25: txpool.Txpool.All:input_type -> txpool.AllRequest
+	33, // 26: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
+	7,  // 27: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
+	12, // 28: txpool.Txpool.Status:input_type -> txpool.StatusRequest
+	14, // 29: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
+	16, // 30: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
+	19, // 31: txpool.Txpool.TxnStatus:input_type -> txpool.TxnStatusRequest
+	24, // 32: txpool.Txpool.AddConditional:input_type -> txpool.AddConditionalRequest
+	25, // 33: txpool.Txpool.AddPrivate:input_type -> txpool.AddPrivateRequest
+	26, // 34: txpool.Txpool.Export:input_type -> txpool.ExportRequest
+	28, // 35: txpool.Txpool.Import:input_type -> txpool.ImportRequest
+	34, // 36: txpool.Txpool.Version:output_type -> types.VersionReply
+	2,  // 37: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
+	4,  // 38: txpool.Txpool.Add:output_type -> txpool.AddReply
+	6,  // 39: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
+	10, // 40: txpool.Txpool.All:output_type -> txpool.AllReply
+	11, // 41: txpool.Txpool.Pending:output_type -> txpool.PendingReply
+	8,  // 42: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
+	13, // 43: txpool.Txpool.Status:output_type -> txpool.StatusReply
+	15, // 44: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
+	18, // 45: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
+	20, // 46: txpool.Txpool.TxnStatus:output_type -> txpool.TxnStatusReply
+	4,  // 47: txpool.Txpool.AddConditional:output_type -> txpool.AddReply
+	4,  // 48: txpool.Txpool.AddPrivate:output_type -> txpool.AddReply
+	27, // 49: txpool.Txpool.Export:output_type -> txpool.ExportedTxn
+	4,  // 50: txpool.Txpool.Import:output_type -> txpool.AddReply
+	36, // [36:51] is the sub-list for method output_type
+	21, // [21:36] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_txpool_txpool_proto_init() }
@@ -1177,13 +1827,15 @@ func file_txpool_txpool_proto_init() {
 	if File_txpool_txpool_proto != nil {
 		return
 	}
+	file_txpool_txpool_proto_msgTypes[21].OneofWrappers = []any{}
+	file_txpool_txpool_proto_msgTypes[23].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txpool_txpool_proto_rawDesc), len(file_txpool_txpool_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   19,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
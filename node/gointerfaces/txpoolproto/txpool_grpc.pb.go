@@ -21,16 +21,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Txpool_Version_FullMethodName      = "/txpool.Txpool/Version"
-	Txpool_FindUnknown_FullMethodName  = "/txpool.Txpool/FindUnknown"
-	Txpool_Add_FullMethodName          = "/txpool.Txpool/Add"
-	Txpool_Transactions_FullMethodName = "/txpool.Txpool/Transactions"
-	Txpool_All_FullMethodName          = "/txpool.Txpool/All"
-	Txpool_Pending_FullMethodName      = "/txpool.Txpool/Pending"
-	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
-	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
-	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
-	Txpool_GetBlobs_FullMethodName     = "/txpool.Txpool/GetBlobs"
+	Txpool_Version_FullMethodName        = "/txpool.Txpool/Version"
+	Txpool_FindUnknown_FullMethodName    = "/txpool.Txpool/FindUnknown"
+	Txpool_Add_FullMethodName            = "/txpool.Txpool/Add"
+	Txpool_Transactions_FullMethodName   = "/txpool.Txpool/Transactions"
+	Txpool_All_FullMethodName            = "/txpool.Txpool/All"
+	Txpool_Pending_FullMethodName        = "/txpool.Txpool/Pending"
+	Txpool_OnAdd_FullMethodName          = "/txpool.Txpool/OnAdd"
+	Txpool_Status_FullMethodName         = "/txpool.Txpool/Status"
+	Txpool_Nonce_FullMethodName          = "/txpool.Txpool/Nonce"
+	Txpool_GetBlobs_FullMethodName       = "/txpool.Txpool/GetBlobs"
+	Txpool_TxnStatus_FullMethodName      = "/txpool.Txpool/TxnStatus"
+	Txpool_AddConditional_FullMethodName = "/txpool.Txpool/AddConditional"
+	Txpool_AddPrivate_FullMethodName     = "/txpool.Txpool/AddPrivate"
+	Txpool_Export_FullMethodName         = "/txpool.Txpool/Export"
+	Txpool_Import_FullMethodName         = "/txpool.Txpool/Import"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -58,6 +63,16 @@ type TxpoolClient interface {
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
+	// returns sub-pool of transaction and why it's not pending, or why and when it was dropped from the pool
+	TxnStatus(ctx context.Context, in *TxnStatusRequest, opts ...grpc.CallOption) (*TxnStatusReply, error)
+	// Adding signed transaction as local, which can be included only while conditions hold. It's not gossiped to peers
+	AddConditional(ctx context.Context, in *AddConditionalRequest, opts ...grpc.CallOption) (*AddReply, error)
+	// Adding signed transaction as local, which is offered only to local block builder. It's not gossiped to peers
+	AddPrivate(ctx context.Context, in *AddPrivateRequest, opts ...grpc.CallOption) (*AddReply, error)
+	// streams all txns of pool, except ones kept only by this node
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportedTxn], error)
+	// adds txns exported by other node: local ones as local, remote ones as if received from peers. Preserves incoming order and amount
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*AddReply, error)
 }
 
 type txpoolClient struct {
@@ -177,6 +192,65 @@ func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts .
 	return out, nil
 }
 
+func (c *txpoolClient) TxnStatus(ctx context.Context, in *TxnStatusRequest, opts ...grpc.CallOption) (*TxnStatusReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxnStatusReply)
+	err := c.cc.Invoke(ctx, Txpool_TxnStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) AddConditional(ctx context.Context, in *AddConditionalRequest, opts ...grpc.CallOption) (*AddReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReply)
+	err := c.cc.Invoke(ctx, Txpool_AddConditional_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) AddPrivate(ctx context.Context, in *AddPrivateRequest, opts ...grpc.CallOption) (*AddReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReply)
+	err := c.cc.Invoke(ctx, Txpool_AddPrivate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportedTxn], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[1], Txpool_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportedTxn]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ExportClient = grpc.ServerStreamingClient[ExportedTxn]
+
+func (c *txpoolClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*AddReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReply)
+	err := c.cc.Invoke(ctx, Txpool_Import_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility.
@@ -202,6 +276,16 @@ type TxpoolServer interface {
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
+	// returns sub-pool of transaction and why it's not pending, or why and when it was dropped from the pool
+	TxnStatus(context.Context, *TxnStatusRequest) (*TxnStatusReply, error)
+	// Adding signed transaction as local, which can be included only while conditions hold. It's not gossiped to peers
+	AddConditional(context.Context, *AddConditionalRequest) (*AddReply, error)
+	// Adding signed transaction as local, which is offered only to local block builder. It's not gossiped to peers
+	AddPrivate(context.Context, *AddPrivateRequest) (*AddReply, error)
+	// streams all txns of pool, except ones kept only by this node
+	Export(*ExportRequest, grpc.ServerStreamingServer[ExportedTxn]) error
+	// adds txns exported by other node: local ones as local, remote ones as if received from peers. Preserves incoming order and amount
+	Import(context.Context, *ImportRequest) (*AddReply, error)
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -242,6 +326,21 @@ func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceRe
 func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
 }
+func (UnimplementedTxpoolServer) TxnStatus(context.Context, *TxnStatusRequest) (*TxnStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxnStatus not implemented")
+}
+func (UnimplementedTxpoolServer) AddConditional(context.Context, *AddConditionalRequest) (*AddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddConditional not implemented")
+}
+func (UnimplementedTxpoolServer) AddPrivate(context.Context, *AddPrivateRequest) (*AddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrivate not implemented")
+}
+func (UnimplementedTxpoolServer) Export(*ExportRequest, grpc.ServerStreamingServer[ExportedTxn]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedTxpoolServer) Import(context.Context, *ImportRequest) (*AddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 func (UnimplementedTxpoolServer) testEmbeddedByValue()                {}
 
@@ -436,6 +535,89 @@ func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_TxnStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).TxnStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_TxnStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).TxnStatus(ctx, req.(*TxnStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_AddConditional_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddConditionalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).AddConditional(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_AddConditional_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).AddConditional(ctx, req.(*AddConditionalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_AddPrivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPrivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).AddPrivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_AddPrivate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).AddPrivate(ctx, req.(*AddPrivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxpoolServer).Export(m, &grpc.GenericServerStream[ExportRequest, ExportedTxn]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_ExportServer = grpc.ServerStreamingServer[ExportedTxn]
+
+func _Txpool_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Import_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -479,6 +661,22 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobs",
 			Handler:    _Txpool_GetBlobs_Handler,
 		},
+		{
+			MethodName: "TxnStatus",
+			Handler:    _Txpool_TxnStatus_Handler,
+		},
+		{
+			MethodName: "AddConditional",
+			Handler:    _Txpool_AddConditional_Handler,
+		},
+		{
+			MethodName: "AddPrivate",
+			Handler:    _Txpool_AddPrivate_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _Txpool_Import_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -486,6 +684,11 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Txpool_OnAdd_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Export",
+			Handler:       _Txpool_Export_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "txpool/txpool.proto",
 }
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -30,8 +31,10 @@ import (
 	"github.com/erigontech/erigon/execution/protocol/rules/ethash"
 	"github.com/erigontech/erigon/execution/rlp"
 	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/node/gointerfaces"
 	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon/node/gointerfaces/typesproto"
+	"github.com/erigontech/erigon/txnprovider/bundle"
 )
 
 // MiningAPIVersion
@@ -46,6 +49,7 @@ type MiningServer struct {
 	minedBlockStreams   MinedBlockStreams
 	ethash              *ethash.API
 	isMining            IsMining
+	bundles             *bundle.Pool // nil if node doesn't build blocks
 	logger              log.Logger
 }
 
@@ -53,8 +57,8 @@ type IsMining interface {
 	IsMining() bool
 }
 
-func NewMiningServer(ctx context.Context, isMining IsMining, ethashApi *ethash.API, logger log.Logger) *MiningServer {
-	return &MiningServer{ctx: ctx, isMining: isMining, ethash: ethashApi, logger: logger}
+func NewMiningServer(ctx context.Context, isMining IsMining, ethashApi *ethash.API, bundles *bundle.Pool, logger log.Logger) *MiningServer {
+	return &MiningServer{ctx: ctx, isMining: isMining, ethash: ethashApi, bundles: bundles, logger: logger}
 }
 
 func (s *MiningServer) Version(context.Context, *emptypb.Empty) (*typesproto.VersionReply, error) {
@@ -104,6 +108,28 @@ func (s *MiningServer) Mining(_ context.Context, req *txpoolproto.MiningRequest)
 	return &txpoolproto.MiningReply{Enabled: s.isMining.IsMining(), Running: true}, nil
 }
 
+func (s *MiningServer) SendBundle(_ context.Context, req *txpoolproto.SendBundleRequest) (*txpoolproto.SendBundleReply, error) {
+	if s.bundles == nil {
+		return nil, bundle.ErrNotSupported
+	}
+	b := &bundle.Bundle{BlockNumber: req.BlockNumber, MinTimestamp: req.MinTimestamp, MaxTimestamp: req.MaxTimestamp}
+	for i, rlpTxn := range req.Txs {
+		txn, err := types.DecodeWrappedTransaction(rlpTxn)
+		if err != nil {
+			return nil, fmt.Errorf("txn %d: %w", i, err)
+		}
+		b.Txns = append(b.Txns, txn)
+	}
+	for _, h := range req.RevertingTxHashes {
+		b.RevertingTxHashes = append(b.RevertingTxHashes, gointerfaces.ConvertH256ToHash(h))
+	}
+	hash, err := s.bundles.Add(b)
+	if err != nil {
+		return nil, err
+	}
+	return &txpoolproto.SendBundleReply{Hash: gointerfaces.ConvertHashToH256(hash)}, nil
+}
+
 func (s *MiningServer) OnPendingLogs(req *txpoolproto.OnPendingLogsRequest, reply txpoolproto.Mining_OnPendingLogsServer) error {
 	remove := s.pendingLogsStreams.Add(reply)
 	defer remove()
@@ -0,0 +1,361 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package events implements declarative Otterscan event indexers.
+//
+// An Event entry describes a log signature, the field to key the index on and an
+// optional list of emitting contracts. Each registered event gets its own stage,
+// index/counter tables and is served by ots2_getEvents, so adding a new index
+// doesn't require writing a stage, an unwinder and an RPC by hand: operators
+// list extra entries in a config file (see LoadFile).
+package events
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/abi"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// EmitterKey is the Event.Key value which keys the index on the contract
+// emitting the log instead of one of its parameters.
+const EmitterKey = "address"
+
+// Event is the configuration entry of an event indexer.
+type Event struct {
+	// Name identifies the indexer; it is part of table and stage names, so it
+	// must be alphanumeric.
+	Name string `toml:"name"`
+
+	// Signature is the solidity event declaration, including the indexed
+	// modifiers and parameter names, e.g.:
+	//
+	//	Transfer(address indexed from, address indexed to, uint256 value)
+	Signature string `toml:"signature"`
+
+	// Key is either EmitterKey or the name of an indexed address parameter.
+	Key string `toml:"key"`
+
+	// Contracts optionally restricts the indexer to logs emitted by those
+	// addresses.
+	Contracts []common.Address `toml:"contracts"`
+}
+
+// Definition is a validated Event, ready to match logs.
+type Definition struct {
+	Event
+
+	// Topic0 is the keccak256 hash of the canonical signature
+	Topic0 common.Hash
+
+	// Inputs are the event parameters, used to decode matched logs
+	Inputs abi.Arguments
+
+	keyTopic  int // 0 == emitter, otherwise topic position of the key
+	topics    int // expected amount of topics, including topic0
+	contracts map[common.Address]struct{}
+}
+
+// Compile parses and validates an Event.
+func Compile(e Event) (*Definition, error) {
+	if e.Name == "" || strings.IndexFunc(e.Name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) >= 0 {
+		return nil, fmt.Errorf("invalid event indexer name %q: must be alphanumeric", e.Name)
+	}
+
+	name, inputs, err := parseSignature(e.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("event indexer %s: %w", e.Name, err)
+	}
+
+	typeNames := make([]string, len(inputs))
+	for i, in := range inputs {
+		typeNames[i] = in.Type.String()
+	}
+	d := &Definition{
+		Event:  e,
+		Topic0: crypto.Keccak256Hash([]byte(name + "(" + strings.Join(typeNames, ",") + ")")),
+		Inputs: inputs,
+		topics: 1,
+	}
+
+	for _, in := range inputs {
+		if !in.Indexed {
+			continue
+		}
+		if in.Name == e.Key {
+			if in.Type.T != abi.AddressTy {
+				return nil, fmt.Errorf("event indexer %s: key %s must be an address, got %s", e.Name, e.Key, in.Type)
+			}
+			d.keyTopic = d.topics
+		}
+		d.topics++
+	}
+	if d.topics > 4 {
+		return nil, fmt.Errorf("event indexer %s: too many indexed parameters", e.Name)
+	}
+	if d.keyTopic == 0 && e.Key != EmitterKey {
+		return nil, fmt.Errorf("event indexer %s: key %q is neither %q nor an indexed parameter", e.Name, e.Key, EmitterKey)
+	}
+
+	if len(e.Contracts) > 0 {
+		d.contracts = make(map[common.Address]struct{}, len(e.Contracts))
+		for _, c := range e.Contracts {
+			d.contracts[c] = struct{}{}
+		}
+	}
+
+	return d, nil
+}
+
+// parseSignature splits "Name(type [indexed] [name], ...)" into its name and
+// arguments; tuples aren't supported.
+func parseSignature(sig string) (string, abi.Arguments, error) {
+	sig = strings.TrimSpace(sig)
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("malformed signature %q", sig)
+	}
+	name := strings.TrimSpace(sig[:open])
+	body := sig[open+1 : len(sig)-1]
+	if strings.ContainsAny(body, "()") {
+		return "", nil, fmt.Errorf("tuple parameters aren't supported: %q", sig)
+	}
+
+	inputs := abi.Arguments{}
+	if strings.TrimSpace(body) == "" {
+		return name, inputs, nil
+	}
+	for i, param := range strings.Split(body, ",") {
+		fields := strings.Fields(param)
+		if len(fields) == 0 {
+			return "", nil, fmt.Errorf("empty parameter %d in %q", i, sig)
+		}
+
+		typ, err := abi.NewType(fields[0], "", nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("parameter %d: %w", i, err)
+		}
+		arg := abi.Argument{Type: typ}
+		fields = fields[1:]
+		if len(fields) > 0 && fields[0] == "indexed" {
+			arg.Indexed = true
+			fields = fields[1:]
+		}
+		switch len(fields) {
+		case 0:
+			arg.Name = fmt.Sprintf("arg%d", i)
+		case 1:
+			arg.Name = fields[0]
+		default:
+			return "", nil, fmt.Errorf("malformed parameter %q", strings.TrimSpace(param))
+		}
+		inputs = append(inputs, arg)
+	}
+
+	return name, inputs, nil
+}
+
+// StageID is the sync stage which runs this indexer.
+func (d *Definition) StageID() stages.SyncStage {
+	return stages.SyncStage("OtsEvent" + d.Name)
+}
+
+// IndexTable stores key + chunk -> txNums of the matched logs.
+func (d *Definition) IndexTable() string {
+	return "OtsEvent" + d.Name + "Index"
+}
+
+// CounterTable stores the pagination counters of IndexTable (dupsort).
+func (d *Definition) CounterTable() string {
+	return "OtsEvent" + d.Name + "Counter"
+}
+
+// Match returns the index key of l and whether l is an occurrence of this
+// event.
+func (d *Definition) Match(l *types.Log) (common.Address, bool) {
+	if len(l.Topics) != d.topics || l.Topics[0] != d.Topic0 {
+		return common.Address{}, false
+	}
+	if d.contracts != nil {
+		if _, ok := d.contracts[l.Address]; !ok {
+			return common.Address{}, false
+		}
+	}
+	if d.keyTopic == 0 {
+		return l.Address, true
+	}
+	return common.BytesToAddress(l.Topics[d.keyTopic][12:]), true
+}
+
+// Decode unpacks all the parameters of a matched log by name; indexed dynamic
+// types are returned as their topic hash.
+func (d *Definition) Decode(l *types.Log) (map[string]any, error) {
+	var indexed abi.Arguments
+	for _, in := range d.Inputs {
+		if in.Indexed {
+			indexed = append(indexed, in)
+		}
+	}
+
+	ret := make(map[string]any, len(d.Inputs))
+	if err := abi.ParseTopicsIntoMap(ret, indexed, l.Topics[1:]); err != nil {
+		return nil, err
+	}
+	if err := d.Inputs.NonIndexed().UnpackIntoMap(ret, l.Data); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     []*Definition
+)
+
+// Register compiles an event indexer and adds its tables to the chaindata
+// schema. It must be called before the chaindata DB is opened.
+func Register(e Event) (*Definition, error) {
+	d, err := Compile(e)
+	if err != nil {
+		return nil, err
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if slices.ContainsFunc(registry, func(r *Definition) bool { return r.Name == d.Name }) {
+		return nil, fmt.Errorf("event indexer %s already registered", d.Name)
+	}
+	kv.RegisterChaindataTable(d.IndexTable(), kv.TableCfgItem{})
+	kv.RegisterChaindataTable(d.CounterTable(), kv.TableCfgItem{Flags: kv.DupSort})
+	registry = append(registry, d)
+
+	return d, nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(e Event) *Definition {
+	d, err := Register(e)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// LoadFile reads event indexers from a TOML config file, one [[event]] table
+// per indexer:
+//
+//	[[event]]
+//	name = "USDCReceived"
+//	signature = "Transfer(address indexed from, address indexed to, uint256 value)"
+//	key = "to"
+//	contracts = ["0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"]
+func LoadFile(path string) ([]Event, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Event []Event `toml:"event"`
+	}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("event indexers config %s: %w", path, err)
+	}
+	return cfg.Event, nil
+}
+
+// Enable registers the built-in event indexers and those of configFile, if
+// not empty. It's only called when OTS v2 indexers are enabled, before the
+// chaindata DB is opened: other nodes don't get the indexer tables.
+func Enable(configFile string) error {
+	entries := Builtin
+	if configFile != "" {
+		loaded, err := LoadFile(configFile)
+		if err != nil {
+			return err
+		}
+		entries = append(slices.Clone(Builtin), loaded...)
+	}
+	// validate all entries first, so a bad one doesn't leave others registered
+	names := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if _, err := Compile(e); err != nil {
+			return err
+		}
+		if _, ok := names[e.Name]; ok {
+			return fmt.Errorf("event indexer %s declared twice", e.Name)
+		}
+		names[e.Name] = struct{}{}
+	}
+	for _, e := range entries {
+		if _, err := Register(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var ErrUnknownEvent = errors.New("unknown event indexer")
+
+// Get returns the registered event indexer with the given name.
+func Get(name string) (*Definition, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for _, d := range registry {
+		if d.Name == name {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+}
+
+// All returns the registered event indexers in registration order.
+func All() []*Definition {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return slices.Clone(registry)
+}
+
+// Built-in event indexers, registered by Enable
+var (
+	// Uniswap V2 swaps per pool
+	UniswapV2Swap = Event{
+		Name:      "UniswapV2Swap",
+		Signature: "Swap(address indexed sender, uint256 amount0In, uint256 amount1In, uint256 amount0Out, uint256 amount1Out, address indexed to)",
+		Key:       EmitterKey,
+	}
+
+	// ENS .eth registrations per owner (ETHRegistrarController)
+	ENSNameRegistered = Event{
+		Name:      "ENSNameRegistered",
+		Signature: "NameRegistered(string name, bytes32 indexed label, address indexed owner, uint256 baseCost, uint256 premium, uint256 expires)",
+		Key:       "owner",
+	}
+
+	Builtin = []Event{UniswapV2Swap, ENSNameRegistered}
+)
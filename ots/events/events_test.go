@@ -0,0 +1,142 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package events
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+const transferSig = "Transfer(address indexed from, address indexed to, uint256 value)"
+
+var transferTopic0 = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+func transferLog(token, from, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{transferTopic0, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+		Data:    common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func TestCompile(t *testing.T) {
+	token := common.HexToAddress("0x1000")
+	from := common.HexToAddress("0x2000")
+	to := common.HexToAddress("0x3000")
+
+	byEmitter, err := Compile(Event{Name: "Transfers", Signature: transferSig, Key: EmitterKey})
+	require.NoError(t, err)
+	require.Equal(t, transferTopic0, byEmitter.Topic0)
+	key, ok := byEmitter.Match(transferLog(token, from, to, 1))
+	require.True(t, ok)
+	require.Equal(t, token, key)
+
+	byRecipient, err := Compile(Event{Name: "Received", Signature: transferSig, Key: "to"})
+	require.NoError(t, err)
+	key, ok = byRecipient.Match(transferLog(token, from, to, 1))
+	require.True(t, ok)
+	require.Equal(t, to, key)
+
+	// ERC721 transfers have the same topic0, but 4 topics
+	l := transferLog(token, from, to, 1)
+	l.Topics = append(l.Topics, common.Hash{})
+	_, ok = byRecipient.Match(l)
+	require.False(t, ok)
+
+	filtered, err := Compile(Event{Name: "Filtered", Signature: transferSig, Key: "from", Contracts: []common.Address{token}})
+	require.NoError(t, err)
+	_, ok = filtered.Match(transferLog(token, from, to, 1))
+	require.True(t, ok)
+	_, ok = filtered.Match(transferLog(common.HexToAddress("0x1001"), from, to, 1))
+	require.False(t, ok)
+
+	fields, err := byEmitter.Decode(transferLog(token, from, to, 42))
+	require.NoError(t, err)
+	require.Equal(t, from, fields["from"])
+	require.Equal(t, to, fields["to"])
+	require.Equal(t, big.NewInt(42), fields["value"])
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, e := range []Event{
+		{Name: "", Signature: transferSig, Key: EmitterKey},
+		{Name: "Bad_Name", Signature: transferSig, Key: EmitterKey},
+		{Name: "NoParens", Signature: "Transfer", Key: EmitterKey},
+		{Name: "BadType", Signature: "Transfer(adress indexed from)", Key: EmitterKey},
+		{Name: "Tuple", Signature: "Transfer((address,uint256) indexed x)", Key: EmitterKey},
+		{Name: "NotIndexed", Signature: "Transfer(address indexed from, address to, uint256 value)", Key: "to"},
+		{Name: "NotAddress", Signature: "Transfer(address indexed from, uint256 indexed value)", Key: "value"},
+		{Name: "Unknown", Signature: transferSig, Key: "owner"},
+		{Name: "TooManyTopics", Signature: "E(address indexed a, address indexed b, address indexed c, address indexed d)", Key: "a"},
+	} {
+		_, err := Compile(e)
+		require.Error(t, err, e.Name)
+	}
+}
+
+func TestEnable(t *testing.T) {
+	// nothing is registered unless OTS v2 indexers are enabled
+	require.Empty(t, All())
+	require.NotContains(t, kv.ChaindataTables, "OtsEvent"+UniswapV2Swap.Name+"Index")
+
+	dir := t.TempDir()
+	token := common.HexToAddress("0x1000")
+	config := filepath.Join(dir, "events.toml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+[[event]]
+name = "TokenReceived"
+signature = "`+transferSig+`"
+key = "to"
+contracts = ["`+token.Hex()+`"]
+`), 0o644))
+	loaded, err := LoadFile(config)
+	require.NoError(t, err)
+	require.Equal(t, []Event{{Name: "TokenReceived", Signature: transferSig, Key: "to", Contracts: []common.Address{token}}}, loaded)
+
+	// a bad entry registers nothing
+	bad := filepath.Join(dir, "bad.toml")
+	require.NoError(t, os.WriteFile(bad, []byte("[[event]]\nname = \"Bad\"\nsignature = \"Transfer\"\nkey = \"address\"\n"), 0o644))
+	require.Error(t, Enable(bad))
+	require.Empty(t, All())
+
+	require.NoError(t, Enable(config))
+	require.Len(t, All(), len(Builtin)+1)
+	d, err := Get(UniswapV2Swap.Name)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822"), d.Topic0)
+	d, err = Get("TokenReceived")
+	require.NoError(t, err)
+	key, ok := d.Match(transferLog(token, common.HexToAddress("0x2000"), common.HexToAddress("0x3000"), 1))
+	require.True(t, ok)
+	require.Equal(t, common.HexToAddress("0x3000"), key)
+
+	require.Contains(t, kv.ChaindataTables, d.IndexTable())
+	require.Equal(t, kv.DupSort, kv.ChaindataTablesCfg[d.CounterTable()].Flags)
+
+	_, err = Register(Event{Name: UniswapV2Swap.Name, Signature: transferSig, Key: EmitterKey})
+	require.Error(t, err)
+	_, err = Get("Missing")
+	require.ErrorIs(t, err, ErrUnknownEvent)
+}
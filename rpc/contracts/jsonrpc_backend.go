@@ -71,6 +71,26 @@ func (b JsonRpcBackend) SendTransaction(ctx context.Context, txn types.Transacti
 	return err
 }
 
+func (b JsonRpcBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return b.client.BlockNumber()
+}
+
+func (b JsonRpcBackend) BalanceAt(ctx context.Context, account common.Address, blockNum *big.Int) (*big.Int, error) {
+	return b.client.GetBalance(account, rpc.BlockReference(BlockNumArg(blockNum)))
+}
+
+// TransactionReceipt - returns nil receipt for not yet mined txn, which makes JsonRpcBackend usable as bind.DeployBackend
+func (b JsonRpcBackend) TransactionReceipt(ctx context.Context, txnHash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.client.GetTransactionReceipt(ctx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.TxHash != txnHash {
+		return nil, nil // null result
+	}
+	return receipt, nil
+}
+
 func (b JsonRpcBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
 	return b.client.FilterLogs(ctx, query)
 }
@@ -46,6 +46,8 @@ const (
 	ErrCodeInvalidParams           = -32602
 	ErrCodeReverted                = -32000
 	ErrCodeVMError                 = -32015
+	ErrCodeConditionsNotMet        = -32003 // ERC-7796: eth_sendRawTransactionConditional
+	ErrCodeConditionsLimitExceeded = -32005 // ERC-7796: eth_sendRawTransactionConditional
 )
 
 const defaultErrorCode = ErrCodeReverted
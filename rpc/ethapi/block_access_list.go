@@ -0,0 +1,104 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// RPCAccountChanges is the JSON form of an EIP-7928 block access list entry
+type RPCAccountChanges struct {
+	Address        common.Address     `json:"address"`
+	StorageChanges []RPCSlotChanges   `json:"storageChanges"`
+	StorageReads   []common.Hash      `json:"storageReads"`
+	BalanceChanges []RPCBalanceChange `json:"balanceChanges"`
+	NonceChanges   []RPCNonceChange   `json:"nonceChanges"`
+	CodeChanges    []RPCCodeChange    `json:"codeChanges"`
+}
+
+type RPCSlotChanges struct {
+	Slot        common.Hash        `json:"slot"`
+	SlotChanges []RPCStorageChange `json:"slotChanges"`
+}
+
+type RPCStorageChange struct {
+	BlockAccessIndex hexutil.Uint64 `json:"blockAccessIndex"`
+	PostValue        common.Hash    `json:"postValue"`
+}
+
+type RPCBalanceChange struct {
+	BlockAccessIndex hexutil.Uint64 `json:"blockAccessIndex"`
+	PostBalance      *hexutil.Big   `json:"postBalance"`
+}
+
+type RPCNonceChange struct {
+	BlockAccessIndex hexutil.Uint64 `json:"blockAccessIndex"`
+	PostNonce        hexutil.Uint64 `json:"postNonce"`
+}
+
+type RPCCodeChange struct {
+	BlockAccessIndex hexutil.Uint64 `json:"blockAccessIndex"`
+	NewCode          hexutil.Bytes  `json:"newCode"`
+}
+
+// RPCMarshalBlockAccessList converts a block access list into its EIP-7928 JSON form
+func RPCMarshalBlockAccessList(bal types.BlockAccessList) []*RPCAccountChanges {
+	out := make([]*RPCAccountChanges, 0, len(bal))
+	for _, account := range bal {
+		changes := &RPCAccountChanges{
+			Address:        account.Address,
+			StorageChanges: make([]RPCSlotChanges, 0, len(account.StorageChanges)),
+			StorageReads:   make([]common.Hash, 0, len(account.StorageReads)),
+			BalanceChanges: make([]RPCBalanceChange, 0, len(account.BalanceChanges)),
+			NonceChanges:   make([]RPCNonceChange, 0, len(account.NonceChanges)),
+			CodeChanges:    make([]RPCCodeChange, 0, len(account.CodeChanges)),
+		}
+		for _, slot := range account.StorageChanges {
+			slotChanges := RPCSlotChanges{Slot: slot.Slot, SlotChanges: make([]RPCStorageChange, 0, len(slot.Changes))}
+			for _, change := range slot.Changes {
+				slotChanges.SlotChanges = append(slotChanges.SlotChanges, RPCStorageChange{
+					BlockAccessIndex: hexutil.Uint64(change.Index),
+					PostValue:        change.Value.Bytes32(),
+				})
+			}
+			changes.StorageChanges = append(changes.StorageChanges, slotChanges)
+		}
+		changes.StorageReads = append(changes.StorageReads, account.StorageReads...)
+		for _, change := range account.BalanceChanges {
+			changes.BalanceChanges = append(changes.BalanceChanges, RPCBalanceChange{
+				BlockAccessIndex: hexutil.Uint64(change.Index),
+				PostBalance:      (*hexutil.Big)(change.Value.ToBig()),
+			})
+		}
+		for _, change := range account.NonceChanges {
+			changes.NonceChanges = append(changes.NonceChanges, RPCNonceChange{
+				BlockAccessIndex: hexutil.Uint64(change.Index),
+				PostNonce:        hexutil.Uint64(change.Value),
+			})
+		}
+		for _, change := range account.CodeChanges {
+			changes.CodeChanges = append(changes.CodeChanges, RPCCodeChange{
+				BlockAccessIndex: hexutil.Uint64(change.Index),
+				NewCode:          change.Data,
+			})
+		}
+		out = append(out, changes)
+	}
+	return out
+}
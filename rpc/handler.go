@@ -468,6 +468,14 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream jsonstrea
 	} else if h.isMethodAllowedByGranularControl(msg.Method) {
 		callb = h.reg.callback(msg.Method)
 	}
+	if callb == nil && h.isMethodAllowedByGranularControl(msg.Method) {
+		// subscription called by its method name, e.g. `txpool_subscribeStatus(hash)` instead of `txpool_subscribe("subscribeStatus", hash)`
+		if elem := strings.SplitN(msg.Method, serviceMethodSeparator, 2); len(elem) == 2 {
+			if subCb := h.reg.subscription(elem[0], elem[1]); subCb != nil {
+				return h.runSubscription(cp, msg, elem[0], subCb, subCb.argTypes, stream)
+			}
+		}
+	}
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
@@ -508,12 +516,19 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage, stream json
 	}
 
 	// Parse subscription name arg too, but remove it before calling the callback.
-	argTypes := append([]reflect.Type{stringType}, callb.argTypes...)
+	return h.runSubscription(cp, msg, namespace, callb, append([]reflect.Type{stringType}, callb.argTypes...), stream)
+}
+
+// runSubscription parses arguments of subscription callback and runs it. Extra leading argTypes (subscription name) are not passed to the callback.
+func (h *handler) runSubscription(cp *callProc, msg *jsonrpcMessage, namespace string, callb *callback, argTypes []reflect.Type, stream jsonstream.Stream) *jsonrpcMessage {
+	if !h.allowSubscribe {
+		return msg.errorResponse(ErrNotificationsUnsupported)
+	}
 	args, err := parsePositionalArguments(msg.Params, argTypes)
 	if err != nil {
 		return msg.errorResponse(&InvalidParamsError{err.Error()})
 	}
-	args = args[1:]
+	args = args[len(argTypes)-len(callb.argTypes):]
 
 	// Install notifier in context so the subscription handler can find it.
 	n := &RemoteNotifier{h: h, namespace: namespace}
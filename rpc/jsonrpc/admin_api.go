@@ -21,6 +21,9 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/erigontech/erigon/db/kv"
+	dbstate "github.com/erigontech/erigon/db/state"
+	"github.com/erigontech/erigon/execution/chain"
 	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/rpc/rpchelper"
@@ -40,16 +43,27 @@ type AdminAPI interface {
 
 	// RemovePeer requests connecting to a remote node.
 	RemovePeer(ctx context.Context, url string) (bool, error)
+
+	// RebuildAccessors re-builds accessors (.bt/.kvei/.kvi/.vi/.efi) of given state files and .idx of given block files in background,
+	// while node keeps serving RPC. Empty list: build all missed accessors of state files.
+	RebuildAccessors(ctx context.Context, fileNames []string) (bool, error)
+
+	// ReindexFile re-builds accessors of one state or block file in background. See RebuildAccessors.
+	ReindexFile(ctx context.Context, fileName string) (bool, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
 type AdminAPIImpl struct {
+	*BaseAPI
+	db         kv.TemporalRoDB
 	ethBackend rpchelper.ApiBackend
 }
 
 // NewAdminAPI returns AdminAPIImpl instance.
-func NewAdminAPI(eth rpchelper.ApiBackend) *AdminAPIImpl {
+func NewAdminAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend) *AdminAPIImpl {
 	return &AdminAPIImpl{
+		BaseAPI:    base,
+		db:         db,
 		ethBackend: eth,
 	}
 }
@@ -92,3 +106,70 @@ func (api *AdminAPIImpl) RemovePeer(ctx context.Context, url string) (bool, erro
 	}
 	return result.Success, nil
 }
+
+// blockIndexRebuilder - block snapshots which can re-build .idx files while node keeps serving reads
+type blockIndexRebuilder interface {
+	HasFile(fileName string) bool
+	RebuildIndicesInBackground(fileNames []string, tmpDir string, chainConfig *chain.Config) error
+}
+
+func (api *AdminAPIImpl) RebuildAccessors(ctx context.Context, fileNames []string) (bool, error) {
+	hasAgg, ok := api.db.(dbstate.HasAgg)
+	if !ok {
+		return false, errors.New("rebuild accessors: not available without local datadir")
+	}
+	agg, ok := hasAgg.Agg().(*dbstate.Aggregator)
+	if !ok {
+		return false, errors.New("rebuild accessors: not available without local datadir")
+	}
+
+	var stateFiles []string
+	blockFiles := map[blockIndexRebuilder][]string{}
+	for _, fileName := range fileNames {
+		if snaps := api.blockSnapshotsOf(fileName); snaps != nil {
+			blockFiles[snaps] = append(blockFiles[snaps], fileName)
+			continue
+		}
+		stateFiles = append(stateFiles, fileName)
+	}
+	if len(stateFiles) > 0 || len(blockFiles) == 0 {
+		if err := agg.RebuildAccessorsInBackground(stateFiles); err != nil {
+			return false, err
+		}
+	}
+	if len(blockFiles) == 0 {
+		return true, nil
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	for snaps, names := range blockFiles {
+		if err := snaps.RebuildIndicesInBackground(names, api.dirs.Tmp, chainConfig); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (api *AdminAPIImpl) blockSnapshotsOf(fileName string) blockIndexRebuilder {
+	for _, snaps := range []any{api._blockReader.Snapshots(), api._blockReader.BorSnapshots()} {
+		if snaps, ok := snaps.(blockIndexRebuilder); ok && snaps.HasFile(fileName) {
+			return snaps
+		}
+	}
+	return nil
+}
+
+func (api *AdminAPIImpl) ReindexFile(ctx context.Context, fileName string) (bool, error) {
+	if fileName == "" {
+		return false, errors.New("reindex file: file name required")
+	}
+	return api.RebuildAccessors(ctx, []string{fileName})
+}
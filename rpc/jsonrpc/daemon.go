@@ -42,9 +42,10 @@ func APIList(db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPool txpoolproto.Tx
 	txpoolImpl := NewTxPoolAPI(base, db, txPool)
 	netImpl := NewNetAPIImpl(eth)
 	debugImpl := NewPrivateDebugAPI(base, db, cfg.Gascap)
+	debugImpl.MaxGetProofRewindBlockCount = cfg.MaxGetProofRewindBlockCount
 	traceImpl := NewTraceAPI(base, db, cfg)
 	web3Impl := NewWeb3APIImpl(eth)
-	adminImpl := NewAdminAPI(eth)
+	adminImpl := NewAdminAPI(base, db, eth)
 	parityImpl := NewParityAPIImpl(base, db)
 
 	var borImpl *BorImpl
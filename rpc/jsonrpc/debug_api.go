@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon/db/kv/order"
 	"github.com/erigontech/erigon/db/rawdb"
 	"github.com/erigontech/erigon/execution/rlp"
+	"github.com/erigontech/erigon/execution/stagedsync"
 	"github.com/erigontech/erigon/execution/stagedsync/stages"
 	"github.com/erigontech/erigon/execution/state"
 	tracersConfig "github.com/erigontech/erigon/execution/tracing/tracers/config"
@@ -65,6 +66,10 @@ type PrivateDebugAPI interface {
 	GetRawReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]hexutil.Bytes, error)
 	GetBadBlocks(ctx context.Context) ([]map[string]interface{}, error)
 	GetRawTransaction(ctx context.Context, hash common.Hash) (hexutil.Bytes, error)
+	GenerateBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*ethapi.RPCAccountChanges, error)
+	GenerateRawBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error)
+	ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*stagedsync.ExecutionWitness, error)
+	ProfileGas(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, opts *GasProfileOptions) (*GasProfileResult, error)
 	FreeOSMemory()
 	SetGCPercent(v int) int
 	SetMemoryLimit(limit int64) int64
@@ -77,6 +82,10 @@ type DebugAPIImpl struct {
 	*BaseAPI
 	db     kv.TemporalRoDB
 	GasCap uint64
+
+	// MaxGetProofRewindBlockCount limits how deep debug_executionWitness rewinds
+	// the state, set by the daemon from the same flag as eth_getProof
+	MaxGetProofRewindBlockCount int
 }
 
 // NewPrivateDebugAPI returns PrivateDebugAPIImpl instance
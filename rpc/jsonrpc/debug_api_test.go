@@ -636,3 +636,42 @@ func TestGetRawTransaction(t *testing.T) {
 	}
 	require.True(testedOnce, "Test flow didn't touch the target flow")
 }
+
+func TestGenerateBlockAccessList(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	for blockNum := rpc.BlockNumber(1); blockNum <= 10; blockNum++ {
+		blockNrOrHash := rpc.BlockNumberOrHashWithNumber(blockNum)
+
+		// the test chain predates access lists, so none are stored
+		_, err := ethApi.GetBlockAccessList(m.Ctx, blockNrOrHash)
+		require.Error(t, err)
+
+		bal, err := api.generateBlockAccessListFor(m.Ctx, blockNrOrHash)
+		require.NoError(t, err)
+		require.NoError(t, bal.Validate())
+
+		// every account the block modified is listed, contracts created and destroyed
+		// within a transaction (block 10) without any changes
+		next := blockNum + 1
+		modified, err := api.GetModifiedAccountsByNumber(m.Ctx, blockNum, &next)
+		require.NoError(t, err)
+		listed := map[common.Address]bool{}
+		for _, account := range bal {
+			listed[account.Address] = true
+		}
+		for _, addr := range modified {
+			require.True(t, listed[addr], "block %d: %x modified but not listed", blockNum, addr)
+		}
+
+		raw, err := api.GenerateRawBlockAccessList(m.Ctx, blockNrOrHash)
+		require.NoError(t, err)
+		require.Equal(t, bal.Hash(), crypto.Keccak256Hash(raw))
+
+		list, err := api.GenerateBlockAccessList(m.Ctx, blockNrOrHash)
+		require.NoError(t, err)
+		require.Len(t, list, len(bal))
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/consensuschain"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/execution/protocol"
+	protocolrules "github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/execution/rlp"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/execution/vm"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/rpc/transactions"
+)
+
+// GenerateBlockAccessList implements debug_generateBlockAccessList. Re-executes the block and returns the
+// EIP-7928 block access list its execution produces, this works for blocks from before access lists existed
+func (api *DebugAPIImpl) GenerateBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*ethapi.RPCAccountChanges, error) {
+	bal, err := api.generateBlockAccessListFor(ctx, blockNrOrHash)
+	if err != nil || bal == nil {
+		return nil, err
+	}
+	return ethapi.RPCMarshalBlockAccessList(bal), nil
+}
+
+// GenerateRawBlockAccessList implements debug_generateRawBlockAccessList. Returns the RLP encoding of the
+// block access list debug_generateBlockAccessList produces, which is what a block header commits to
+func (api *DebugAPIImpl) GenerateRawBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	bal, err := api.generateBlockAccessListFor(ctx, blockNrOrHash)
+	if err != nil || bal == nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(bal)
+}
+
+func (api *DebugAPIImpl) generateBlockAccessListFor(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (types.BlockAccessList, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	return api.generateBlockAccessList(ctx, tx, block)
+}
+
+// generateBlockAccessList re-executes block on top of its parent's state the way the parallel executor
+// does: each transaction runs in its own IntraBlockState over a version map holding the writes of the
+// ones before it, and the access list is built from the VersionedIO recorded along the way
+func (api *DebugAPIImpl) generateBlockAccessList(ctx context.Context, tx kv.TemporalTx, block *types.Block) (types.BlockAccessList, error) {
+	blockNum := block.NumberU64()
+	if blockNum == 0 {
+		return nil, errors.New("genesis block has no block access list")
+	}
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := api.engine().(protocolrules.Engine)
+	if !ok {
+		return nil, fmt.Errorf("block access list generation is not supported by %T", api.engine())
+	}
+
+	// state as of the start of the block, before its system calls
+	stateReader, err := rpchelper.CreateHistoryStateReader(tx, blockNum, -1, api._txNumReader)
+	if err != nil {
+		return nil, err
+	}
+
+	header := block.HeaderNoCopy()
+	txs := block.Transactions()
+	blockCtx := transactions.NewEVMBlockContext(engine, header, true /* requireCanonical */, tx, api._blockReader, chainConfig)
+	chainRules := blockCtx.Rules(chainConfig)
+	signer := types.MakeSigner(chainConfig, blockNum, header.Time)
+	logger := log.New("debug_generateBlockAccessList")
+	chainReader := consensuschain.NewReader(chainConfig, tx, api._blockReader, logger)
+
+	versionMap := state.NewVersionMap(nil)
+	blockIO := &state.VersionedIO{}
+
+	newIBS := func(txIndex int) *state.IntraBlockState {
+		ibs := state.New(stateReader)
+		ibs.SetVersionMap(versionMap)
+		ibs.SetTxContext(blockNum, txIndex)
+		return ibs
+	}
+
+	record := func(ibs *state.IntraBlockState, txIndex int, writes state.VersionedWrites) {
+		version := state.Version{BlockNum: blockNum, TxIndex: txIndex}
+		blockIO.RecordReads(version, ibs.VersionedReads())
+		blockIO.RecordAccesses(version, ibs.AccessedAddresses())
+		if len(writes) > 0 {
+			blockIO.RecordWrites(version, writes)
+			versionMap.FlushVersionedWrites(writes, true, "")
+		}
+	}
+
+	ibs := newIBS(-1)
+	if err := protocol.InitializeBlockExecution(engine, chainReader, header, chainConfig, ibs, nil, logger, nil); err != nil {
+		return nil, err
+	}
+	record(ibs, -1, ibs.VersionedWrites(false))
+
+	gasPool := protocol.NewGasPool(header.GasLimit, chainConfig.GetMaxBlobGasPerBlock(header.Time))
+	receipts := make(types.Receipts, 0, len(txs))
+	var cumulativeGasUsed uint64
+
+	for txIndex, txn := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msg, err := txn.AsMessage(*signer, header.BaseFee, chainRules)
+		if err != nil {
+			return nil, fmt.Errorf("convert txn %d into msg: %w", txIndex, err)
+		}
+
+		ibs := newIBS(txIndex)
+		evm := vm.NewEVM(blockCtx, protocol.NewEVMTxContext(msg), ibs, chainConfig, vm.Config{})
+		result, err := protocol.ApplyMessage(evm, msg, gasPool, true /* refunds */, false /* gasBailout */, engine)
+		if err != nil {
+			return nil, fmt.Errorf("apply txn %d: %w", txIndex, err)
+		}
+		ibs.SoftFinalise()
+		if err := ibs.MakeWriteSet(chainRules, state.NewNoopWriter()); err != nil {
+			return nil, err
+		}
+
+		cumulativeGasUsed += result.GasUsed
+		receipts = append(receipts, protocol.MakeReceipt(header.Number, block.Hash(), msg, txn, cumulativeGasUsed, result, ibs, evm))
+		record(ibs, txIndex, ibs.VersionedWrites(false))
+	}
+
+	ibs = newIBS(len(txs))
+	syscall := func(contract common.Address, data []byte) ([]byte, error) {
+		return protocol.SysCallContract(contract, data, chainConfig, ibs, header, engine, false /* constCall */, vm.Config{})
+	}
+	if _, err := engine.Finalize(chainConfig, types.CopyHeader(header), ibs, txs, block.Uncles(), receipts, block.Withdrawals(), chainReader, syscall, false, logger); err != nil {
+		return nil, fmt.Errorf("finalize block %d: %w", blockNum, err)
+	}
+	record(ibs, len(txs), ibs.VersionedWrites(true))
+
+	return stagedsync.CreateBAL(blockNum, blockIO, ""), nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// ExecutionWitness implements debug_executionWitness. Returns the witness of the block in geth's
+// ExecutionWitness format: the pre-state trie nodes, codes, keys and headers a stateless client needs
+// to execute the block and check its state root
+func (api *DebugAPIImpl) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*stagedsync.ExecutionWitness, error) {
+	var result *stagedsync.ExecutionWitness
+	logger := log.New("debug_executionWitness")
+	err := api.computeWitness(ctx, api.db, blockNrOrHash, 0, true, api.MaxGetProofRewindBlockCount, logger, func(bw *blockWitness) error {
+		if bw == nil {
+			return errors.New("genesis block has no execution witness")
+		}
+
+		// the parent header carries the pre-state root, older ones are read by BLOCKHASH
+		headers := []*types.Header{bw.prevHeader}
+		for header := bw.prevHeader; header.Number.Uint64() > bw.oldestHashRead; {
+			parent := bw.store.ChainReader.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+			if parent == nil {
+				return fmt.Errorf("header %d not found", header.Number.Uint64()-1)
+			}
+			headers = append(headers, parent)
+			header = parent
+		}
+
+		var err error
+		result, err = stagedsync.NewExecutionWitness(bw.trie, bw.touchedPlainKeys, bw.codeReads, headers)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
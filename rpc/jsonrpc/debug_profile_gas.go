@@ -0,0 +1,119 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/exec"
+	protocolrules "github.com/erigontech/erigon/execution/protocol/rules"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/tracing/tracers/native"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// debug_profileGas re-executes at most this many blocks
+const ProfileGasMaxBlockCount = 10_000
+
+// GasProfileOptions selects what debug_profileGas returns
+type GasProfileOptions struct {
+	Top   int  `json:"top"`   // entries of each list of the report, 20 if not set
+	Pprof bool `json:"pprof"` // also return the gas of the call stacks as a pprof profile
+}
+
+// GasProfileResult is the gas profile of a range of blocks
+type GasProfileResult struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	*native.GasProfileReport
+	Pprof hexutil.Bytes `json:"pprof,omitempty"` // gzipped protobuf, see `go tool pprof`
+}
+
+// ProfileGas implements debug_profileGas. Re-executes the blocks in [fromBlock, toBlock] and reports the contracts,
+// function selectors, opcodes and precompiles their transactions spent the most gas on
+func (api *DebugAPIImpl) ProfileGas(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, opts *GasProfileOptions) (*GasProfileResult, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(fromBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(toBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("from block (%d) must not be later than to block (%d)", from, to)
+	}
+	if to-from >= ProfileGasMaxBlockCount {
+		return nil, fmt.Errorf("block range too large: %d blocks, at most %d", to-from+1, ProfileGasMaxBlockCount)
+	}
+	from = max(from, 1) // genesis has no transactions
+
+	minTxNum, err := api._txNumReader.Min(tx, from)
+	if err != nil {
+		return nil, err
+	}
+	r := state.NewHistoryReaderV3()
+	r.SetTx(tx)
+	if minTxNum < r.StateHistoryStartFrom() {
+		return nil, state.PrunedError
+	}
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := api.engine().(protocolrules.Engine)
+	if !ok {
+		return nil, fmt.Errorf("gas profiling is not supported by %T", api.engine())
+	}
+
+	profile, err := exec.ProfileGas(ctx, from, to, tx, exec.ExecArgs{
+		ChainDB:     api.db,
+		BlockReader: api._blockReader,
+		ChainConfig: chainConfig,
+		Engine:      engine,
+		Dirs:        api.dirs,
+	}, log.New("debug_profileGas"))
+	if err != nil {
+		return nil, err
+	}
+
+	top := 20
+	if opts != nil && opts.Top > 0 {
+		top = opts.Top
+	}
+	result := &GasProfileResult{FromBlock: hexutil.Uint64(from), ToBlock: hexutil.Uint64(to), GasProfileReport: profile.Top(top)}
+	if opts != nil && opts.Pprof {
+		var buf bytes.Buffer
+		if err := profile.WritePprof(&buf); err != nil {
+			return nil, err
+		}
+		result.Pprof = buf.Bytes()
+	}
+	return result, nil
+}
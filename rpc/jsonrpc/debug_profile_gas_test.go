@@ -0,0 +1,98 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/execution/tracing/tracers/native"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestProfileGas(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 5000000)
+
+	var gas, txs uint64
+	tx, err := m.DB.BeginTemporalRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	for n := uint64(1); n <= 10; n++ {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, n)
+		require.NoError(t, err)
+		gas += block.GasUsed()
+		txs += uint64(block.Transactions().Len())
+	}
+	tx.Rollback()
+
+	res, err := api.ProfileGas(m.Ctx, 0, 10, &GasProfileOptions{Top: 1000, Pprof: true})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uint64(res.FromBlock))
+	require.Equal(t, gas, res.Gas)
+	require.Equal(t, txs, res.Txs)
+	require.NotEmpty(t, res.Contracts)
+	require.NotEmpty(t, res.Selectors)
+	require.Contains(t, opcodes(res.Opcodes), "SSTORE")
+
+	var contractsGas, selectorsGas uint64
+	for i, e := range res.Contracts {
+		contractsGas += e.Gas
+		if i > 0 {
+			require.LessOrEqual(t, e.Gas, res.Contracts[i-1].Gas)
+		}
+	}
+	var creates int
+	for _, e := range res.Selectors {
+		selectorsGas += e.Gas
+		if e.Selector == "create" {
+			creates++
+		}
+	}
+	require.Equal(t, contractsGas, selectorsGas)
+	require.Positive(t, creates)
+
+	// the call stacks of the pprof profile hold the gas of the contracts
+	prof, err := profile.ParseData(res.Pprof)
+	require.NoError(t, err)
+	var stacksGas int64
+	for _, s := range prof.Sample {
+		stacksGas += s.Value[0]
+	}
+	require.Equal(t, int64(contractsGas), stacksGas)
+
+	res, err = api.ProfileGas(m.Ctx, 1, 10, &GasProfileOptions{Top: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Contracts, 1)
+	require.Nil(t, res.Pprof)
+
+	_, err = api.ProfileGas(m.Ctx, 5, 4, nil)
+	require.ErrorContains(t, err, "must not be later")
+	_, err = api.ProfileGas(m.Ctx, 0, rpc.BlockNumber(ProfileGasMaxBlockCount), nil)
+	require.ErrorContains(t, err, "too large")
+}
+
+func opcodes(entries []*native.GasProfileEntry) []string {
+	var ops []string
+	for _, e := range entries {
+		ops = append(ops, e.Opcode)
+	}
+	return ops
+}
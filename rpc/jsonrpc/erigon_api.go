@@ -41,6 +41,13 @@ type ErigonAPI interface {
 	GetBlockByTimestamp(ctx context.Context, timeStamp rpc.Timestamp, fullTx bool) (map[string]interface{}, error)
 	GetBalanceChangesInBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (map[common.Address]*hexutil.Big, error)
 
+	// State diffs (see ./erigon_state_diff.go)
+	GetBlockStateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, transactions *bool) (*BlockStateDiff, error)
+	GetStateDiffRange(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, filter *StateDiffFilter) ([]*BlockStateDiff, error)
+
+	// Custom traces (see ./erigon_custom_trace.go)
+	GetCustomTrace(ctx context.Context, name string, blockNrOrHash rpc.BlockNumberOrHash) ([]*CustomTraceResult, error)
+
 	// Receipt related (see ./erigon_receipts.go)
 	GetLogsByHash(ctx context.Context, hash common.Hash) ([][]*types.Log, error)
 	//GetLogsByNumber(ctx context.Context, number rpc.BlockNumber) ([][]*types.Log, error)
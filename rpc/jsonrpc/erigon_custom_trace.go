@@ -0,0 +1,133 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/rawdb"
+	"github.com/erigontech/erigon/execution/tracing/tracers"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// CustomTraceResult is the result a custom trace produced for a transaction, null if it produced nothing
+type CustomTraceResult struct {
+	TransactionHash  common.Hash     `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	Result           json.RawMessage `json:"result"`
+}
+
+// GetCustomTrace implements erigon_getCustomTrace. Returns the results the registered custom trace `name` produced
+// for the transactions of a block, or for a single transaction if given its hash. The results are read from the
+// custom trace domain filled by `integration stage_custom_trace --domain=<name>`, nothing is re-executed
+func (api *ErigonImpl) GetCustomTrace(ctx context.Context, name string, blockNrOrHash rpc.BlockNumberOrHash) ([]*CustomTraceResult, error) {
+	if _, ok := tracers.LookupCustomTrace(name); !ok {
+		return nil, fmt.Errorf("unknown custom trace: %s, registered: %v", name, tracers.CustomTraceNames())
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		number, err := api._blockReader.HeaderNumber(ctx, tx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if number == nil { // not a block, then a transaction
+			return api.txnCustomTrace(ctx, tx, name, hash)
+		}
+	}
+
+	blockNumber, hash, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(ctx, tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+	minTxNum, err := api._txNumReader.Min(tx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	// an empty block is checked by its end txn
+	if err := customTraceProduced(tx, name, blockNumber, minTxNum+1, minTxNum+max(uint64(block.Transactions().Len()), 1)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*CustomTraceResult, 0, block.Transactions().Len())
+	for i, txn := range block.Transactions() {
+		txNum := minTxNum + 1 + uint64(i) // the first txNum of a block is its pre-execution system txn
+		v, _, err := rawdb.ReadCustomTrace(tx, name, txNum)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &CustomTraceResult{TransactionHash: txn.Hash(), TransactionIndex: hexutil.Uint64(i), Result: v})
+	}
+	return result, nil
+}
+
+func (api *ErigonImpl) txnCustomTrace(ctx context.Context, tx kv.TemporalTx, name string, txnHash common.Hash) ([]*CustomTraceResult, error) {
+	blockNum, txNum, ok, err := api.txnLookup(ctx, tx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("block or transaction %x not found", txnHash)
+	}
+	minTxNum, err := api._txNumReader.Min(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if txNum <= minTxNum {
+		return nil, fmt.Errorf("uint underflow txnums error txNum: %d, txNumMin: %d, blockNum: %d", txNum, minTxNum, blockNum)
+	}
+	if err := customTraceProduced(tx, name, blockNum, txNum, txNum); err != nil {
+		return nil, err
+	}
+
+	v, _, err := rawdb.ReadCustomTrace(tx, name, txNum)
+	if err != nil {
+		return nil, err
+	}
+	return []*CustomTraceResult{{TransactionHash: txnHash, TransactionIndex: hexutil.Uint64(txNum - minTxNum - 1), Result: v}}, nil
+}
+
+// customTraceProduced - the txns the trace was not produced for must not look like the ones it had nothing for.
+// Each trace has its own progress: a trace added later is produced from the point the shared domain was at
+func customTraceProduced(tx kv.TemporalTx, name string, blockNum, fromTxNum, toTxNum uint64) error {
+	from, to, ok, err := rawdb.ReadCustomTraceProgress(tx, name)
+	if err != nil {
+		return err
+	}
+	if !ok || fromTxNum < from || toTxNum > to {
+		return fmt.Errorf("custom trace %s is not produced for block %d, see `integration stage_custom_trace`", name, blockNum)
+	}
+	return nil
+}
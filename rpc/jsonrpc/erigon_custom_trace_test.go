@@ -0,0 +1,113 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/state/statecfg"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetCustomTrace(t *testing.T) {
+	customTraceCfg := statecfg.Schema.CustomTraceDomain
+	statecfg.EnableCustomTraces()
+	t.Cleanup(func() { statecfg.Schema.CustomTraceDomain = customTraceCfg })
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+
+	n := rpc.BlockNumber(1)
+	_, err := api.GetCustomTrace(m.Ctx, "sstoreCounts", rpc.BlockNumberOrHash{BlockNumber: &n})
+	require.ErrorContains(t, err, "not produced")
+
+	// the traces are produced up to the executed block: the first run is up to block 10 only
+	var execProgress uint64
+	setExecProgress := func(progress uint64) {
+		require.NoError(t, m.DB.Update(m.Ctx, func(tx kv.RwTx) (err error) {
+			if execProgress == 0 {
+				if execProgress, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+					return err
+				}
+			}
+			return stages.SaveStageProgress(tx, stages.Execution, progress)
+		}))
+	}
+	setExecProgress(10)
+	cfg := stagedsync.StageCustomTraceCfg([]string{"sstoreCounts"}, m.DB, m.Dirs, m.BlockReader, m.ChainConfig, m.Engine, m.Cfg().Genesis, m.Cfg().Sync)
+	require.NoError(t, stagedsync.SpawnCustomTrace(cfg, m.Ctx, m.Log))
+
+	_, err = api.GetCustomTrace(m.Ctx, "unknown", rpc.BlockNumberOrHash{BlockNumber: &n})
+	require.ErrorContains(t, err, "unknown custom trace")
+
+	var stores, txns, withResult int
+	for n := rpc.BlockNumber(1); n <= 10; n++ {
+		results, err := api.GetCustomTrace(m.Ctx, "sstoreCounts", rpc.BlockNumberOrHash{BlockNumber: &n})
+		require.NoError(t, err)
+		for i, res := range results {
+			require.Equal(t, hexutil.Uint64(i), res.TransactionIndex)
+			txns++
+			if res.Result == nil {
+				continue
+			}
+			withResult++
+			var counts map[common.Address]hexutil.Uint64
+			require.NoError(t, json.Unmarshal(res.Result, &counts))
+			for _, count := range counts {
+				require.Positive(t, count)
+				stores += int(count)
+			}
+
+			// the same result by the transaction's hash
+			byTxn, err := api.GetCustomTrace(m.Ctx, "sstoreCounts", rpc.BlockNumberOrHashWithHash(res.TransactionHash, false))
+			require.NoError(t, err)
+			require.Len(t, byTxn, 1)
+			require.Equal(t, res, byTxn[0])
+		}
+	}
+	require.Positive(t, stores)
+	// the results are of each transaction, not the latest one
+	require.Less(t, withResult, txns)
+
+	// the trace added later is produced from the point the domain is at: the blocks before are not produced, not empty
+	setExecProgress(execProgress)
+	require.Greater(t, execProgress, uint64(10))
+	cfg = stagedsync.StageCustomTraceCfg([]string{"sstoreCounts", "gasRefunds"}, m.DB, m.Dirs, m.BlockReader, m.ChainConfig, m.Engine, m.Cfg().Genesis, m.Cfg().Sync)
+	require.NoError(t, stagedsync.SpawnCustomTrace(cfg, m.Ctx, m.Log))
+	n = 1
+	_, err = api.GetCustomTrace(m.Ctx, "gasRefunds", rpc.BlockNumberOrHash{BlockNumber: &n})
+	require.ErrorContains(t, err, "custom trace gasRefunds is not produced")
+	latest := rpc.LatestBlockNumber
+	_, err = api.GetCustomTrace(m.Ctx, "gasRefunds", rpc.BlockNumberOrHash{BlockNumber: &latest})
+	require.NoError(t, err)
+
+	// reset of one trace keeps the others
+	reset := stagedsync.NewProduce([]string{"gasRefunds"})
+	require.NoError(t, stagedsync.StageCustomTraceReset(m.Ctx, m.DB, reset))
+	_, err = api.GetCustomTrace(m.Ctx, "gasRefunds", rpc.BlockNumberOrHash{BlockNumber: &latest})
+	require.ErrorContains(t, err, "not produced")
+	_, err = api.GetCustomTrace(m.Ctx, "sstoreCounts", rpc.BlockNumberOrHash{BlockNumber: &n})
+	require.NoError(t, err)
+}
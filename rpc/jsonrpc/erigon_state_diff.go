@@ -0,0 +1,305 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/length"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/order"
+	"github.com/erigontech/erigon/execution/state"
+	"github.com/erigontech/erigon/execution/types/accounts"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// erigon_getStateDiffRange is the maximum number of blocks which can be retrieved
+const GetStateDiffRangeMaxBlockCount = 1000
+
+// BlockStateDiff is the state diff of a block, in the format of the stateDiff of trace_replayBlockTransactions,
+// optionally broken down by transaction
+type BlockStateDiff struct {
+	BlockNumber  hexutil.Uint64                       `json:"blockNumber"`
+	BlockHash    common.Hash                          `json:"blockHash"`
+	StateDiff    map[common.Address]*StateDiffAccount `json:"stateDiff"`
+	Transactions []*TransactionStateDiff              `json:"transactions,omitempty"`
+}
+
+// TransactionStateDiff is the state diff of a single transaction of a block
+type TransactionStateDiff struct {
+	TransactionHash  common.Hash                          `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64                       `json:"transactionIndex"`
+	StateDiff        map[common.Address]*StateDiffAccount `json:"stateDiff"`
+}
+
+// StateDiffFilter selects what erigon_getStateDiffRange returns
+type StateDiffFilter struct {
+	Addresses    []common.Address `json:"addresses"`    // only diffs of these accounts, all if empty
+	Transactions bool             `json:"transactions"` // break each block's diff down by transaction
+}
+
+// GetBlockStateDiff implements erigon_getBlockStateDiff. Returns the accounts, storage and code the block changed,
+// with their values before and after it, read from state history instead of re-executing the block. With
+// transactions set the diff of every transaction is returned as well, system calls before and after the
+// transactions (block rewards, withdrawals, ...) only show up in the block's diff
+func (api *ErigonImpl) GetBlockStateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, transactions *bool) (*BlockStateDiff, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNumber, hash, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.blockStateDiff(ctx, tx, blockNumber, hash, nil, transactions != nil && *transactions)
+}
+
+// GetStateDiffRange implements erigon_getStateDiffRange. Returns the state diff of every block in [fromBlock, toBlock]
+// like erigon_getBlockStateDiff does, limited to the filter's accounts
+func (api *ErigonImpl) GetStateDiffRange(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, filter *StateDiffFilter) ([]*BlockStateDiff, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(fromBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(toBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("from block (%d) must not be later than to block (%d)", from, to)
+	}
+	if to-from >= GetStateDiffRangeMaxBlockCount {
+		return nil, fmt.Errorf("block range too large: %d blocks, at most %d", to-from+1, GetStateDiffRangeMaxBlockCount)
+	}
+
+	var include func(common.Address) bool
+	var perTx bool
+	if filter != nil {
+		perTx = filter.Transactions
+		if len(filter.Addresses) > 0 {
+			addresses := make(map[common.Address]struct{}, len(filter.Addresses))
+			for _, addr := range filter.Addresses {
+				addresses[addr] = struct{}{}
+			}
+			include = func(addr common.Address) bool {
+				_, ok := addresses[addr]
+				return ok
+			}
+		}
+	}
+
+	result := make([]*BlockStateDiff, 0, to-from+1)
+	for blockNumber := from; blockNumber <= to; blockNumber++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hash, ok, err := api._blockReader.CanonicalHash(ctx, tx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("canonical hash not found for block %d", blockNumber)
+		}
+		diff, err := api.blockStateDiff(ctx, tx, blockNumber, hash, include, perTx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, diff)
+	}
+	return result, nil
+}
+
+func (api *ErigonImpl) blockStateDiff(ctx context.Context, tx kv.TemporalTx, blockNumber uint64, hash common.Hash, include func(common.Address) bool, perTx bool) (*BlockStateDiff, error) {
+	minTxNum, err := api._txNumReader.Min(tx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	maxTxNum, err := api._txNumReader.Max(tx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	r := state.NewHistoryReaderV3()
+	r.SetTx(tx)
+	if minTxNum < r.StateHistoryStartFrom() {
+		return nil, state.PrunedError
+	}
+
+	result := &BlockStateDiff{BlockNumber: hexutil.Uint64(blockNumber), BlockHash: hash}
+	if result.StateDiff, err = historyStateDiff(tx, minTxNum, maxTxNum+1, include); err != nil {
+		return nil, err
+	}
+	if !perTx {
+		return result, nil
+	}
+
+	block, err := api.blockWithSenders(ctx, tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+	result.Transactions = make([]*TransactionStateDiff, 0, len(block.Transactions()))
+	for i, txn := range block.Transactions() {
+		txNum := minTxNum + 1 + uint64(i) // the first txNum of a block is its pre-execution system txn
+		diff, err := historyStateDiff(tx, txNum, txNum+1, include)
+		if err != nil {
+			return nil, err
+		}
+		result.Transactions = append(result.Transactions, &TransactionStateDiff{
+			TransactionHash:  txn.Hash(),
+			TransactionIndex: hexutil.Uint64(i),
+			StateDiff:        diff,
+		})
+	}
+	return result, nil
+}
+
+type historyChange struct {
+	from, to []byte
+}
+
+// historyChanges returns the keys of the domain changed in [fromTxNum, toTxNum) with their values before and after
+func historyChanges(tx kv.TemporalTx, domain kv.Domain, fromTxNum, toTxNum uint64, include func(common.Address) bool) (map[string]historyChange, error) {
+	it, err := tx.HistoryRange(domain, int(fromTxNum), int(toTxNum), order.Asc, kv.Unlim)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	changes := map[string]historyChange{}
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if include != nil && !include(common.BytesToAddress(k[:length.Addr])) {
+			continue
+		}
+		// history holds the value a key had before it was first changed in the range
+		after, _, err := tx.GetAsOf(domain, k, toTxNum)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(v, after) {
+			continue
+		}
+		changes[string(k)] = historyChange{from: common.Copy(v), to: common.Copy(after)}
+	}
+	return changes, nil
+}
+
+// historyStateDiff builds the state diff of [fromTxNum, toTxNum) out of the changes recorded in the accounts,
+// storage and code histories
+func historyStateDiff(tx kv.TemporalTx, fromTxNum, toTxNum uint64, include func(common.Address) bool) (map[common.Address]*StateDiffAccount, error) {
+	accountChanges, err := historyChanges(tx, kv.AccountsDomain, fromTxNum, toTxNum, include)
+	if err != nil {
+		return nil, err
+	}
+	codeChanges, err := historyChanges(tx, kv.CodeDomain, fromTxNum, toTxNum, include)
+	if err != nil {
+		return nil, err
+	}
+	storageChanges, err := historyChanges(tx, kv.StorageDomain, fromTxNum, toTxNum, include)
+	if err != nil {
+		return nil, err
+	}
+
+	sdMap := map[common.Address]*StateDiffAccount{}
+	accountDiff := func(addr common.Address) *StateDiffAccount {
+		diff, ok := sdMap[addr]
+		if !ok {
+			diff = &StateDiffAccount{Balance: "=", Code: "=", Nonce: "=", Storage: map[common.Hash]map[string]interface{}{}}
+			sdMap[addr] = diff
+		}
+		return diff
+	}
+
+	for k, change := range storageChanges {
+		diff := accountDiff(common.BytesToAddress([]byte(k[:length.Addr])))
+		diff.Storage[common.BytesToHash([]byte(k[length.Addr:]))] = map[string]interface{}{
+			"*": &StateDiffStorage{From: common.BytesToHash(change.from), To: common.BytesToHash(change.to)},
+		}
+	}
+
+	for k, change := range accountChanges {
+		addr := common.BytesToAddress([]byte(k))
+		var from, to accounts.Account
+		if len(change.from) > 0 {
+			if err := accounts.DeserialiseV3(&from, change.from); err != nil {
+				return nil, err
+			}
+		}
+		if len(change.to) > 0 {
+			if err := accounts.DeserialiseV3(&to, change.to); err != nil {
+				return nil, err
+			}
+		}
+		code, codeChanged := codeChanges[k]
+		if !codeChanged {
+			code = historyChange{from: hexutil.Bytes{}, to: hexutil.Bytes{}}
+		}
+
+		diff := accountDiff(addr)
+		switch {
+		case len(change.from) > 0 && len(change.to) > 0:
+			if !from.Balance.Eq(&to.Balance) {
+				diff.Balance = map[string]*StateDiffBalance{"*": {From: (*hexutil.Big)(from.Balance.ToBig()), To: (*hexutil.Big)(to.Balance.ToBig())}}
+			}
+			if from.Nonce != to.Nonce {
+				diff.Nonce = map[string]*StateDiffNonce{"*": {From: hexutil.Uint64(from.Nonce), To: hexutil.Uint64(to.Nonce)}}
+			}
+			if codeChanged {
+				diff.Code = map[string]*StateDiffCode{"*": {From: code.from, To: code.to}}
+			}
+		case len(change.to) > 0:
+			diff.Balance = map[string]*hexutil.Big{"+": (*hexutil.Big)(to.Balance.ToBig())}
+			diff.Nonce = map[string]hexutil.Uint64{"+": hexutil.Uint64(to.Nonce)}
+			diff.Code = map[string]hexutil.Bytes{"+": code.to}
+			for _, sm := range diff.Storage {
+				sm["+"] = &sm["*"].(*StateDiffStorage).To
+				delete(sm, "*")
+			}
+		case len(change.from) > 0:
+			diff.Balance = map[string]*hexutil.Big{"-": (*hexutil.Big)(from.Balance.ToBig())}
+			diff.Nonce = map[string]hexutil.Uint64{"-": hexutil.Uint64(from.Nonce)}
+			diff.Code = map[string]hexutil.Bytes{"-": code.from}
+		}
+	}
+
+	for addr, diff := range sdMap {
+		if diff.Balance == "=" && diff.Nonce == "=" && diff.Code == "=" && len(diff.Storage) == 0 {
+			delete(sdMap, addr)
+		}
+	}
+	return sdMap, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetBlockStateDiff(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	traceApi := NewTraceAPI(newBaseApiForTest(m), m.DB, &httpcfg.HttpCfg{})
+
+	perTx := true
+	for n := rpc.BlockNumber(1); n <= 10; n++ {
+		bnh := rpc.BlockNumberOrHash{BlockNumber: &n}
+		diff, err := api.GetBlockStateDiff(m.Ctx, bnh, &perTx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(n), uint64(diff.BlockNumber))
+
+		// per transaction diffs read from history must match those of re-execution
+		traces, err := traceApi.ReplayBlockTransactions(m.Ctx, bnh, []string{TraceTypeStateDiff}, new(bool), nil)
+		require.NoError(t, err)
+		require.Len(t, diff.Transactions, len(traces))
+		for i, trace := range traces {
+			require.Equal(t, trace.TransactionHash, &diff.Transactions[i].TransactionHash)
+			expected, err := json.Marshal(trace.StateDiff)
+			require.NoError(t, err)
+			actual, err := json.Marshal(diff.Transactions[i].StateDiff)
+			require.NoError(t, err)
+			require.JSONEq(t, string(expected), string(actual), "block %d txn %d", n, i)
+		}
+	}
+
+	n := rpc.BlockNumber(6)
+	diff, err := api.GetBlockStateDiff(m.Ctx, rpc.BlockNumberOrHash{BlockNumber: &n}, nil)
+	require.NoError(t, err)
+	require.Nil(t, diff.Transactions)
+	balance := diff.StateDiff[common.HexToAddress("0x0000000000000001000000000000000000000000")].Balance
+	require.Contains(t, balance, "+")
+}
+
+func TestGetStateDiffRange(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+
+	diffs, err := api.GetStateDiffRange(m.Ctx, 1, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, diffs, 10)
+	for i, diff := range diffs {
+		require.Equal(t, uint64(i+1), uint64(diff.BlockNumber))
+		require.Nil(t, diff.Transactions)
+	}
+
+	addr := common.HexToAddress("0x0000000000000001000000000000000000000000")
+	diffs, err = api.GetStateDiffRange(m.Ctx, 1, 10, &StateDiffFilter{Addresses: []common.Address{addr}, Transactions: true})
+	require.NoError(t, err)
+	var touched int
+	for _, diff := range diffs {
+		for a := range diff.StateDiff {
+			require.Equal(t, addr, a)
+			touched++
+		}
+		for _, txDiff := range diff.Transactions {
+			for a := range txDiff.StateDiff {
+				require.Equal(t, addr, a)
+			}
+		}
+	}
+	require.Positive(t, touched)
+
+	_, err = api.GetStateDiffRange(m.Ctx, 5, 4, nil)
+	require.Error(t, err)
+	_, err = api.GetStateDiffRange(m.Ctx, 0, GetStateDiffRangeMaxBlockCount+1, nil)
+	require.Error(t, err)
+}
@@ -416,6 +416,33 @@ func (api *APIImpl) GetBlockTransactionCountByHash(ctx context.Context, blockHas
 	return &numOfTx, nil
 }
 
+// GetBlockAccessList implements eth_getBlockAccessList. Returns the EIP-7928 block access list stored with the block,
+// blocks from before access lists were introduced don't have one - debug_generateBlockAccessList can re-create it
+func (api *APIImpl) GetBlockAccessList(ctx context.Context, numberOrHash rpc.BlockNumberOrHash) ([]*ethapi.RPCAccountChanges, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, numberOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	if block.HeaderNoCopy().BlockAccessListHash == nil {
+		return nil, fmt.Errorf("block %d has no block access list", blockNum)
+	}
+
+	return ethapi.RPCMarshalBlockAccessList(block.BlockAccessList()), nil
+}
+
 func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, error) {
 	if number != rpc.PendingBlockNumber {
 		return api.blockByRPCNumber(ctx, number, tx)
@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon/db/kv/membatchwithdb"
 	"github.com/erigontech/erigon/db/state/execctx"
 	"github.com/erigontech/erigon/execution/commitment/trie"
+	witnesstypes "github.com/erigontech/erigon/execution/commitment/witness"
 	"github.com/erigontech/erigon/execution/protocol"
 	"github.com/erigontech/erigon/execution/protocol/params"
 	"github.com/erigontech/erigon/execution/protocol/rules"
@@ -625,56 +626,131 @@ func verifyExecResult(execResult *protocol.EphemeralExecResult, block *types.Blo
 }
 
 func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger) (hexutil.Bytes, error) {
-	roTx, err := db.BeginRo(ctx)
+	var result hexutil.Bytes
+	err := api.computeWitness(ctx, db, blockNrOrHash, txIndex, fullBlock, maxGetProofRewindBlockCount, logger, func(bw *blockWitness) error {
+		// Witness for genesis block is empty
+		if bw == nil {
+			w := trie.NewWitness(make([]trie.WitnessOperator, 0))
+
+			var buf bytes.Buffer
+			if _, err := w.WriteInto(&buf); err != nil {
+				return err
+			}
+			result = buf.Bytes()
+			return nil
+		}
+
+		// retain list is need for the serialization of the trie.Trie into a witness
+		retainListBuilder := trie.NewRetainListBuilder()
+		for _, key := range bw.touchedHashedKeys {
+			if len(key) == 32 {
+				retainListBuilder.AddTouch(key)
+			} else {
+				addr, _, hash := dbutils.ParseCompositeStorageKey(key)
+				storageTouch := dbutils.GenerateCompositeTrieKey(addr, hash)
+				retainListBuilder.AddStorageTouch(storageTouch)
+			}
+		}
+
+		for _, codeWithHash := range bw.codeReads {
+			retainListBuilder.ReadCode(codeWithHash.CodeHash, codeWithHash.Code)
+		}
+
+		retainList := retainListBuilder.Build(false)
+
+		// serialize witness trie
+		witness, err := bw.trie.ExtractWitness(true, retainList)
+		if err != nil {
+			return err
+		}
+
+		var witnessBuffer bytes.Buffer
+		_, err = witness.WriteInto(&witnessBuffer)
+		if err != nil {
+			return err
+		}
+
+		// this is a verification step: we execute block #blockNr statelessly using the witness, and we expect to get the same state root as in the header
+		// otherwise something went wrong
+		bw.store.Tds.SetTrie(bw.trie)
+		newStateRoot, err := stagedsync.ExecuteBlockStatelessly(bw.block, bw.prevHeader, bw.store.ChainReader, bw.store.Tds, bw.cfg, &witnessBuffer, bw.store.GetHashFn, logger)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(newStateRoot.Bytes(), bw.block.Root().Bytes()) {
+			fmt.Printf("state root mismatch after stateless execution actual(%x) != expected(%x)\n", newStateRoot.Bytes(), bw.block.Root().Bytes())
+		}
+		result = common.CopyBytes(witnessBuffer.Bytes())
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return result, nil
+}
+
+// blockWitness holds the witness trie of a block, loaded from the state before the block
+// for the keys its execution touches, together with what is needed to serialize and verify it
+type blockWitness struct {
+	block      *types.Block
+	prevHeader *types.Header
+	trie       *trie.Trie
+
+	touchedPlainKeys  [][]byte
+	touchedHashedKeys [][]byte
+	codeReads         map[common.Hash]witnesstypes.CodeWithHash
+	oldestHashRead    uint64 // lowest block number read with BLOCKHASH, blockNr-1 if none
+
+	store *stagedsync.WitnessStore
+	cfg   *stagedsync.WitnessCfg
+}
+
+// computeWitness executes the block ephemerally to find the keys it touches and builds its
+// witness trie, then calls fn with it while the transactions it was computed in are still
+// open. fn is called with nil for the genesis block, which has no pre-state, and isn't
+// called for unknown blocks.
+func (api *BaseAPI) computeWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger, fn func(bw *blockWitness) error) error {
+	roTx, err := db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
 	defer roTx.Rollback()
 
 	blockNr, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, roTx, api._blockReader, api.filters) // DoCall cannot be executed on non-canonical blocks
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Witness for genesis block is empty
 	if blockNr == 0 {
-		w := trie.NewWitness(make([]trie.WitnessOperator, 0))
-
-		var buf bytes.Buffer
-		_, err = w.WriteInto(&buf)
-		if err != nil {
-			return nil, err
-		}
-
-		return buf.Bytes(), nil
+		return fn(nil)
 	}
 
 	block, err := api.blockWithSenders(ctx, roTx, hash, blockNr)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if block == nil {
-		return nil, nil
+		return nil
 	}
 
 	if !fullBlock && int(txIndex) >= len(block.Transactions()) {
-		return nil, fmt.Errorf("transaction index out of bounds: %d", txIndex)
+		return fmt.Errorf("transaction index out of bounds: %d", txIndex)
 	}
 
 	latestBlock, err := rpchelper.GetLatestBlockNumber(roTx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if latestBlock < blockNr {
 		// shouldn't happen, but check anyway
-		return nil, fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
+		return fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
 	}
 
 	// Compute the witness if it's for a tx or it's not present in db
 	prevHeader, err := api._blockReader.HeaderByNumber(ctx, roTx, blockNr-1)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	regenerateHash := false
@@ -684,12 +760,12 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 
 	engine, ok := api.engine().(rules.Engine)
 	if !ok {
-		return nil, errors.New("engine is not rules.Engine")
+		return errors.New("engine is not rules.Engine")
 	}
 
 	roTx2, err := db.BeginRo(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer roTx2.Rollback()
 	txBatch2 := membatchwithdb.NewMemoryBatch(roTx2, "", logger)
@@ -698,31 +774,38 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 	// Prepare witness config
 	chainConfig, err := api.chainConfig(ctx, roTx2)
 	if err != nil {
-		return nil, fmt.Errorf("error loading chain config: %v", err)
+		return fmt.Errorf("error loading chain config: %v", err)
 	}
 
 	// Unwind to blockNr
 	cfg := stagedsync.StageWitnessCfg(true, 0, chainConfig, engine, api._blockReader, api.dirs)
 	err = stagedsync.RewindStagesForWitness(txBatch2, blockNr, latestBlock, &cfg, regenerateHash, ctx, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	store, err := stagedsync.PrepareForWitness(txBatch2, block, prevHeader.Root, &cfg, ctx, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	domains, err := execctx.NewSharedDomains(txBatch2, log.New())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	sdCtx := domains.GetCommitmentContext()
 
+	// record the oldest block hash read, the headers down to it are part of the witness
+	oldestHashRead := blockNr - 1
+	getHashFn := func(n uint64) (common.Hash, error) {
+		oldestHashRead = min(oldestHashRead, n)
+		return store.GetHashFn(n)
+	}
+
 	// execute block #blockNr ephemerally. This will use TrieStateWriter to record touches of accounts and storage keys.
-	_, err = protocol.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, store.GetHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
+	_, err = protocol.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, getHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// gather touched keys from ephemeral block execution
@@ -737,56 +820,24 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 	// generate the block witness, this works by loading the merkle paths to the touched keys (they are loaded from the state at block #blockNr-1)
 	witnessTrie, witnessRootHash, err := sdCtx.Witness(ctx, codeReads, "computeWitness")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !bytes.Equal(witnessRootHash, prevHeader.Root[:]) {
-		return nil, fmt.Errorf("witness root hash mismatch actual(%x)!=expected(%x)", witnessRootHash, prevHeader.Root[:])
-	}
-
-	// retain list is need for the serialization of the trie.Trie into a witness
-	retainListBuilder := trie.NewRetainListBuilder()
-	for _, key := range touchedHashedKeys {
-		if len(key) == 32 {
-			retainListBuilder.AddTouch(key)
-		} else {
-			addr, _, hash := dbutils.ParseCompositeStorageKey(key)
-			storageTouch := dbutils.GenerateCompositeTrieKey(addr, hash)
-			retainListBuilder.AddStorageTouch(storageTouch)
-		}
-	}
-
-	for _, codeWithHash := range codeReads {
-		retainListBuilder.ReadCode(codeWithHash.CodeHash, codeWithHash.Code)
-	}
-
-	retainList := retainListBuilder.Build(false)
-
-	// serialize witness trie
-	witness, err := witnessTrie.ExtractWitness(true, retainList)
-	if err != nil {
-		return nil, err
-	}
-
-	var witnessBuffer bytes.Buffer
-	_, err = witness.WriteInto(&witnessBuffer)
-	if err != nil {
-		return nil, err
-	}
-
-	// this is a verification step: we execute block #blockNr statelessly using the witness, and we expect to get the same state root as in the header
-	// otherwise something went wrong
-	store.Tds.SetTrie(witnessTrie)
-	newStateRoot, err := stagedsync.ExecuteBlockStatelessly(block, prevHeader, store.ChainReader, store.Tds, &cfg, &witnessBuffer, store.GetHashFn, logger)
-	if err != nil {
-		return nil, err
-	}
-	if !bytes.Equal(newStateRoot.Bytes(), block.Root().Bytes()) {
-		fmt.Printf("state root mismatch after stateless execution actual(%x) != expected(%x)\n", newStateRoot.Bytes(), block.Root().Bytes())
-	}
-	witnessBufBytes := witnessBuffer.Bytes()
-	witnessBufBytesCopy := common.CopyBytes(witnessBufBytes)
-	return witnessBufBytesCopy, nil
+		return fmt.Errorf("witness root hash mismatch actual(%x)!=expected(%x)", witnessRootHash, prevHeader.Root[:])
+	}
+
+	return fn(&blockWitness{
+		block:             block,
+		prevHeader:        prevHeader,
+		trie:              witnessTrie,
+		touchedPlainKeys:  touchedPlainKeys,
+		touchedHashedKeys: touchedHashedKeys,
+		codeReads:         codeReads,
+		oldestHashRead:    oldestHashRead,
+		store:             store,
+		cfg:               &cfg,
+	})
 }
 
 func (api *APIImpl) tryBlockFromLru(hash common.Hash) *types.Block {
@@ -68,6 +68,9 @@ type Otterscan2API interface {
 	GetWithdrawalsList(ctx context.Context, addr common.Address, idx, count uint64) (*WithdrawalsListResult, error)
 	GetWithdrawalsCount(ctx context.Context, addr common.Address) (uint64, error)
 
+	GetEvents(ctx context.Context, name string, key common.Address, idx, count uint64) (*EventListResult, error)
+	GetEventsCount(ctx context.Context, name string, key common.Address) (uint64, error)
+
 	TransferIntegrityChecker(ctx context.Context) error
 	HoldingsIntegrityChecker(ctx context.Context) error
 }
@@ -121,17 +121,18 @@ func genericResultList[T any](ctx context.Context, tx kv.Tx, addr common.Address
 
 // Given an index, locates the counter chunk which should contain the desired index (>= index)
 func findNextCounter(counter kv.CursorDupSort, addr common.Address, idx uint64) (uint64, []byte, error) {
-	v, err := counter.SeekBothRange(addr.Bytes(), hexutil.EncodeTs(idx))
+	k, v, err := counter.SeekExact(addr.Bytes())
 	if err != nil {
 		return 0, nil, err
 	}
 
 	// No occurrences
-	if v == nil {
+	if k == nil {
 		return 0, nil, nil
 	}
 
-	// <= 256 matches-optimization
+	// <= 256 matches-optimization; it must be handled before seeking the dups because
+	// the 1 byte counter doesn't sort against the 8 bytes idx
 	if len(v) == 1 {
 		c, err := counter.CountDuplicates()
 		if err != nil {
@@ -140,9 +141,20 @@ func findNextCounter(counter kv.CursorDupSort, addr common.Address, idx uint64)
 		if c != 1 {
 			return 0, nil, fmt.Errorf("db possibly corrupted, expected 1 duplicate, got %d", c)
 		}
+		if idx > uint64(v[0])+1 {
+			return 0, nil, nil
+		}
 		return uint64(v[0]) + 1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, nil
 	}
 
+	v, err = counter.SeekBothRange(addr.Bytes(), hexutil.EncodeTs(idx))
+	if err != nil {
+		return 0, nil, err
+	}
+	if v == nil {
+		return 0, nil, nil
+	}
+
 	// Regular chunk
 	return binary.BigEndian.Uint64(v[:length.Ts]), v[length.Ts:], nil
 }
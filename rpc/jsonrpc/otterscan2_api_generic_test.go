@@ -0,0 +1,91 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/memdb"
+	"github.com/erigontech/erigon/ots/indexer"
+)
+
+type idxMaterializer struct{}
+
+func (idxMaterializer) Convert(_ context.Context, _ kv.Tx, idx uint64) (*uint64, error) {
+	return &idx, nil
+}
+
+func putTransferChunk(t *testing.T, tx kv.RwTx, addr common.Address, chunk uint64, idxs ...uint64) {
+	t.Helper()
+	k := append(common.CopyBytes(addr.Bytes()), hexutil.EncodeTs(chunk)...)
+	v := make([]byte, 0, len(idxs)*8)
+	for _, idx := range idxs {
+		v = binary.BigEndian.AppendUint64(v, idx)
+	}
+	require.NoError(t, tx.Put(kv.OtsERC20TransferIndex, k, v))
+}
+
+func TestGenericResultList(t *testing.T) {
+	ctx := context.Background()
+	_, tx := memdb.NewTestTx(t)
+
+	// Matches of the <= 256 optimization store the count - 1 as a 1 byte counter
+	single := common.HexToAddress("0x01")
+	putTransferChunk(t, tx, single, ^uint64(0), 7)
+	require.NoError(t, tx.Put(kv.OtsERC20TransferCounter, single.Bytes(), []byte{0}))
+
+	optimized := common.HexToAddress("0x02")
+	putTransferChunk(t, tx, optimized, ^uint64(0), 3, 9)
+	require.NoError(t, tx.Put(kv.OtsERC20TransferCounter, optimized.Bytes(), []byte{1}))
+
+	regular := common.HexToAddress("0x03")
+	putTransferChunk(t, tx, regular, 12, 10, 11, 12)
+	putTransferChunk(t, tx, regular, ^uint64(0), 20, 21)
+	require.NoError(t, tx.Put(kv.OtsERC20TransferCounter, regular.Bytes(), indexer.RegularCounterSerializer(3, hexutil.EncodeTs(12))))
+	require.NoError(t, tx.Put(kv.OtsERC20TransferCounter, regular.Bytes(), indexer.RegularCounterSerializer(5, hexutil.EncodeTs(^uint64(0)))))
+
+	list := func(addr common.Address, idx, count uint64) []uint64 {
+		res, err := genericResultList[uint64](ctx, tx, addr, idx, count, kv.OtsERC20TransferIndex, kv.OtsERC20TransferCounter, idxMaterializer{})
+		require.NoError(t, err)
+		ret := make([]uint64, 0, len(res))
+		for _, r := range res {
+			ret = append(ret, *r)
+		}
+		return ret
+	}
+
+	require.Equal(t, []uint64{7}, list(single, 0, 10))
+	require.Empty(t, list(single, 1, 10))
+
+	require.Equal(t, []uint64{3, 9}, list(optimized, 0, 10))
+	require.Equal(t, []uint64{9}, list(optimized, 1, 10))
+	require.Empty(t, list(optimized, 2, 10))
+
+	require.Equal(t, []uint64{10, 11, 12, 20, 21}, list(regular, 0, 10))
+	require.Equal(t, []uint64{11, 12}, list(regular, 1, 2))
+	require.Equal(t, []uint64{21}, list(regular, 4, 10))
+	require.Empty(t, list(regular, 5, 10))
+
+	require.Empty(t, list(common.HexToAddress("0x04"), 0, 10))
+}
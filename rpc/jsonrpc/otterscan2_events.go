@@ -0,0 +1,166 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/rawdb"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/ots/events"
+)
+
+type EventListResult struct {
+	BlocksSummary map[hexutil.Uint64]*BlockSummary `json:"blocksSummary"`
+	Results       []*EventMatch                    `json:"results"`
+}
+
+// EventMatch is a transaction containing at least one log of the requested event
+// and key.
+type EventMatch struct {
+	BlockNum hexutil.Uint64 `json:"blockNumber"`
+	Hash     common.Hash    `json:"transactionHash"`
+	Logs     []*EventLog    `json:"logs"`
+}
+
+type EventLog struct {
+	Log    *types.Log     `json:"log"`
+	Fields map[string]any `json:"fields"`
+}
+
+type eventSearchResultMaterializer struct {
+	api *Otterscan2APIImpl
+	def *events.Definition
+	key common.Address
+}
+
+func (m *eventSearchResultMaterializer) Convert(ctx context.Context, tx kv.Tx, txNum uint64) (*EventMatch, error) {
+	blockNum, ok, err := m.api._txNumReader.FindBlockNum(tx, txNum)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("couldn't find block of txNum %d", txNum)
+	}
+	blockHash, ok, err := m.api._blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("couldn't find canonical hash of block %d", blockNum)
+	}
+	minTxNum, err := m.api._txNumReader.Min(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	// txNum of the first block txn is minTxNum+1 (minTxNum is the begin-block system txn)
+	txn, err := m.api._blockReader.TxnByIdxInBlock(ctx, tx, blockNum, int(txNum-minTxNum-1))
+	if err != nil {
+		return nil, err
+	}
+	if txn == nil {
+		return nil, fmt.Errorf("couldn't find txn of txNum %d in block %d", txNum, blockNum)
+	}
+	receipt, ok, err := rawdb.ReadReceiptCacheV2(tx.(kv.TemporalTx), rawdb.RCacheV2Query{
+		BlockNum:      blockNum,
+		BlockHash:     blockHash,
+		TxnHash:       txn.Hash(),
+		TxNum:         txNum,
+		DontCalcBloom: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("couldn't find receipt of txNum %d", txNum)
+	}
+
+	result := &EventMatch{
+		BlockNum: hexutil.Uint64(blockNum),
+		Hash:     txn.Hash(),
+		Logs:     make([]*EventLog, 0),
+	}
+	for _, l := range receipt.Logs {
+		if key, ok := m.def.Match(l); !ok || key != m.key {
+			continue
+		}
+		fields, err := m.def.Decode(l)
+		if err != nil {
+			return nil, err
+		}
+		for name, v := range fields {
+			if b, ok := v.(*big.Int); ok {
+				fields[name] = (*hexutil.Big)(b)
+			}
+		}
+		result.Logs = append(result.Logs, &EventLog{l, fields})
+	}
+	return result, nil
+}
+
+// GetEvents returns the transactions containing logs of the registered event
+// indexer name whose key is the given address, see genericTransferList for the
+// pagination semantics.
+func (api *Otterscan2APIImpl) GetEvents(ctx context.Context, name string, key common.Address, idx, count uint64) (*EventListResult, error) {
+	if count > MAX_MATCH_COUNT {
+		return nil, fmt.Errorf("maximum allowed results: %v", MAX_MATCH_COUNT)
+	}
+	def, err := events.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var srm SearchResultMaterializer[EventMatch] = &eventSearchResultMaterializer{api, def, key}
+	ret, err := genericResultList(ctx, tx, key, idx, count, def.IndexTable(), def.CounterTable(), srm)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]hexutil.Uint64, 0, len(ret))
+	for _, r := range ret {
+		blocks = append(blocks, r.BlockNum)
+	}
+
+	blocksSummary, err := api.newBlocksSummaryFromResults(ctx, tx, blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &EventListResult{
+		BlocksSummary: blocksSummary,
+		Results:       ret,
+	}, nil
+}
+
+func (api *Otterscan2APIImpl) GetEventsCount(ctx context.Context, name string, key common.Address) (uint64, error) {
+	def, err := events.Get(name)
+	if err != nil {
+		return 0, err
+	}
+	return api.genericGetCount(ctx, key, def.CounterTable())
+}
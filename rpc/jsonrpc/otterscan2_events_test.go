@@ -0,0 +1,104 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/db/kv/kvcfg"
+	"github.com/erigontech/erigon/db/state/statecfg"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/ots/events"
+)
+
+// Emitted once by the Poly contract of the test chain, on block 10
+var polyDeployEvent = events.MustRegister(events.Event{
+	Name:      "TestPolyDeploy",
+	Signature: "DeployEvent(address d)",
+	Key:       events.EmitterKey,
+})
+
+func TestOtterscan2GetEvents(t *testing.T) {
+	ctx := context.Background()
+
+	// Event indexers read logs from the receipts cache history, which is only
+	// kept with --persist.receipts
+	rcacheCfg := statecfg.Schema.RCacheDomain
+	statecfg.EnableHistoricalRCache()
+	defer func() { statecfg.Schema.RCacheDomain = rcacheCfg }()
+
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	require.NoError(t, m.DB.Update(ctx, func(tx kv.RwTx) error {
+		return kvcfg.PersistReceipts.ForceWrite(tx, true)
+	}))
+
+	cfg := stagedsync.StageDbAwareCfg(m.DB, m.Dirs.Tmp, m.ChainConfig, m.BlockReader, m.Engine)
+	forward := stagedsync.GenericStageForwardFunc(ctx, cfg, stages.Execution, stagedsync.NewEventIndexerExecutor(polyDeployEvent))
+	require.NoError(t, forward(false, &stagedsync.StageState{ID: polyDeployEvent.StageID()}, nil, nil, nil, m.Log))
+
+	var poly common.Address
+	require.NoError(t, m.DB.View(ctx, func(tx kv.Tx) error {
+		progress, err := stages.GetStageProgress(tx, polyDeployEvent.StageID())
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), progress)
+
+		k, err := kv.FirstKey(tx, polyDeployEvent.IndexTable())
+		require.NoError(t, err)
+		require.NotNil(t, k)
+		poly = common.BytesToAddress(k[:20])
+		return nil
+	}))
+
+	api := NewOtterscan2API(newBaseApiForTest(m), m.DB)
+	count, err := api.GetEventsCount(ctx, polyDeployEvent.Name, poly)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	res, err := api.GetEvents(ctx, polyDeployEvent.Name, poly, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	match := res.Results[0]
+	require.Equal(t, hexutil.Uint64(10), match.BlockNum)
+	require.Contains(t, res.BlocksSummary, match.BlockNum)
+	require.Len(t, match.Logs, 1)
+	require.Equal(t, poly, match.Logs[0].Log.Address)
+	require.Equal(t, match.Hash, match.Logs[0].Log.TxHash)
+	require.IsType(t, common.Address{}, match.Logs[0].Fields["d"])
+
+	// Past the end
+	res, err = api.GetEvents(ctx, polyDeployEvent.Name, poly, 1, 10)
+	require.NoError(t, err)
+	require.Empty(t, res.Results)
+
+	_, err = api.GetEvents(ctx, "Missing", poly, 0, 10)
+	require.ErrorIs(t, err, events.ErrUnknownEvent)
+
+	u := &stagedsync.UnwindState{ID: polyDeployEvent.StageID(), UnwindPoint: 9, CurrentBlockNumber: 11}
+	require.NoError(t, stagedsync.GenericStageUnwindImpl(ctx, nil, cfg, u, stagedsync.NewEventIndexerUnwinder(polyDeployEvent)))
+
+	count, err = api.GetEventsCount(ctx, polyDeployEvent.Name, poly)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+}
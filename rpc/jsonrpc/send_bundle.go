@@ -0,0 +1,67 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+)
+
+// SendBundleArgs - arguments of `eth_sendBundle`
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp      *uint64         `json:"minTimestamp"`
+	MaxTimestamp      *uint64         `json:"maxTimestamp"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle implements eth_sendBundle. Bundle's txns are included into target block atomically and in given order, or not at all.
+// Available only when node builds blocks.
+func (api *APIImpl) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	req := &txpoolproto.SendBundleRequest{BlockNumber: uint64(args.BlockNumber)}
+	if args.MinTimestamp != nil {
+		req.MinTimestamp = *args.MinTimestamp
+	}
+	if args.MaxTimestamp != nil {
+		req.MaxTimestamp = *args.MaxTimestamp
+	}
+	for _, h := range args.RevertingTxHashes {
+		req.RevertingTxHashes = append(req.RevertingTxHashes, gointerfaces.ConvertHashToH256(h))
+	}
+	for i, encodedTx := range args.Txs {
+		if _, err := api.checkRawTransaction(ctx, encodedTx); err != nil {
+			return nil, fmt.Errorf("txn %d: %w", i, err)
+		}
+		req.Txs = append(req.Txs, encodedTx)
+	}
+
+	reply, err := api.mining.SendBundle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: gointerfaces.ConvertH256ToHash(reply.Hash)}, nil
+}
@@ -14,54 +14,61 @@ import (
 
 // SendRawTransaction implements eth_sendRawTransaction. Creates new message call transaction or a contract creation for previously-signed transactions.
 func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
-	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	txn, err := api.checkRawTransaction(ctx, encodedTx)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
+	hash := txn.Hash()
+	res, err := api.txPool.Add(ctx, &txpoolproto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if res.Imported[0] != txpoolproto.ImportResult_SUCCESS {
+		return hash, fmt.Errorf("%s: %s", txpoolproto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
+	}
+
+	return txn.Hash(), nil
+}
+
+// checkRawTransaction - decodes transaction submitted over RPC and checks fee cap, replay-protection and chain id
+func (api *APIImpl) checkRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (types.Transaction, error) {
+	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	if err != nil {
+		return nil, err
+	}
+
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(txn.GetFeeCap().ToBig(), txn.GetGasLimit(), api.FeeCap); err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
 	if !txn.Protected() && !api.AllowUnprotectedTxs {
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
 
-	// this has been moved to prior to adding of transactions to capture the
-	// pre state of the db - which is used for logging in the messages below
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
 	defer tx.Rollback()
 
 	cc, err := api.chainConfig(ctx, tx)
 	if err != nil {
-		return common.Hash{}, err
+		return nil, err
 	}
 
 	if txn.Protected() {
 		txnChainId := txn.GetChainID()
 		chainId := cc.ChainID
 		if chainId.Cmp(txnChainId.ToBig()) != 0 {
-			return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
+			return nil, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
 		}
 	}
-
-	hash := txn.Hash()
-	res, err := api.txPool.Add(ctx, &txpoolproto.AddRequest{RlpTxs: [][]byte{encodedTx}})
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	if res.Imported[0] != txpoolproto.ImportResult_SUCCESS {
-		return hash, fmt.Errorf("%s: %s", txpoolproto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
-	}
-
-	return txn.Hash(), nil
+	return txn, nil
 }
 
 // SendTransaction implements eth_sendTransaction. Creates new message call transaction or a contract creation if the data field contains code.
@@ -0,0 +1,110 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// TransactionConditions - options of `eth_sendRawTransactionConditional` (ERC-7796)
+type TransactionConditions struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Uint64                 `json:"blockNumberMin"`
+	BlockNumberMax *hexutil.Uint64                 `json:"blockNumberMax"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax"`
+}
+
+// KnownAccount - expected storage of account: storage root hash, or object of storage slot => value
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+func (a *KnownAccount) UnmarshalJSON(data []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(data, &root); err == nil {
+		a.StorageRoot = &root
+		return nil
+	}
+	return json.Unmarshal(data, &a.StorageSlots)
+}
+
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+func (c *TransactionConditions) toTxnConditions() *txpoolcfg.TxnConditions {
+	conditions := &txpoolcfg.TxnConditions{
+		BlockNumberMin: (*uint64)(c.BlockNumberMin),
+		BlockNumberMax: (*uint64)(c.BlockNumberMax),
+		TimestampMin:   (*uint64)(c.TimestampMin),
+		TimestampMax:   (*uint64)(c.TimestampMax),
+	}
+	if len(c.KnownAccounts) > 0 {
+		conditions.KnownAccounts = make(map[common.Address]txpoolcfg.KnownAccount, len(c.KnownAccounts))
+		for addr, account := range c.KnownAccounts {
+			conditions.KnownAccounts[addr] = txpoolcfg.KnownAccount{StorageRoot: account.StorageRoot, StorageSlots: account.StorageSlots}
+		}
+	}
+	return conditions
+}
+
+// SendRawTransactionConditional implements eth_sendRawTransactionConditional. Transaction is included only into block
+// on top of which given conditions hold: known storage of accounts, block number and timestamp ranges.
+// Txpool re-checks conditions on every new block and drops the transaction once they don't hold.
+// Conditional transactions are not gossiped to peers.
+func (api *APIImpl) SendRawTransactionConditional(ctx context.Context, encodedTx hexutil.Bytes, options TransactionConditions) (common.Hash, error) {
+	txn, err := api.checkRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	conditions := options.toTxnConditions()
+	if err := conditions.Validate(); err != nil {
+		if errors.Is(err, txpoolcfg.ErrConditionsTooCostly) {
+			return common.Hash{}, &rpc.CustomError{Code: rpc.ErrCodeConditionsLimitExceeded, Message: err.Error()}
+		}
+		return common.Hash{}, &rpc.InvalidParamsError{Message: err.Error()}
+	}
+
+	hash := txn.Hash()
+	res, err := api.txPool.AddConditional(ctx, &txpoolproto.AddConditionalRequest{RlpTx: encodedTx, Conditions: conditions.ToProto()})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if res.Imported[0] != txpoolproto.ImportResult_SUCCESS {
+		if res.Errors[0] == txpoolcfg.ConditionsNotMet.String() {
+			return hash, &rpc.CustomError{Code: rpc.ErrCodeConditionsNotMet, Message: res.Errors[0]}
+		}
+		return hash, fmt.Errorf("%s: %s", txpoolproto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
+	}
+	return hash, nil
+}
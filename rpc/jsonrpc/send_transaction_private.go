@@ -0,0 +1,52 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// SendPrivateRawTransaction implements eth_sendPrivateRawTransaction. Transaction is offered only to block builder of this node:
+// it's not gossiped to peers, nor exposed by txpool_content and newPendingTransactions subscriptions.
+// If maxBlockNumber is set, txpool drops the transaction once it's not included up to that block.
+func (api *APIImpl) SendPrivateRawTransaction(ctx context.Context, encodedTx hexutil.Bytes, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
+	txn, err := api.checkRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hash := txn.Hash()
+	res, err := api.txPool.AddPrivate(ctx, &txpoolproto.AddPrivateRequest{RlpTx: encodedTx, MaxBlockNumber: (*uint64)(maxBlockNumber)})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if res.Imported[0] != txpoolproto.ImportResult_SUCCESS {
+		if maxBlockNumber != nil && res.Errors[0] == txpoolcfg.ConditionsNotMet.String() {
+			return hash, &rpc.InvalidParamsError{Message: fmt.Sprintf("max block number %d is in the past", *maxBlockNumber)}
+		}
+		return hash, fmt.Errorf("%s: %s", txpoolproto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
+	}
+	return hash, nil
+}
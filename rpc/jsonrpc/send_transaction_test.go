@@ -18,6 +18,7 @@ package jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"math/big"
 	"testing"
@@ -28,8 +29,11 @@ import (
 
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
 	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/empty"
+	"github.com/erigontech/erigon/common/hexutil"
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/common/u256"
+	"github.com/erigontech/erigon/db/kv/kvcache"
 	"github.com/erigontech/erigon/execution/protocol/params"
 	"github.com/erigontech/erigon/execution/rlp"
 	"github.com/erigontech/erigon/execution/stagedsync/stageloop"
@@ -37,9 +41,12 @@ import (
 	"github.com/erigontech/erigon/execution/tests/mock"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/node/ethconfig"
+	"github.com/erigontech/erigon/node/gointerfaces"
 	"github.com/erigontech/erigon/node/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/node/gointerfaces/typesproto"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
@@ -179,6 +186,94 @@ func TestSendRawTransactionUnprotected(t *testing.T) {
 	}
 }
 
+func TestSendRawTransactionConditional(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	mockSentry, require := mock.MockWithTxPool(t), require.New(t)
+	oneBlockStep(mockSentry, require, t)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := txpoolproto.NewTxpoolClient(conn)
+	api := NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	blockNumberMin := hexutil.Uint64(1)
+	hash, err := api.SendRawTransactionConditional(ctx, encodedTransfer(mockSentry, require, 0), TransactionConditions{
+		KnownAccounts:  map[common.Address]KnownAccount{mockSentry.Address: {StorageSlots: map[common.Hash]common.Hash{{}: {}}}},
+		BlockNumberMin: &blockNumberMin,
+	})
+	require.NoError(err)
+	reply, err := txPool.Transactions(ctx, &txpoolproto.TransactionsRequest{Hashes: []*typesproto.H256{gointerfaces.ConvertHashToH256(hash)}})
+	require.NoError(err)
+	require.NotEmpty(reply.RlpTxs[0])
+
+	_, err = api.SendRawTransactionConditional(ctx, encodedTransfer(mockSentry, require, 1), TransactionConditions{
+		KnownAccounts: map[common.Address]KnownAccount{mockSentry.Address: {StorageSlots: map[common.Hash]common.Hash{{}: {1}}}},
+	})
+	var rpcErr *rpc.CustomError
+	require.ErrorAs(err, &rpcErr)
+	require.Equal(rpc.ErrCodeConditionsNotMet, rpcErr.Code)
+}
+
+func TestConditionsStorageRoot(t *testing.T) {
+	m, bankAddr, contractAddr, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	view, err := kvcache.NewDummy().View(ctx, tx)
+	require.NoError(t, err)
+
+	for _, addr := range []common.Address{contractAddr, bankAddr} {
+		proof, err := api.GetProof(ctx, addr, nil, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+		require.NoError(t, err)
+		root, err := view.StorageRoot(addr)
+		require.NoError(t, err)
+		require.Equal(t, proof.StorageHash, root)
+	}
+	root, err := view.StorageRoot(contractAddr)
+	require.NoError(t, err)
+	require.NotEqual(t, empty.RootHash, root)
+	root, err = view.StorageRoot(common.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddeaddead0"))
+	require.NoError(t, err)
+	require.Equal(t, empty.RootHash, root)
+}
+
+func TestSendPrivateRawTransaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	mockSentry, require := mock.MockWithTxPool(t), require.New(t)
+	oneBlockStep(mockSentry, require, t)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := txpoolproto.NewTxpoolClient(conn)
+	api := NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	maxBlockNumber := hexutil.Uint64(100)
+	hash, err := api.SendPrivateRawTransaction(ctx, encodedTransfer(mockSentry, require, 0), &maxBlockNumber)
+	require.NoError(err)
+	status, err := txPool.TxnStatus(ctx, &txpoolproto.TxnStatusRequest{Hash: gointerfaces.ConvertHashToH256(hash)})
+	require.NoError(err)
+	require.NotEmpty(status.SubPool)
+
+	maxBlockNumber = 0
+	_, err = api.SendPrivateRawTransaction(ctx, encodedTransfer(mockSentry, require, 1), &maxBlockNumber)
+	var rpcErr *rpc.InvalidParamsError
+	require.ErrorAs(err, &rpcErr)
+}
+
+func encodedTransfer(m *mock.MockSentry, require *require.Assertions, nonce uint64) []byte {
+	txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+	require.NoError(err)
+	buf := bytes.NewBuffer(nil)
+	require.NoError(txn.MarshalBinary(buf))
+	return buf.Bytes()
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) types.Transaction {
 	return pricedTransaction(nonce, gaslimit, u256.Num1, key)
 }
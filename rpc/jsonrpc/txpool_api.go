@@ -28,13 +28,21 @@ import (
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/node/gointerfaces"
 	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/rpc/jsonstream"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
 
 // TxPoolAPI the interface for the txpool_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*ethapi.RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr common.Address) (map[string]map[string]*ethapi.RPCTransaction, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
+	GetTransactionStatus(ctx context.Context, hash common.Hash) (*TxnStatus, error)
+	SubscribeStatus(ctx context.Context, hash common.Hash) (*rpc.Subscription, error)
+	Export(ctx context.Context, stream jsonstream.Stream) error
+	Import(ctx context.Context, txns []txpoolcfg.ExportedTxn) ([]string, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -217,40 +225,47 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
-
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.All(ctx, &txpoolproto.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
+
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
-
 	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	format := func(txn types.Transaction) string {
+		if to := txn.GetTo(); to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), txn.GetValue(), txn.GetGasLimit(), txn.GetFeeCap())
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", txn.GetValue(), txn.GetGasLimit(), txn.GetFeeCap())
 	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
-		content["pending"][account.Hex()] = dump
-	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+		var subPool map[string]map[string]string
+		switch reply.Txs[i].TxnType {
+		case txpoolproto.AllReply_PENDING:
+			subPool = content["pending"]
+		case txpoolproto.AllReply_BASE_FEE:
+			subPool = content["baseFee"]
+		case txpoolproto.AllReply_QUEUED:
+			subPool = content["queued"]
+		default:
+			continue
 		}
-		content["queued"][account.Hex()] = dump
+		account := common.Address(gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)).Hex()
+		if _, ok := subPool[account]; !ok {
+			subPool[account] = make(map[string]string, 4)
+		}
+		subPool[account][strconv.FormatUint(txn.GetNonce(), 10)] = format(txn)
 	}
-	return content
+	return content, nil
 }
-*/
@@ -18,10 +18,13 @@ package jsonrpc
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/holiman/uint256"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/require"
 
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
@@ -33,8 +36,11 @@ import (
 	"github.com/erigontech/erigon/execution/tests/mock"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/jsonstream"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
 
 func TestTxPoolContent(t *testing.T) {
@@ -78,3 +84,115 @@ func TestTxPoolContent(t *testing.T) {
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
 }
+
+func TestTxPoolTransactionStatus(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	newTxn := func(nonce uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *signer, m.Key)
+		require.NoError(err)
+		return txn
+	}
+	pending, gapped := newTxn(0), newTxn(2)
+	chain, err := blockgen.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 2, func(i int, b *blockgen.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		if i == 1 {
+			b.AddTx(pending)
+		}
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain.Slice(0, 1)))
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpoolproto.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpoolproto.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	for _, txn := range []types.Transaction{pending, gapped} {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		reply, err := txPool.Add(ctx, &txpoolproto.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+		require.NoError(err)
+		require.Equal([]txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS}, reply.Imported, reply.Errors)
+	}
+
+	status, err := api.GetTransactionStatus(ctx, pending.Hash())
+	require.NoError(err)
+	require.Equal(TxnStatusPending, status.Status)
+	require.Empty(status.NotPendingReasons)
+
+	status, err = api.GetTransactionStatus(ctx, gapped.Hash())
+	require.NoError(err)
+	require.Equal(TxnStatusQueued, status.Status)
+	require.Equal([]string{txpoolcfg.NotPendingNonceGap}, status.NotPendingReasons)
+
+	status, err = api.GetTransactionStatus(ctx, common.Hash{0xff})
+	require.NoError(err)
+	require.Equal(TxnStatusUnknown, status.Status)
+
+	// subscription follows transaction until it's mined
+	server := rpc.NewServer(50, false, false, true, m.Log, 0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("txpool", api))
+	client := rpc.DialInProc(server, m.Log)
+	defer client.Close()
+	statuses := make(chan *TxnStatus, 4)
+	sub, err := client.Subscribe(ctx, "txpool", statuses, "subscribeStatus", pending.Hash())
+	require.NoError(err)
+	defer sub.Unsubscribe()
+	require.Equal(TxnStatusPending, (<-statuses).Status)
+
+	require.NoError(m.InsertChain(chain.Slice(1, 2)))
+	select {
+	case status = <-statuses:
+		require.Equal(TxnStatusMined, status.Status)
+		require.Equal(hexutil.Uint64(2), *status.BlockNumber)
+	case err = <-sub.Err():
+		require.NoError(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no notification about mined transaction")
+	}
+}
+
+func TestTxPoolExportImport(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := blockgen.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *blockgen.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpoolproto.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpoolproto.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	var rlpTxns [][]byte
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		rlpTxns = append(rlpTxns, buf.Bytes())
+	}
+	reply, err := txPool.Add(ctx, &txpoolproto.AddRequest{RlpTxs: rlpTxns})
+	require.NoError(err)
+	require.Equal([]txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS, txpoolproto.ImportResult_SUCCESS}, reply.Imported, reply.Errors)
+
+	var buf bytes.Buffer
+	stream := jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096))
+	require.NoError(api.Export(ctx, stream))
+	require.NoError(stream.Flush())
+	var exported []txpoolcfg.ExportedTxn
+	require.NoError(json.Unmarshal(buf.Bytes(), &exported))
+	require.Len(exported, 2)
+	for i, txn := range exported {
+		require.Equal(hexutil.Bytes(rlpTxns[i]), txn.Rlp)
+		require.Equal(m.Address, txn.Sender)
+		require.True(txn.Local)
+	}
+
+	results, err := api.Import(ctx, exported)
+	require.NoError(err)
+	require.Equal([]string{txpoolcfg.AlreadyKnown.String(), txpoolcfg.AlreadyKnown.String()}, results)
+}
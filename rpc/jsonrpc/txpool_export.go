@@ -0,0 +1,75 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc/jsonstream"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// Export streams all transactions of the pool: RLP (blob transactions with sidecars), sender, local flag, arrival block and arrival sequence number.
+// Output of txpool_export is an input of txpool_import: to move transactions to pool of other node, for example on failover.
+func (api *TxPoolAPIImpl) Export(ctx context.Context, stream jsonstream.Stream) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	txns, err := api.pool.Export(ctx, &txpoolproto.ExportRequest{})
+	if err != nil {
+		return err
+	}
+	stream.WriteArrayStart()
+	defer stream.WriteArrayEnd()
+	for first := true; ; first = false {
+		txn, err := txns.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(txpoolcfg.ExportedTxnFromProto(txn))
+		if err != nil {
+			return err
+		}
+		if !first {
+			stream.WriteMore()
+		}
+		if _, err := stream.Write(b); err != nil {
+			return err
+		}
+	}
+}
+
+// Import adds transactions exported by txpool_export of other node: local ones as local transactions of this node,
+// remote ones as if they were received from peers, in order of their arrival to exporting pool. Senders are recovered from signatures:
+// exported ones aren't trusted from RPC. Returns final result of each transaction, in order of input.
+func (api *TxPoolAPIImpl) Import(ctx context.Context, txns []txpoolcfg.ExportedTxn) ([]string, error) {
+	req := &txpoolproto.ImportRequest{Txs: make([]*txpoolproto.ExportedTxn, len(txns))}
+	for i, txn := range txns {
+		req.Txs[i] = txn.ToProto()
+	}
+	reply, err := api.pool.Import(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return reply.Errors, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/dbg"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+const (
+	TxnStatusPending = "pending"
+	TxnStatusBaseFee = "baseFee"
+	TxnStatusQueued  = "queued"
+	TxnStatusDropped = "dropped"
+	TxnStatusMined   = "mined"
+	TxnStatusUnknown = "unknown"
+)
+
+// TxnStatus - result of `txpool_getTransactionStatus`
+type TxnStatus struct {
+	Hash              common.Hash     `json:"hash"`
+	Status            string          `json:"status"`                      // pending, baseFee, queued, dropped, mined, unknown
+	NotPendingReasons []string        `json:"notPendingReasons,omitempty"` // why txn is not in pending sub-pool: nonce gap, fee too low, balance, ...
+	DiscardReason     string          `json:"discardReason,omitempty"`
+	DiscardedAt       *hexutil.Uint64 `json:"discardedAt,omitempty"` // unix time
+	BlockNumber       *hexutil.Uint64 `json:"blockNumber,omitempty"` // for mined txn
+}
+
+func (s *TxnStatus) equal(other *TxnStatus) bool {
+	return s.Status == other.Status && s.DiscardReason == other.DiscardReason && slices.Equal(s.NotPendingReasons, other.NotPendingReasons)
+}
+
+// final - status of transaction will not change anymore
+func (s *TxnStatus) final() bool {
+	return s.Status == TxnStatusMined || s.Status == TxnStatusDropped
+}
+
+// GetTransactionStatus returns sub-pool of transaction and why it's not pending. Or why and when it was dropped from the pool.
+func (api *TxPoolAPIImpl) GetTransactionStatus(ctx context.Context, hash common.Hash) (*TxnStatus, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	blockNum, _, ok, err := api.txnLookup(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return &TxnStatus{Hash: hash, Status: TxnStatusMined, BlockNumber: (*hexutil.Uint64)(&blockNum)}, nil
+	}
+
+	reply, err := api.pool.TxnStatus(ctx, &txpoolproto.TxnStatusRequest{Hash: gointerfaces.ConvertHashToH256(hash)})
+	if err != nil {
+		return nil, err
+	}
+	discardReason := txpoolcfg.DiscardReason(reply.DiscardReason)
+	status := &TxnStatus{Hash: hash, Status: TxnStatusUnknown, NotPendingReasons: reply.NotPending}
+	switch {
+	case reply.SubPool == "Pending":
+		status.Status = TxnStatusPending
+	case reply.SubPool == "BaseFee":
+		status.Status = TxnStatusBaseFee
+	case reply.SubPool == "Queued":
+		status.Status = TxnStatusQueued
+	case discardReason == txpoolcfg.Mined: // mined in non-canonical block, or block is not indexed yet
+		status.Status = TxnStatusMined
+	case discardReason != txpoolcfg.NotSet:
+		status.Status = TxnStatusDropped
+	}
+	if discardReason != txpoolcfg.NotSet {
+		discardedAt := hexutil.Uint64(time.UnixMilli(int64(reply.DiscardedAt)).Unix())
+		status.DiscardReason, status.DiscardedAt = discardReason.String(), &discardedAt
+	}
+	return status, nil
+}
+
+const (
+	txnStatusRecheckMin     = time.Second // re-check interval after status change
+	txnStatusRecheckMax     = time.Minute // re-check interval grows up to this value while status doesn't change
+	txnStatusUnknownTimeout = time.Minute // subscription ends if transaction is unknown for this long
+)
+
+// SubscribeStatus send a notification each time when status of transaction changed: `txpool_subscribeStatus(hash)`.
+// Subscription ends when transaction is mined or dropped from the pool, or if the pool doesn't know the transaction for txnStatusUnknownTimeout.
+func (api *TxPoolAPIImpl) SubscribeStatus(ctx context.Context, hash common.Hash) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer dbg.LogPanic()
+		// txn moves between sub-pools on new blocks, but also may be dropped/promoted by other txns: also re-check by timer
+		var headers <-chan *types.Header
+		if api.filters != nil {
+			var id rpchelper.HeadsSubID
+			headers, id = api.filters.SubscribeNewHeads(32)
+			defer api.filters.UnsubscribeHeads(id)
+		}
+		recheck := txnStatusRecheckMin
+		timer := time.NewTimer(recheck)
+		defer timer.Stop()
+
+		var prev *TxnStatus
+		var unknownSince time.Time
+		for {
+			status, err := api.GetTransactionStatus(context.Background(), hash)
+			switch {
+			case err != nil:
+				log.Debug("[rpc] transaction status", "hash", hash, "err", err)
+				recheck = min(2*recheck, txnStatusRecheckMax)
+			case prev == nil || !prev.equal(status):
+				if err := notifier.Notify(rpcSub.ID, status); err != nil {
+					log.Debug("[rpc] error while notifying subscription", "err", err)
+				}
+				prev, recheck = status, txnStatusRecheckMin
+			default:
+				recheck = min(2*recheck, txnStatusRecheckMax)
+			}
+			if status != nil {
+				if status.final() {
+					return
+				}
+				if status.Status != TxnStatusUnknown {
+					unknownSince = time.Time{}
+				} else if unknownSince.IsZero() {
+					unknownSince = time.Now()
+				} else if time.Since(unknownSince) >= txnStatusUnknownTimeout {
+					return
+				}
+			}
+			timer.Reset(recheck)
+
+			select {
+			case _, ok := <-headers:
+				if !ok {
+					log.Debug("[rpc] new heads channel was closed")
+					return
+				}
+			case <-timer.C:
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
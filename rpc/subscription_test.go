@@ -178,6 +178,44 @@ func TestServerUnsubscribe(t *testing.T) {
 	}
 }
 
+// This test checks that subscription can be created by its method name: `nftest_someSubscription(...)`.
+func TestSubscriptionByMethodName(t *testing.T) {
+	logger := log.New()
+	p1, p2 := net.Pipe()
+	defer p2.Close()
+
+	server := newTestServer(logger)
+	server.RegisterName("nftest2", &notificationTestService{})
+	go server.ServeCodec(NewCodec(p1), 0)
+
+	p2.SetDeadline(time.Now().Add(10 * time.Second))
+	p2.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"nftest2_someSubscription","params":[2,10]}`))
+
+	var (
+		resps         = make(chan subConfirmation)
+		notifications = make(chan subscriptionResult)
+		errors        = make(chan error, 1)
+	)
+	go waitForMessages(json.NewDecoder(p2), resps, notifications, errors)
+
+	var sub subConfirmation
+	select {
+	case sub = <-resps:
+	case err := <-errors:
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case n := <-notifications:
+			if n.ID != string(sub.subid) {
+				t.Errorf("wrong subscription ID in notification: %s", n.ID)
+			}
+		case err := <-errors:
+			t.Fatal(err)
+		}
+	}
+}
+
 type subConfirmation struct {
 	reqid int
 	subid ID
@@ -0,0 +1,172 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// DefaultPoolLimit - max amount of bundles waiting for inclusion
+const DefaultPoolLimit = 10_000
+
+var (
+	ErrEmptyBundle   = errors.New("bundle has no transactions")
+	ErrNoTargetBlock = errors.New("bundle has no target block")
+	ErrBlockInPast   = errors.New("bundle target block is already built")
+	ErrBadTimestamps = errors.New("bundle minTimestamp is greater than maxTimestamp")
+	ErrAlreadyKnown  = errors.New("bundle already known")
+	ErrPoolOverflow  = errors.New("bundle pool is full")
+	ErrNotSupported  = errors.New("bundles are supported only by block builder")
+	ErrTxnType       = errors.New("blob and account abstraction txns are not supported in bundles")
+)
+
+// Bundle - ordered list of transactions which must be included into target block atomically (all or nothing) and in given order
+type Bundle struct {
+	Txns              []types.Transaction
+	BlockNumber       uint64        // target block
+	MinTimestamp      uint64        // 0 - no limit
+	MaxTimestamp      uint64        // 0 - no limit
+	RevertingTxHashes []common.Hash // txns which may revert. Reverted txn which is not in this list - invalidates whole bundle
+
+	hash common.Hash
+}
+
+// Hash - keccak256 of concatenated hashes of bundle's txns
+func (b *Bundle) Hash() common.Hash {
+	if b.hash == (common.Hash{}) {
+		buf := make([]byte, 0, len(b.Txns)*len(common.Hash{}))
+		for _, txn := range b.Txns {
+			h := txn.Hash()
+			buf = append(buf, h[:]...)
+		}
+		b.hash = crypto.Keccak256Hash(buf)
+	}
+	return b.hash
+}
+
+func (b *Bundle) CanRevert(txnHash common.Hash) bool {
+	return slices.Contains(b.RevertingTxHashes, txnHash)
+}
+
+// FitsTime - block with given timestamp satisfies bundle's timestamp range
+func (b *Bundle) FitsTime(blockTime uint64) bool {
+	if b.MinTimestamp > 0 && blockTime < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp > 0 && blockTime > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+func (b *Bundle) validate() error {
+	if len(b.Txns) == 0 {
+		return ErrEmptyBundle
+	}
+	if b.BlockNumber == 0 {
+		return ErrNoTargetBlock
+	}
+	if b.MinTimestamp > 0 && b.MaxTimestamp > 0 && b.MinTimestamp > b.MaxTimestamp {
+		return ErrBadTimestamps
+	}
+	for _, txn := range b.Txns {
+		if txn.Type() == types.BlobTxType || txn.Type() == types.AccountAbstractionTxType {
+			return fmt.Errorf("%w: %x", ErrTxnType, txn.Hash())
+		}
+	}
+	return nil
+}
+
+// Pool - bundles waiting for their target block. Bundles are not persisted.
+// Bundle stays in the pool until block builder moves to next blocks: builder may build many payloads for same block.
+type Pool struct {
+	lock     sync.Mutex
+	byHash   map[common.Hash]*Bundle
+	byBlock  map[uint64][]*Bundle // target block => bundles in arrival order
+	minBlock uint64               // bundles for blocks before it are already expired
+	limit    int
+}
+
+func NewPool(limit int) *Pool {
+	return &Pool{byHash: map[common.Hash]*Bundle{}, byBlock: map[uint64][]*Bundle{}, limit: limit}
+}
+
+func (p *Pool) Add(b *Bundle) (common.Hash, error) {
+	if err := b.validate(); err != nil {
+		return common.Hash{}, err
+	}
+	hash := b.Hash()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if b.BlockNumber < p.minBlock {
+		return hash, fmt.Errorf("%w: %d", ErrBlockInPast, b.BlockNumber)
+	}
+	if _, ok := p.byHash[hash]; ok {
+		return hash, ErrAlreadyKnown
+	}
+	if len(p.byHash) >= p.limit {
+		return hash, ErrPoolOverflow
+	}
+	p.byHash[hash] = b
+	p.byBlock[b.BlockNumber] = append(p.byBlock[b.BlockNumber], b)
+	return hash, nil
+}
+
+// Bundles - bundles which can be included into block with given number and timestamp. Forgets bundles of previous blocks.
+func (p *Pool) Bundles(blockNum, blockTime uint64) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.expire(blockNum)
+
+	var res []*Bundle
+	for _, b := range p.byBlock[blockNum] {
+		if b.FitsTime(blockTime) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func (p *Pool) expire(blockNum uint64) {
+	if blockNum <= p.minBlock {
+		return
+	}
+	for target, bundles := range p.byBlock {
+		if target >= blockNum {
+			continue
+		}
+		for _, b := range bundles {
+			delete(p.byHash, b.Hash())
+		}
+		delete(p.byBlock, target)
+	}
+	p.minBlock = blockNum
+}
+
+func (p *Pool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.byHash)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+func testBundle(blockNum uint64, nonces ...uint64) *Bundle {
+	b := &Bundle{BlockNumber: blockNum}
+	for _, nonce := range nonces {
+		b.Txns = append(b.Txns, types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(1), nil))
+	}
+	return b
+}
+
+func TestPool(t *testing.T) {
+	require := require.New(t)
+	p := NewPool(3)
+
+	_, err := p.Add(testBundle(10))
+	require.ErrorIs(err, ErrEmptyBundle)
+	_, err = p.Add(testBundle(0, 1))
+	require.ErrorIs(err, ErrNoTargetBlock)
+	bad := testBundle(10, 1)
+	bad.MinTimestamp, bad.MaxTimestamp = 20, 10
+	_, err = p.Add(bad)
+	require.ErrorIs(err, ErrBadTimestamps)
+
+	b1 := testBundle(10, 1, 2)
+	h1, err := p.Add(b1)
+	require.NoError(err)
+	require.Equal(b1.Hash(), h1)
+	_, err = p.Add(testBundle(10, 1, 2))
+	require.ErrorIs(err, ErrAlreadyKnown)
+
+	b2 := testBundle(10, 3)
+	b2.MinTimestamp, b2.MaxTimestamp = 100, 200
+	_, err = p.Add(b2)
+	require.NoError(err)
+	b3 := testBundle(11, 4)
+	_, err = p.Add(b3)
+	require.NoError(err)
+	_, err = p.Add(testBundle(12, 5))
+	require.ErrorIs(err, ErrPoolOverflow)
+
+	require.Equal([]*Bundle{b1}, p.Bundles(10, 50))
+	require.Equal([]*Bundle{b1, b2}, p.Bundles(10, 150))
+	require.Equal(3, p.Len())
+
+	// builder moved to next block: bundles of previous blocks are expired
+	require.Equal([]*Bundle{b3}, p.Bundles(11, 150))
+	require.Equal(1, p.Len())
+	_, err = p.Add(testBundle(10, 6))
+	require.ErrorIs(err, ErrBlockInPast)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain/networkname"
+	"github.com/erigontech/erigon/rpc/contracts"
+	"github.com/erigontech/erigon/txnprovider/shutter/internal/devkeyper"
+	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
+)
+
+func registerDevKeyperCmd(app *cli.App) {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:  "shutter-dev-keyper",
+		Usage: "stand in for shutter keypers of dev chain: deploy shutter contracts, register validators and release decryption keys every slot",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "el-url",
+				Usage: "execution layer url of dev chain node",
+				Value: "http://localhost:8545",
+			},
+			&cli.StringFlag{
+				Name:  "funder-key",
+				Usage: "hex private key of account which funds deployer of shutter contracts",
+				Value: hex.EncodeToString(crypto.FromECDSA(shuttercfg.DevFunderKey)),
+			},
+			&cli.Uint64Flag{
+				Name:  "num-keypers",
+				Usage: "number of keypers in keyper set",
+				Value: 3,
+			},
+			&cli.Uint64Flag{
+				Name:  "threshold",
+				Usage: "number of keypers needed to release decryption keys",
+				Value: 2,
+			},
+			&cli.Uint64Flag{
+				Name:  "validators",
+				Usage: "number of validators to register in validator registry",
+				Value: 4,
+			},
+			&cli.StringFlag{
+				Name:  "validator-info-file",
+				Usage: "path to write json of registered validators to (see shutter-validator-reg-check)",
+			},
+			&cli.Uint64Flag{
+				Name:  "p2p.listen.port",
+				Usage: "port to publish decryption keys on: nodes use it as bootstrap node",
+				Value: shuttercfg.DevKeyperP2pPort,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			funderKey, err := crypto.HexToECDSA(cliCtx.String("funder-key"))
+			if err != nil {
+				return fmt.Errorf("invalid funder key: %w", err)
+			}
+			opts := devkeyper.Options{
+				FunderKey:     funderKey,
+				NumKeypers:    cliCtx.Uint64("num-keypers"),
+				Threshold:     cliCtx.Uint64("threshold"),
+				NumValidators: cliCtx.Uint64("validators"),
+				ListenPort:    cliCtx.Uint64("p2p.listen.port"),
+			}
+			return devKeyper(cliCtx.Context, cliCtx.String("el-url"), cliCtx.String("validator-info-file"), opts)
+		},
+	})
+}
+
+func devKeyper(ctx context.Context, elUrl, validatorInfoFile string, opts devkeyper.Options) error {
+	logger := log.New()
+	logger.SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StderrHandler))
+	config := shuttercfg.ConfigByChainName(networkname.Dev)
+	keyper, err := devkeyper.New(logger, config, contracts.NewJsonRpcBackend(elUrl, logger), opts)
+	if err != nil {
+		return err
+	}
+	validators, err := keyper.Setup(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set up shutter on dev chain: %w", err)
+	}
+	if validatorInfoFile != "" {
+		b, err := json.MarshalIndent(validators, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(validatorInfoFile, b, 0o644); err != nil {
+			return err
+		}
+		logger.Info("validator info written", "file", validatorInfoFile)
+	}
+	logger.Info("shutter is set up: (re)start dev node with --shutter to use encrypted mempool")
+	return keyper.Run(ctx)
+}
@@ -22,4 +22,5 @@ import (
 
 func RegisterCmds(app *cli.App) {
 	registerValidatorRegCheckCmd(app)
+	registerDevKeyperCmd(app)
 }
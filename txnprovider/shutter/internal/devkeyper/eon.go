@@ -0,0 +1,161 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devkeyper
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/txnprovider/shutter"
+	shuttercrypto "github.com/erigontech/erigon/txnprovider/shutter/internal/crypto"
+	shutterproto "github.com/erigontech/erigon/txnprovider/shutter/internal/proto"
+)
+
+// EonKeys - threshold keys of one eon. Dev keyper is a trusted dealer: it generates shares of all keypers itself
+type EonKeys struct {
+	Index     shutter.EonIndex
+	Threshold uint64
+	Keypers   []Keyper
+	PublicKey *shuttercrypto.EonPublicKey
+}
+
+type Keyper struct {
+	Index          int
+	PrivateKey     *ecdsa.PrivateKey
+	SecretKeyShare *shuttercrypto.EonSecretKeyShare
+}
+
+func (k Keyper) Address() common.Address {
+	return crypto.PubkeyToAddress(k.PrivateKey.PublicKey)
+}
+
+func GenerateEonKeys(index shutter.EonIndex, threshold, numKeypers uint64) (EonKeys, error) {
+	polynomials := make([]*shuttercrypto.Polynomial, numKeypers)
+	gammas := make([]*shuttercrypto.Gammas, numKeypers)
+	for i := range polynomials {
+		polynomial, err := shuttercrypto.RandomPolynomial(rand.Reader, threshold-1)
+		if err != nil {
+			return EonKeys{}, err
+		}
+		polynomials[i] = polynomial
+		gammas[i] = polynomial.Gammas()
+	}
+
+	keypers := make([]Keyper, numKeypers)
+	for i := range keypers {
+		privKey, err := crypto.GenerateKey()
+		if err != nil {
+			return EonKeys{}, err
+		}
+		keyperX := shuttercrypto.KeyperX(i)
+		polynomialEvals := make([]*big.Int, numKeypers)
+		for j, polynomial := range polynomials {
+			polynomialEvals[j] = polynomial.Eval(keyperX)
+		}
+		keypers[i] = Keyper{
+			Index:          i,
+			PrivateKey:     privKey,
+			SecretKeyShare: shuttercrypto.ComputeEonSecretKeyShare(polynomialEvals),
+		}
+	}
+
+	return EonKeys{
+		Index:     index,
+		Threshold: threshold,
+		Keypers:   keypers,
+		PublicKey: shuttercrypto.ComputeEonPublicKey(gammas),
+	}, nil
+}
+
+func (k EonKeys) Members() []common.Address {
+	members := make([]common.Address, len(k.Keypers))
+	for i, keyper := range k.Keypers {
+		members[i] = keyper.Address()
+	}
+	return members
+}
+
+// DecryptionKeysEnvelope - message of keypers which releases keys of given txns for slot: ready to be published to shutter.DecryptionKeysTopic
+func (k EonKeys) DecryptionKeysEnvelope(instanceId, slot, txnPointer uint64, ips shutter.IdentityPreimages) ([]byte, error) {
+	signers := k.Keypers[:k.Threshold]
+	// keys message always starts with placeholder key of slot
+	ips = append(shutter.IdentityPreimages{SlotIdentityPreimage(slot)}, ips...)
+	keys := make([]*shutterproto.Key, len(ips))
+	for i, ip := range ips {
+		epochId := shuttercrypto.ComputeEpochID(ip[:])
+		shares := make([]*shuttercrypto.EpochSecretKeyShare, len(signers))
+		indices := make([]int, len(signers))
+		for j, signer := range signers {
+			shares[j] = shuttercrypto.ComputeEpochSecretKeyShare(signer.SecretKeyShare, epochId)
+			indices[j] = signer.Index
+		}
+		epochSecretKey, err := shuttercrypto.ComputeEpochSecretKey(indices, shares, k.Threshold)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = &shutterproto.Key{IdentityPreimage: ip[:], Key: epochSecretKey.Marshal()}
+	}
+
+	signatureData := shutter.DecryptionKeysSignatureData{
+		InstanceId:        instanceId,
+		Eon:               k.Index,
+		Slot:              slot,
+		TxnPointer:        txnPointer,
+		IdentityPreimages: ips.ToListSSZ(),
+	}
+	signerIndices := make([]uint64, len(signers))
+	signatures := make([][]byte, len(signers))
+	for i, signer := range signers {
+		signerIndices[i] = uint64(signer.Index)
+		var err error
+		if signatures[i], err = signatureData.Sign(signer.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := anypb.New(&shutterproto.DecryptionKeys{
+		InstanceId: instanceId,
+		Eon:        uint64(k.Index),
+		Keys:       keys,
+		Extra: &shutterproto.DecryptionKeys_Gnosis{
+			Gnosis: &shutterproto.GnosisDecryptionKeysExtra{
+				Slot:          slot,
+				TxPointer:     txnPointer,
+				SignerIndices: signerIndices,
+				Signatures:    signatures,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&shutterproto.Envelope{Version: shutterproto.EnvelopeVersion, Message: msg})
+}
+
+// SlotIdentityPreimage - 32 zero bytes and slot as 20 bytes big endian: it's always ordered before
+// identity preimages of txns, because sender addresses can't be that small
+func SlotIdentityPreimage(slot uint64) *shutter.IdentityPreimage {
+	var ip shutter.IdentityPreimage
+	new(big.Int).SetUint64(slot).FillBytes(ip[32:])
+	return &ip
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devkeyper_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/execution/chain/networkname"
+	"github.com/erigontech/erigon/txnprovider/shutter"
+	shuttercrypto "github.com/erigontech/erigon/txnprovider/shutter/internal/crypto"
+	"github.com/erigontech/erigon/txnprovider/shutter/internal/devkeyper"
+	shutterproto "github.com/erigontech/erigon/txnprovider/shutter/internal/proto"
+	"github.com/erigontech/erigon/txnprovider/shutter/internal/testhelpers"
+	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
+)
+
+func TestDecryptionKeysEnvelope(t *testing.T) {
+	config := shuttercfg.ConfigByChainName(networkname.Dev)
+	eonKeys, err := devkeyper.GenerateEonKeys(2, 2, 3)
+	require.NoError(t, err)
+	eon := shutter.Eon{Index: eonKeys.Index, Key: eonKeys.PublicKey.Marshal(), Threshold: eonKeys.Threshold, Members: eonKeys.Members()}
+
+	var identityPrefix [32]byte
+	_, err = rand.Read(identityPrefix[:])
+	require.NoError(t, err)
+	ip := shutter.IdentityPreimageFromSenderPrefix(identityPrefix, common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	sigma, err := shuttercrypto.RandomSigma(rand.Reader)
+	require.NoError(t, err)
+	plaintext := []byte("encrypted txn")
+	encrypted := shuttercrypto.Encrypt(plaintext, eonKeys.PublicKey, shuttercrypto.ComputeEpochID(ip[:]), sigma)
+
+	slotCalculator := shutter.NewBeaconChainSlotCalculator(config.BeaconChainGenesisTimestamp, config.SecondsPerSlot)
+	slot := slotCalculator.CalcCurrentSlot() + 1
+	envelope, err := eonKeys.DecryptionKeysEnvelope(config.InstanceId, slot, 5, shutter.IdentityPreimages{ip})
+	require.NoError(t, err)
+	msg, err := shutterproto.UnmarshallDecryptionKeys(envelope)
+	require.NoError(t, err)
+
+	// keys of dev keyper must pass validation of nodes
+	eonTracker := testhelpers.MockEonTrackerCreator(
+		testhelpers.WithCurrentEonMockResult(testhelpers.CurrentEonMockResult{Eon: eon, Ok: true}),
+		testhelpers.WithRecentEonMockResult(testhelpers.RecentEonMockResult{Eon: eon, Ok: true}),
+	)(t)
+	validator := shutter.NewDecryptionKeysValidator(config, slotCalculator, eonTracker)
+	require.NoError(t, validator.Validate(msg))
+
+	require.Equal(t, slot, msg.GetGnosis().Slot)
+	require.Equal(t, uint64(5), msg.GetGnosis().TxPointer)
+	require.Len(t, msg.Keys, 2)
+	require.Equal(t, devkeyper.SlotIdentityPreimage(slot)[:], msg.Keys[0].IdentityPreimage)
+	require.Equal(t, ip[:], msg.Keys[1].IdentityPreimage)
+	epochSecretKey := new(shuttercrypto.EpochSecretKey)
+	require.NoError(t, epochSecretKey.Unmarshal(msg.Keys[1].Key))
+	decrypted, err := encrypted.Decrypt(epochSecretKey)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devkeyper
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/abi/bind"
+	"github.com/erigontech/erigon/txnprovider/shutter"
+	shuttercontracts "github.com/erigontech/erigon/txnprovider/shutter/internal/contracts"
+	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
+)
+
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BlockNumber(ctx context.Context) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNum *big.Int) (*big.Int, error)
+}
+
+type Options struct {
+	FunderKey     *ecdsa.PrivateKey // funds deployer of contracts, if it has no funds
+	NumKeypers    uint64
+	Threshold     uint64
+	NumValidators uint64 // registered in validator registry by Setup
+	ListenPort    uint64
+}
+
+// DevKeyper - stands in for keypers of dev chain: deploys shutter contracts, generates eon keys and registers validators (see Setup),
+// then releases decryption keys of all submitted encrypted txns every slot (see Run). It keeps no secrets: for testing only.
+type DevKeyper struct {
+	logger         log.Logger
+	config         shuttercfg.Config
+	backend        Backend
+	opts           Options
+	deployerKey    *ecdsa.PrivateKey
+	deployer       common.Address
+	slotCalculator shutter.SlotCalculator
+	eonKeys        EonKeys
+}
+
+func New(logger log.Logger, config shuttercfg.Config, backend Backend, opts Options) (*DevKeyper, error) {
+	if opts.Threshold == 0 || opts.Threshold > opts.NumKeypers {
+		return nil, fmt.Errorf("threshold must be in [1, %d], got %d", opts.NumKeypers, opts.Threshold)
+	}
+	return &DevKeyper{
+		logger:         logger,
+		config:         config,
+		backend:        backend,
+		opts:           opts,
+		deployerKey:    shuttercfg.DevKeyperDeployerKey,
+		deployer:       crypto.PubkeyToAddress(shuttercfg.DevKeyperDeployerKey.PublicKey),
+		slotCalculator: shutter.NewBeaconChainSlotCalculator(config.BeaconChainGenesisTimestamp, config.SecondsPerSlot),
+	}, nil
+}
+
+// Setup - prepares chain for shutter: deploys contracts (if needed), adds new eon and registers validators.
+// Eon keys live only in memory of dev keyper: each run starts a new eon.
+func (k *DevKeyper) Setup(ctx context.Context) (shutter.ValidatorInfo, error) {
+	if err := k.fundDeployer(ctx); err != nil {
+		return nil, err
+	}
+	if err := k.deployContracts(ctx); err != nil {
+		return nil, err
+	}
+	eonKeys, err := k.addEon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	k.eonKeys = eonKeys
+	return k.registerValidators(ctx)
+}
+
+// Run - publishes decryption keys every slot, for txns submitted to sequencer contract in current eon. Must be called after Setup.
+func (k *DevKeyper) Run(ctx context.Context) error {
+	topic, closeHost, err := k.joinDecryptionKeysTopic(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeHost()
+
+	sequencer, err := shuttercontracts.NewSequencer(common.HexToAddress(k.config.SequencerContractAddress), k.backend)
+	if err != nil {
+		return err
+	}
+	fromBlock, err := k.backend.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	var txnPointer uint64
+	var lastSlot uint64
+	submitted := map[uint64]*shutter.IdentityPreimage{} // by txn index in eon
+	for {
+		// keys for next slot are released in the middle of current one: before block of next slot is built.
+		// Each slot is handled once: after release in the middle of current slot, next slot is the one after it
+		nextSlot := max(k.slotCalculator.CalcCurrentSlot(), lastSlot) + 1
+		releaseAt := time.Unix(int64(k.slotCalculator.CalcSlotStartTimestamp(nextSlot)), 0).Add(-time.Duration(k.config.SecondsPerSlot) * time.Second / 2)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(releaseAt)):
+		}
+		lastSlot = nextSlot
+
+		head, err := k.backend.BlockNumber(ctx)
+		if err != nil {
+			k.logger.Warn("[shutter-dev-keyper] can't get head", "err", err)
+			continue
+		}
+		if head >= fromBlock {
+			if err := k.collectSubmitted(ctx, sequencer, fromBlock, head, submitted); err != nil {
+				k.logger.Warn("[shutter-dev-keyper] can't get submitted txns", "err", err)
+				continue
+			}
+			fromBlock = head + 1
+		}
+
+		var ips shutter.IdentityPreimages
+		for uint64(len(ips)) < k.config.MaxNumKeysPerMessage-1 { // minus placeholder key of slot
+			ip, ok := submitted[txnPointer+uint64(len(ips))]
+			if !ok {
+				break
+			}
+			ips = append(ips, ip)
+		}
+		envelope, err := k.eonKeys.DecryptionKeysEnvelope(k.config.InstanceId, nextSlot, txnPointer, ips)
+		if err != nil {
+			return err
+		}
+		if err := topic.Publish(ctx, envelope); err != nil {
+			k.logger.Warn("[shutter-dev-keyper] can't publish decryption keys", "err", err)
+			continue
+		}
+		k.logger.Info("[shutter-dev-keyper] decryption keys published", "slot", nextSlot, "eon", k.eonKeys.Index, "txnPointer", txnPointer, "keys", len(ips))
+		for i := range ips {
+			delete(submitted, txnPointer+uint64(i))
+		}
+		txnPointer += uint64(len(ips))
+	}
+}
+
+func (k *DevKeyper) collectSubmitted(
+	ctx context.Context,
+	sequencer *shuttercontracts.Sequencer,
+	fromBlock, toBlock uint64,
+	submitted map[uint64]*shutter.IdentityPreimage,
+) error {
+	it, err := sequencer.FilterTransactionSubmitted(&bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		if shutter.EonIndex(it.Event.Eon) != k.eonKeys.Index {
+			continue
+		}
+		submitted[it.Event.TxIndex] = shutter.IdentityPreimageFromSenderPrefix(it.Event.IdentityPrefix, it.Event.Sender)
+	}
+	return it.Error()
+}
+
+func (k *DevKeyper) joinDecryptionKeysTopic(ctx context.Context) (*pubsub.Topic, func(), error) {
+	privKey, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(crypto.FromECDSA(shuttercfg.DevKeyperP2pKey))
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", k.opts.ListenPort))
+	if err != nil {
+		return nil, nil, err
+	}
+	host, err := libp2p.New(
+		libp2p.Identity(privKey),
+		libp2p.ListenAddrs(addr),
+		libp2p.UserAgent("erigon/shutter-dev-keyper"),
+		libp2p.ProtocolVersion(shutter.ProtocolVersion),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeHost := func() {
+		if err := host.Close(); err != nil {
+			k.logger.Warn("[shutter-dev-keyper] can't close p2p host", "err", err)
+		}
+	}
+	gossipSub, err := pubsub.NewGossipSub(ctx, host)
+	if err != nil {
+		closeHost()
+		return nil, nil, err
+	}
+	topic, err := gossipSub.Join(shutter.DecryptionKeysTopic)
+	if err != nil {
+		closeHost()
+		return nil, nil, err
+	}
+	k.logger.Info("[shutter-dev-keyper] p2p host started", "addr", addr, "id", host.ID())
+	return topic, closeHost, nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devkeyper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/common/race"
+	"github.com/erigontech/erigon/common/testlog"
+	"github.com/erigontech/erigon/execution/abi/bind"
+	"github.com/erigontech/erigon/execution/chain/networkname"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	executiontests "github.com/erigontech/erigon/execution/tests"
+	"github.com/erigontech/erigon/execution/tests/testutil"
+	"github.com/erigontech/erigon/node/ethconfig"
+	"github.com/erigontech/erigon/txnprovider/shutter"
+	shuttercontracts "github.com/erigontech/erigon/txnprovider/shutter/internal/contracts"
+	"github.com/erigontech/erigon/txnprovider/shutter/internal/devkeyper"
+	"github.com/erigontech/erigon/txnprovider/shutter/internal/testhelpers"
+	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
+)
+
+func TestDevKeyper(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	//goland:noinspection GoBoolExpressions
+	if race.Enabled && runtime.GOOS == "darwin" {
+		// We run race detector for medium tests which fails on macOS.
+		t.Skip("issue #15007")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	logger := testlog.Logger(t, log.LvlDebug)
+	bootstrapConnected := newBootstrapConnectedHandler(logger.GetHandler())
+	logger.SetHandler(bootstrapConnected)
+	dataDir := t.TempDir()
+	// genesis and shutter config of `erigon --chain=dev --dev.pos --shutter`
+	genesis := chainspec.DeveloperPoSGenesisBlock()
+	chainConfig := genesis.Config
+	// pre-funded in dev genesis: funds deployer of dev keyper and encryptor of txns
+	funderKey := shuttercfg.DevFunderKey
+	// dev keyper deploys shutter contracts, so we start an engine api tester without shutter first
+	eat := executiontests.InitialiseEngineApiTester(t, executiontests.EngineApiTesterInitArgs{
+		Logger:      logger,
+		DataDir:     dataDir,
+		Genesis:     genesis,
+		CoinbaseKey: funderKey,
+	})
+	shutterPort, err := testutil.NextFreePort()
+	require.NoError(t, err)
+	keyperPort, err := testutil.NextFreePort()
+	require.NoError(t, err)
+	keyperPeerAddr, err := devKeyperPeerAddr(keyperPort)
+	require.NoError(t, err)
+	shutterConfig := shuttercfg.ConfigByChainName(networkname.Dev)
+	require.Equal(t, uint256.MustFromBig(chainConfig.ChainID), shutterConfig.ChainId)
+	// ports and node key as set by cli, shorter slots to speed up the test
+	shutterConfig.BootstrapNodes = []string{keyperPeerAddr}
+	shutterConfig.PrivateKey = eat.NodeKey
+	shutterConfig.ListenPort = uint64(shutterPort)
+	shutterConfig.SecondsPerSlot = 1
+	slotCalculator := shutter.NewBeaconChainSlotCalculator(shutterConfig.BeaconChainGenesisTimestamp, shutterConfig.SecondsPerSlot)
+	cl := testhelpers.NewMockCl(logger, eat.MockCl, slotCalculator)
+	err = cl.Initialise(ctx)
+	require.NoError(t, err)
+	backend := &restartableBackend{Backend: eat.ContractBackend}
+	keyper, err := devkeyper.New(logger, shutterConfig, backend, devkeyper.Options{
+		FunderKey:     funderKey,
+		NumKeypers:    3,
+		Threshold:     2,
+		NumValidators: 2,
+		ListenPort:    uint64(keyperPort),
+	})
+	require.NoError(t, err)
+	// setup waits for its txns to be mined: blocks are built in the background until it is done
+	validators := setUpWhileBuildingBlocks(ctx, t, keyper, cl)
+	require.Len(t, validators, 2)
+	registryChecker := shutter.NewValidatorRegistryChecker(
+		logger,
+		eat.ContractBackend,
+		common.HexToAddress(shutterConfig.ValidatorRegistryContractAddress),
+		shutterConfig.ChainId,
+	)
+	registered, err := registryChecker.FilterRegistered(ctx, validators)
+	require.NoError(t, err)
+	for index := range validators {
+		require.Contains(t, registered, index)
+	}
+	eon := currentEon(ctx, t, eat.ContractBackend, shutterConfig)
+	encryptorPrivKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	transactor := executiontests.NewTransactor(eat.RpcApiClient, chainConfig.ChainID)
+	oneEth := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	topUp, err := transactor.SubmitSimpleTransfer(funderKey, crypto.PubkeyToAddress(encryptorPrivKey.PublicKey), oneEth)
+	require.NoError(t, err)
+	block, err := cl.BuildBlock(ctx)
+	require.NoError(t, err)
+	err = eat.TxnInclusionVerifier.VerifyTxnsInclusion(ctx, block, topUp.Hash())
+	require.NoError(t, err)
+
+	// now that shutter is set up on chain - we can restart erigon with shutter enabled and run the dev keyper
+	eat.Close(t)
+	eat = executiontests.InitialiseEngineApiTester(t, executiontests.EngineApiTesterInitArgs{
+		Logger:           logger,
+		DataDir:          dataDir,
+		Genesis:          genesis,
+		CoinbaseKey:      funderKey,
+		EthConfigTweaker: func(ethConfig *ethconfig.Config) { ethConfig.Shutter = shutterConfig },
+		MockClState:      eat.MockCl.State(),
+	})
+	// need to recreate these since we have a new engine api tester with new ports
+	backend.Backend = eat.ContractBackend
+	cl = testhelpers.NewMockCl(logger, eat.MockCl, slotCalculator)
+	err = cl.Initialise(ctx)
+	require.NoError(t, err)
+	transactor = executiontests.NewTransactor(eat.RpcApiClient, chainConfig.ChainID)
+	keyperErr := make(chan error, 1)
+	keyperCtx, stopKeyper := context.WithCancel(ctx)
+	go func() { keyperErr <- keyper.Run(keyperCtx) }()
+	t.Cleanup(func() {
+		stopKeyper()
+		require.ErrorIs(t, <-keyperErr, context.Canceled)
+	})
+	// keys released before the node subscribes to them are lost: submit encrypted txn once it is connected
+	err = bootstrapConnected.wait(ctx, 30*time.Second)
+	require.NoError(t, err)
+
+	encryptedTransactor := testhelpers.NewEncryptedTransactor(transactor, encryptorPrivKey, shutterConfig.SequencerContractAddress, eat.ContractBackend)
+	receiver := common.Address{1}
+	encryptedSubmission, err := encryptedTransactor.SubmitEncryptedTransfer(ctx, funderKey, receiver, big.NewInt(1), eon)
+	require.NoError(t, err)
+	block, err = cl.BuildBlock(ctx)
+	require.NoError(t, err)
+	err = eat.TxnInclusionVerifier.VerifyTxnsInclusion(ctx, block, encryptedSubmission.SubmissionTxn.Hash())
+	require.NoError(t, err)
+	// dev keyper releases decryption key in the middle of the slot after submission: decrypted txn is at the top of next block
+	block, err = cl.BuildBlock(ctx)
+	require.NoError(t, err)
+	err = eat.TxnInclusionVerifier.VerifyTxnsOrderedInclusion(
+		ctx,
+		block,
+		executiontests.OrderedInclusion{TxnIndex: 0, TxnHash: encryptedSubmission.OriginalTxn.Hash()},
+	)
+	require.NoError(t, err)
+}
+
+func setUpWhileBuildingBlocks(ctx context.Context, t *testing.T, keyper *devkeyper.DevKeyper, cl *testhelpers.MockCl) shutter.ValidatorInfo {
+	blocksCtx, stopBlocks := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	var blocksErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			_, err := cl.BuildBlock(blocksCtx)
+			if blocksCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				blocksErr = err
+				return
+			}
+		}
+	}()
+	validators, err := keyper.Setup(ctx)
+	stopBlocks()
+	wg.Wait()
+	require.NoError(t, err)
+	require.NoError(t, blocksErr)
+	return validators
+}
+
+func currentEon(ctx context.Context, t *testing.T, backend bind.ContractBackend, config shuttercfg.Config) shutter.Eon {
+	callOpts := &bind.CallOpts{Context: ctx}
+	ksm, err := shuttercontracts.NewKeyperSetManager(common.HexToAddress(config.KeyperSetManagerContractAddress), backend)
+	require.NoError(t, err)
+	numKeyperSets, err := ksm.GetNumKeyperSets(callOpts)
+	require.NoError(t, err)
+	require.Positive(t, numKeyperSets)
+	keyBroadcast, err := shuttercontracts.NewKeyBroadcastContract(common.HexToAddress(config.KeyBroadcastContractAddress), backend)
+	require.NoError(t, err)
+	index := numKeyperSets - 1
+	key, err := keyBroadcast.GetEonKey(callOpts, index)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+	return shutter.Eon{Index: shutter.EonIndex(index), Key: key}
+}
+
+func devKeyperPeerAddr(port int) (string, error) {
+	privKey, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(crypto.FromECDSA(shuttercfg.DevKeyperP2pKey))
+	if err != nil {
+		return "", err
+	}
+	peerId, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", port, peerId), nil
+}
+
+// restartableBackend - dev keyper outlives engine api tester it is set up with: backend is swapped on restart
+type restartableBackend struct {
+	devkeyper.Backend
+}
+
+// bootstrapConnectedHandler - signals once shutter node connects to its bootstrap node: the dev keyper
+type bootstrapConnectedHandler struct {
+	log.Handler
+	once      sync.Once
+	connected chan struct{}
+}
+
+func newBootstrapConnectedHandler(handler log.Handler) *bootstrapConnectedHandler {
+	return &bootstrapConnectedHandler{Handler: handler, connected: make(chan struct{})}
+}
+
+func (h *bootstrapConnectedHandler) Log(r *log.Record) error {
+	if r.Msg == "connected to bootstrap node" {
+		h.once.Do(func() { close(h.connected) })
+	}
+	return h.Handler.Log(r)
+}
+
+func (h *bootstrapConnectedHandler) wait(ctx context.Context, timeout time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(timeout):
+		return errors.New("shutter node did not connect to dev keyper")
+	case <-h.connected:
+		return nil
+	}
+}
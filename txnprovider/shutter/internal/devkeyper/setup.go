@@ -0,0 +1,306 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devkeyper
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+	blst "github.com/supranational/blst/bindings/go"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/execution/abi/bind"
+	"github.com/erigontech/erigon/execution/types"
+	"github.com/erigontech/erigon/txnprovider/shutter"
+	shuttercontracts "github.com/erigontech/erigon/txnprovider/shutter/internal/contracts"
+)
+
+const (
+	// eonActivationDelay - blocks between adding keyper set and its activation: enough to broadcast eon key before it
+	eonActivationDelay = 3
+	// registrationDst - domain of BLS signatures of validator registrations
+	registrationDst = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var deployerFunding = new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)) // 10 ETH
+
+func (k *DevKeyper) transactOpts(ctx context.Context) (*bind.TransactOpts, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(k.deployerKey, k.config.ChainId.ToBig())
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+func (k *DevKeyper) waitMined(ctx context.Context, txns ...types.Transaction) error {
+	for _, txn := range txns {
+		receipt, err := bind.WaitMined(ctx, k.backend, txn)
+		if err != nil {
+			return err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("txn %s failed", txn.Hash())
+		}
+	}
+	return nil
+}
+
+// fundDeployer - deployer of contracts is a dedicated account (so that addresses of contracts are known upfront): top it up from funder
+func (k *DevKeyper) fundDeployer(ctx context.Context) error {
+	balance, err := k.backend.BalanceAt(ctx, k.deployer, nil)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(deployerFunding) >= 0 {
+		return nil
+	}
+	if k.opts.FunderKey == nil {
+		return fmt.Errorf("deployer %s has no funds", k.deployer)
+	}
+	funder := crypto.PubkeyToAddress(k.opts.FunderKey.PublicKey)
+	nonce, err := k.backend.PendingNonceAt(ctx, funder)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := k.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	txn := types.NewTransaction(nonce, k.deployer, uint256.MustFromBig(deployerFunding), 21_000, uint256.MustFromBig(gasPrice), nil)
+	signedTxn, err := types.SignTx(txn, *types.LatestSignerForChainID(k.config.ChainId.ToBig()), k.opts.FunderKey)
+	if err != nil {
+		return err
+	}
+	if err := k.backend.SendTransaction(ctx, signedTxn); err != nil {
+		return fmt.Errorf("fund deployer: %w", err)
+	}
+	k.logger.Info("[shutter-dev-keyper] funding deployer", "deployer", k.deployer, "funder", funder, "txn", signedTxn.Hash())
+	return k.waitMined(ctx, signedTxn)
+}
+
+// deployContracts - deploys shutter contracts at addresses of config, if they aren't deployed yet
+func (k *DevKeyper) deployContracts(ctx context.Context) error {
+	ksmAddr := common.HexToAddress(k.config.KeyperSetManagerContractAddress)
+	code, err := k.backend.CodeAt(ctx, ksmAddr, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) > 0 {
+		k.logger.Info("[shutter-dev-keyper] contracts are already deployed", "keyperSetManager", ksmAddr)
+		return nil
+	}
+	nonce, err := k.backend.PendingNonceAt(ctx, k.deployer)
+	if err != nil {
+		return err
+	}
+	if nonce != 0 {
+		return fmt.Errorf("deployer %s has nonce %d, but contracts aren't deployed: they can't be deployed at addresses of config", k.deployer, nonce)
+	}
+
+	opts, err := k.transactOpts(ctx)
+	if err != nil {
+		return err
+	}
+	sequencerAddr, sequencerTxn, _, err := shuttercontracts.DeploySequencer(opts, k.backend)
+	if err != nil {
+		return fmt.Errorf("deploy sequencer: %w", err)
+	}
+	registryAddr, registryTxn, _, err := shuttercontracts.DeployValidatorRegistry(opts, k.backend)
+	if err != nil {
+		return fmt.Errorf("deploy validator registry: %w", err)
+	}
+	_, ksmTxn, ksm, err := shuttercontracts.DeployKeyperSetManager(opts, k.backend, k.deployer)
+	if err != nil {
+		return fmt.Errorf("deploy keyper set manager: %w", err)
+	}
+	keyBroadcastAddr, keyBroadcastTxn, _, err := shuttercontracts.DeployKeyBroadcastContract(opts, k.backend, ksmAddr)
+	if err != nil {
+		return fmt.Errorf("deploy key broadcast contract: %w", err)
+	}
+	if err := k.waitMined(ctx, sequencerTxn, registryTxn, ksmTxn, keyBroadcastTxn); err != nil {
+		return err
+	}
+	if sequencerAddr.String() != k.config.SequencerContractAddress ||
+		registryAddr.String() != k.config.ValidatorRegistryContractAddress ||
+		keyBroadcastAddr.String() != k.config.KeyBroadcastContractAddress {
+		return errors.New("contracts are deployed not at addresses of config")
+	}
+
+	initTxn, err := ksm.Initialize(opts, k.deployer, k.deployer)
+	if err != nil {
+		return fmt.Errorf("initialize keyper set manager: %w", err)
+	}
+	if err := k.waitMined(ctx, initTxn); err != nil {
+		return err
+	}
+	k.logger.Info("[shutter-dev-keyper] contracts deployed",
+		"sequencer", sequencerAddr, "validatorRegistry", registryAddr, "keyperSetManager", ksmAddr, "keyBroadcast", keyBroadcastAddr)
+	return nil
+}
+
+// addEon - generates keys of new eon, adds its keyper set and broadcasts its key
+func (k *DevKeyper) addEon(ctx context.Context) (EonKeys, error) {
+	ksm, err := shuttercontracts.NewKeyperSetManager(common.HexToAddress(k.config.KeyperSetManagerContractAddress), k.backend)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	numKeyperSets, err := ksm.GetNumKeyperSets(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return EonKeys{}, err
+	}
+	keys, err := GenerateEonKeys(shutter.EonIndex(numKeyperSets), k.opts.Threshold, k.opts.NumKeypers)
+	if err != nil {
+		return EonKeys{}, err
+	}
+
+	opts, err := k.transactOpts(ctx)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	keyperSetAddr, keyperSetTxn, keyperSet, err := shuttercontracts.DeployKeyperSet(opts, k.backend)
+	if err != nil {
+		return EonKeys{}, fmt.Errorf("deploy keyper set: %w", err)
+	}
+	if err := k.waitMined(ctx, keyperSetTxn); err != nil {
+		return EonKeys{}, err
+	}
+	publisherTxn, err := keyperSet.SetPublisher(opts, k.deployer)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	thresholdTxn, err := keyperSet.SetThreshold(opts, keys.Threshold)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	membersTxn, err := keyperSet.AddMembers(opts, keys.Members())
+	if err != nil {
+		return EonKeys{}, err
+	}
+	finalizedTxn, err := keyperSet.SetFinalized(opts)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	if err := k.waitMined(ctx, publisherTxn, thresholdTxn, membersTxn, finalizedTxn); err != nil {
+		return EonKeys{}, err
+	}
+
+	head, err := k.backend.BlockNumber(ctx)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	activationBlock := head + eonActivationDelay
+	addTxn, err := ksm.AddKeyperSet(opts, activationBlock, keyperSetAddr)
+	if err != nil {
+		return EonKeys{}, fmt.Errorf("add keyper set: %w", err)
+	}
+	if err := k.waitMined(ctx, addTxn); err != nil {
+		return EonKeys{}, err
+	}
+
+	keyBroadcast, err := shuttercontracts.NewKeyBroadcastContract(common.HexToAddress(k.config.KeyBroadcastContractAddress), k.backend)
+	if err != nil {
+		return EonKeys{}, err
+	}
+	broadcastTxn, err := keyBroadcast.BroadcastEonKey(opts, uint64(keys.Index), keys.PublicKey.Marshal())
+	if err != nil {
+		return EonKeys{}, fmt.Errorf("broadcast eon key: %w", err)
+	}
+	if err := k.waitMined(ctx, broadcastTxn); err != nil {
+		return EonKeys{}, err
+	}
+	k.logger.Info("[shutter-dev-keyper] eon added", "eon", keys.Index, "activationBlock", activationBlock,
+		"keypers", len(keys.Keypers), "threshold", keys.Threshold, "keyperSet", keyperSetAddr)
+	return keys, nil
+}
+
+// registerValidators - registers validators with fresh BLS keys in validator registry, by one aggregate registration message
+func (k *DevKeyper) registerValidators(ctx context.Context) (shutter.ValidatorInfo, error) {
+	if k.opts.NumValidators == 0 {
+		return shutter.ValidatorInfo{}, nil
+	}
+	registryAddr := common.HexToAddress(k.config.ValidatorRegistryContractAddress)
+	registry, err := shuttercontracts.NewValidatorRegistry(registryAddr, k.backend)
+	if err != nil {
+		return nil, err
+	}
+	// validators registered by previous runs keep their indices: new ones go after them
+	callOpts := &bind.CallOpts{Context: ctx}
+	numUpdates, err := registry.GetNumUpdates(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	var firstIndex uint64
+	for i := uint64(0); i < numUpdates.Uint64(); i++ {
+		update, err := registry.GetUpdate(callOpts, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, err
+		}
+		var msg shutter.AggregateRegistrationMessage
+		if err := msg.Unmarshal(update.Message); err != nil || msg.Count == 0 {
+			continue // legacy or invalid messages aren't made by dev keyper
+		}
+		indices := msg.ValidatorIndices()
+		firstIndex = max(firstIndex, uint64(indices[len(indices)-1])+1)
+	}
+
+	msg := shutter.AggregateRegistrationMessage{
+		Version:                  shutter.AggregateValidatorRegistrationMessageVersion,
+		ChainId:                  k.config.ChainId.Uint64(),
+		ValidatorRegistryAddress: registryAddr,
+		ValidatorIndex:           firstIndex,
+		Nonce:                    1,
+		Count:                    uint32(k.opts.NumValidators),
+		IsRegistration:           true,
+	}
+	msgHash := crypto.Keccak256(msg.Marshal())
+	validators := make(shutter.ValidatorInfo, k.opts.NumValidators)
+	sigs := make([]*blst.P2Affine, k.opts.NumValidators)
+	for i := range sigs {
+		var ikm [32]byte
+		if _, err := rand.Read(ikm[:]); err != nil {
+			return nil, err
+		}
+		secretKey := blst.KeyGen(ikm[:])
+		validators[shutter.ValidatorIndex(firstIndex)+shutter.ValidatorIndex(i)] = shutter.ValidatorPubKey(hexutil.Encode(new(blst.P1Affine).From(secretKey).Compress()))
+		sigs[i] = new(blst.P2Affine).Sign(secretKey, msgHash, []byte(registrationDst))
+	}
+	var aggregate blst.P2Aggregate
+	if !aggregate.Aggregate(sigs, false) {
+		return nil, errors.New("aggregate registration signatures")
+	}
+
+	opts, err := k.transactOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updateTxn, err := registry.Update(opts, msg.Marshal(), aggregate.ToAffine().Compress())
+	if err != nil {
+		return nil, fmt.Errorf("register validators: %w", err)
+	}
+	if err := k.waitMined(ctx, updateTxn); err != nil {
+		return nil, err
+	}
+	k.logger.Info("[shutter-dev-keyper] validators registered", "from", firstIndex, "count", k.opts.NumValidators)
+	return validators, nil
+}
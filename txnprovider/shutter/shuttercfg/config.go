@@ -79,6 +79,8 @@ func ConfigByChainName(chainName string) Config {
 		return chiadoConfig
 	case networkname.Gnosis:
 		return gnosisConfig
+	case networkname.Dev:
+		return devConfig
 	default:
 		panic("missing shutter config for chain: " + chainName)
 	}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package shuttercfg
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	chainspec "github.com/erigontech/erigon/execution/chain/spec"
+	"github.com/erigontech/erigon/execution/types"
+)
+
+// Dev chain (`--chain=dev --dev.pos`: Clique dev chain doesn't accept Engine API payloads) has no keypers:
+// `erigon shutter-dev-keyper` stands in for them. It deploys shutter contracts from DevKeyperDeployerKey - so their
+// addresses are known upfront, and listens on DevKeyperP2pPort with DevKeyperP2pKey - so it's a known bootstrap node.
+// Deployer is funded by DevFunderKey - account pre-funded in dev genesis. These keys are public: never use them outside of dev chain.
+var (
+	DevKeyperDeployerKey = crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("erigon shutter dev keyper deployer")))
+	DevKeyperP2pKey      = crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("erigon shutter dev keyper p2p")))
+	DevFunderKey         = crypto.ToECDSAUnsafe(common.FromHex("26e86e45f6fc45ec6e2ecd128cec80fa1d1505e5507dcd2ae58c3130a7a97b48"))
+)
+
+const (
+	DevKeyperP2pPort  = 23_103
+	DevSecondsPerSlot = 5
+	devSlotsPerEpoch  = 32
+	devInstanceId     = 1_337
+)
+
+// DevContracts - order of contracts deployment by DevKeyperDeployerKey: address of contract is determined by nonce of deployer
+var DevContracts = struct{ Sequencer, ValidatorRegistry, KeyperSetManager, KeyBroadcast uint64 }{0, 1, 2, 3}
+
+var devConfig = newDevConfig()
+
+func newDevConfig() Config {
+	deployer := crypto.PubkeyToAddress(DevKeyperDeployerKey.PublicKey)
+	return Config{
+		Enabled:                          true,
+		InstanceId:                       devInstanceId,
+		ChainId:                          uint256.MustFromBig(chainspec.DeveloperPoSGenesisBlock().Config.ChainID),
+		BeaconChainGenesisTimestamp:      0,
+		SecondsPerSlot:                   DevSecondsPerSlot,
+		SequencerContractAddress:         types.CreateAddress(deployer, DevContracts.Sequencer).String(),
+		ValidatorRegistryContractAddress: types.CreateAddress(deployer, DevContracts.ValidatorRegistry).String(),
+		KeyperSetManagerContractAddress:  types.CreateAddress(deployer, DevContracts.KeyperSetManager).String(),
+		KeyBroadcastContractAddress:      types.CreateAddress(deployer, DevContracts.KeyBroadcast).String(),
+		MaxNumKeysPerMessage:             defaultMaxNumKeysPerMessage,
+		ReorgDepthAwareness:              defaultReorgDepthAwarenessEpochs * devSlotsPerEpoch,
+		MaxPooledEncryptedTxns:           defaultMaxPooledEncryptedTxns,
+		EncryptedGasLimit:                defaultEncryptedGasLimit,
+		EncryptedTxnsLookBackDistance:    defaultEncryptedTxnsLookBackDistance,
+		MaxDecryptionKeysDelay:           defaultMaxDecryptionKeysDelay,
+		P2pConfig: P2pConfig{
+			ListenPort:     defaultP2PListenPort,
+			BootstrapNodes: []string{DevKeyperBootstrapNode("127.0.0.1")},
+		},
+	}
+}
+
+// DevKeyperBootstrapNode - multiaddr of dev keyper running on given host
+func DevKeyperBootstrapNode(ip string) string {
+	privKey, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(crypto.FromECDSA(DevKeyperP2pKey))
+	if err != nil {
+		panic(err)
+	}
+	peerId, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip, DevKeyperP2pPort, peerId)
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/node/gointerfaces/grpcutil"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// Export - calls f for each txn of pool, in order of senders and nonces: so importing them in this order doesn't create nonce gaps.
+// Txns kept by this node only (see TxnSlot.keptByThisNodeOnly) aren't exported: their conditions aren't persisted, nor transferable.
+func (p *TxPool) Export(tx kv.Tx, f func(txn txpoolcfg.ExportedTxn) error) error {
+	var txns []*metaTxn
+	var exported []txpoolcfg.ExportedTxn
+
+	p.lock.Lock()
+	p.all.ascendAll(func(mt *metaTxn) bool {
+		if mt.TxnSlot.keptByThisNodeOnly() {
+			return true
+		}
+		if sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]; found {
+			txns = append(txns, mt)
+			exported = append(exported, txpoolcfg.ExportedTxn{
+				Rlp:          mt.TxnSlot.Rlp,
+				Sender:       sender,
+				Local:        mt.subPool&IsLocal > 0,
+				ArrivalBlock: hexutil.Uint64(mt.timestamp),
+				Arrival:      hexutil.Uint64(mt.arrival),
+			})
+		}
+		return true
+	})
+	p.lock.Unlock()
+
+	for i := range exported {
+		if exported[i].Rlp == nil { // flushed to db
+			v, err := tx.GetOne(kv.PoolTransaction, txns[i].TxnSlot.IDHash[:])
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				continue // mined or evicted meanwhile
+			}
+			exported[i].Rlp = v[20:]
+		}
+		if err := f(exported[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportTxns - adds txns exported by pool of other node (see Export) and returns final result of each txn:
+// unlike AddRemoteTxns remote txns are added synchronously. Txns keep locality and arrival block of exporting pool,
+// and arrive in order of their TxnSlot.Arrival there - after txns already in this pool: so first-come-first-served
+// ordering survives failover.
+func (p *TxPool) ImportTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error) {
+	coreDb, cache := p.chainDB()
+	coreTx, err := coreDb.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer coreTx.Rollback()
+
+	cacheView, err := cache.View(ctx, coreTx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	reasons := make([]txpoolcfg.DiscardReason, len(newTxns.Txns))
+	var accepted TxnSlots
+	var acceptedIdx []int
+	for i, txn := range newTxns.Txns {
+		if !newTxns.IsLocal[i] && p.cfg.NoGossip {
+			reasons[i] = txpoolcfg.RemoteTxnsDisabled
+			continue
+		}
+		accepted.Append(txn, newTxns.Senders.At(i), newTxns.IsLocal[i])
+		acceptedIdx = append(acceptedIdx, i)
+	}
+
+	// arrival numbers of exporting pool are not comparable with numbers of this pool: keep only their order
+	byArrival := make([]*TxnSlot, len(accepted.Txns))
+	copy(byArrival, accepted.Txns)
+	slices.SortStableFunc(byArrival, func(a, b *TxnSlot) int { return cmp.Compare(a.Arrival, b.Arrival) })
+	for _, txn := range byArrival {
+		p.arrivalSeq++
+		txn.Arrival = p.arrivalSeq
+	}
+
+	if err = p.senders.registerNewSenders(&accepted, p.logger); err != nil {
+		return nil, err
+	}
+	validationReasons, goodTxns, err := p.validateTxns(&accepted, cacheView)
+	if err != nil {
+		return nil, err
+	}
+	announcements, addReasons, err := p.addTxns(p.lastSeenBlock.Load(), cacheView, p.senders, goodTxns,
+		p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), p.blockGasLimit.Load(), true, p.logger)
+	if err != nil {
+		return nil, err
+	}
+	addReasons = fillDiscardReasons(addReasons, goodTxns, p.discardReasonsLRU)
+
+	p.promoted.Reset()
+	p.promoted.AppendOther(announcements)
+	j := 0 // index in goodTxns
+	for k, i := range acceptedIdx {
+		if validationReasons[k] != txpoolcfg.NotSet {
+			reasons[i] = validationReasons[k]
+			continue
+		}
+		reasons[i] = addReasons[j]
+		if reasons[i] == txpoolcfg.Success {
+			txn := goodTxns.Txns[j]
+			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
+		}
+		j++
+	}
+	if p.promoted.Len() > 0 {
+		select {
+		case p.newPendingTxns <- p.promoted.Copy():
+		default:
+		}
+	}
+	return reasons, nil
+}
+
+// replicateQueueSize - batches of local txns waiting for replication: if standby is slow, newer batches are dropped
+const replicateQueueSize = 1024
+
+// Replicator - mirrors local txns of pool to pool of other node (see txpoolcfg.Config.ReplicateTo), via its txpool gRPC:
+// there they are local txns too - so they survive failover to that node.
+type Replicator struct {
+	client txpoolproto.TxpoolClient
+	queue  chan [][]byte
+	logger log.Logger
+}
+
+func NewReplicator(addr string, logger log.Logger) (*Replicator, error) {
+	conn, err := grpcutil.Connect(nil, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Replicator{
+		client: txpoolproto.NewTxpoolClient(conn),
+		queue:  make(chan [][]byte, replicateQueueSize),
+		logger: logger,
+	}, nil
+}
+
+// replicate - never blocks: replication problems must not affect pool
+func (r *Replicator) replicate(rlpTxns [][]byte) {
+	select {
+	case r.queue <- rlpTxns:
+	default:
+		r.logger.Warn("[txpool] replication: queue is full, txns are not replicated", "count", len(rlpTxns))
+	}
+}
+
+func (r *Replicator) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rlpTxns := <-r.queue:
+			r.send(ctx, rlpTxns)
+		}
+	}
+}
+
+func (r *Replicator) send(ctx context.Context, rlpTxns [][]byte) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	reply, err := r.client.Add(ctx, &txpoolproto.AddRequest{RlpTxs: rlpTxns})
+	if err != nil {
+		r.logger.Warn("[txpool] replication: failed", "count", len(rlpTxns), "err", err)
+		return
+	}
+	for i, result := range reply.Imported {
+		if result != txpoolproto.ImportResult_SUCCESS && result != txpoolproto.ImportResult_ALREADY_EXISTS {
+			r.logger.Debug("[txpool] replication: txn rejected by standby", "result", result, "err", reply.Errors[i])
+		}
+	}
+}
+
+// replicatedTxns - RLP of txns successfully added to pool, which can be replicated
+func replicatedTxns(txns TxnSlots, reasons []txpoolcfg.DiscardReason) [][]byte {
+	var rlpTxns [][]byte
+	for i, reason := range reasons {
+		if reason == txpoolcfg.Success && !txns.Txns[i].keptByThisNodeOnly() {
+			rlpTxns = append(rlpTxns, txns.Txns[i].Rlp)
+		}
+	}
+	return rlpTxns
+}
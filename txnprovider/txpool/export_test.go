@@ -0,0 +1,234 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/types"
+	accounts3 "github.com/erigontech/erigon/execution/types/accounts"
+	"github.com/erigontech/erigon/node/direct"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(chain.TestChainConfig.ChainID)
+	chainID := *uint256.MustFromBig(chain.TestChainConfig.ChainID)
+	rlpTxn := func(nonce uint64) []byte {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(300_000), nil), *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		return buf.Bytes()
+	}
+	block := &remoteproto.StateChangeBatch{
+		PendingBlockBaseFee: 200_000,
+		BlockGasLimit:       1_000_000,
+		ChangeBatch:         []*remoteproto.StateChange{{BlockHeight: 1, BlockTime: 1}},
+	}
+	account := accounts3.Account{Balance: *uint256.NewInt(common.Ether), Incarnation: 1}
+	block.ChangeBatch[0].Changes = append(block.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+		Action:  remoteproto.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(sender),
+		Data:    accounts3.SerialiseV3(&account),
+	})
+	newPool := func() (*TxPool, *GrpcServer) {
+		pool := newJournalTestPool(t, txpoolcfg.DefaultConfig)
+		require.NoError(t, pool.start(ctx))
+		require.NoError(t, pool.OnNewBlock(ctx, block, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+		return pool, NewGrpcServer(ctx, pool, pool.poolDB, nil, chainID, log.New())
+	}
+
+	// over the direct client: it adapts the server stream
+	export := func(client *direct.TxPoolClient) []*txpoolproto.ExportedTxn {
+		stream, err := client.Export(ctx, &txpoolproto.ExportRequest{})
+		require.NoError(t, err)
+		var exported []*txpoolproto.ExportedTxn
+		for {
+			txn, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return exported
+			}
+			require.NoError(t, err)
+			exported = append(exported, txn)
+		}
+	}
+
+	primary, primaryServer := newPool()
+	reply, err := primaryServer.Add(ctx, &txpoolproto.AddRequest{RlpTxs: [][]byte{rlpTxn(0)}})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS}, reply.Imported)
+	private, err := primaryServer.AddPrivate(ctx, &txpoolproto.AddPrivateRequest{RlpTx: rlpTxn(1)})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS}, private.Imported)
+	parseCtx := NewTxnParseContext(chainID).ChainIDRequired()
+	var remote TxnSlots
+	remote.Resize(1)
+	remote.Txns[0] = &TxnSlot{}
+	_, err = parseCtx.ParseTransaction(rlpTxn(2), 0, remote.Txns[0], remote.Senders.At(0), false, true, nil)
+	require.NoError(t, err)
+	primary.AddRemoteTxns(ctx, remote)
+	require.NoError(t, primary.processRemoteTxns(ctx))
+
+	exported := export(direct.NewTxPoolClient(primaryServer))
+	// private txn is not exported
+	require.Len(t, exported, 2)
+	require.Equal(t, rlpTxn(0), exported[0].RlpTx)
+	require.True(t, exported[0].IsLocal)
+	require.Equal(t, sender, common.Address(gointerfaces.ConvertH160toAddress(exported[0].Sender)))
+	require.Equal(t, rlpTxn(2), exported[1].RlpTx)
+	require.False(t, exported[1].IsLocal)
+
+	standby, standbyServer := newPool()
+	reply, err = standbyServer.Import(ctx, &txpoolproto.ImportRequest{Txs: exported})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS, txpoolproto.ImportResult_SUCCESS}, reply.Imported)
+	txn, err := types.DecodeTransaction(rlpTxn(0))
+	require.NoError(t, err)
+	require.Equal(t, PendingSubPool.String(), standby.TxnStatus(txn.Hash()).SubPool)
+	// nonce gap: private txn stayed on primary
+	require.Equal(t, QueuedSubPool.String(), standby.TxnStatus(remote.Txns[0].IDHash).SubPool)
+	exported = export(direct.NewTxPoolClient(standbyServer))
+	require.Len(t, exported, 2)
+	require.True(t, exported[0].IsLocal)
+	require.False(t, exported[1].IsLocal)
+
+	reply, err = standbyServer.Import(ctx, &txpoolproto.ImportRequest{Txs: exported[:1]})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_ALREADY_EXISTS}, reply.Imported)
+}
+
+func TestImportKeepsSendersAndArrival(t *testing.T) {
+	ctx := context.Background()
+	signer := types.LatestSignerForChainID(chain.TestChainConfig.ChainID)
+	chainID := *uint256.MustFromBig(chain.TestChainConfig.ChainID)
+	keyA, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyB, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rlpTxn := func(key *ecdsa.PrivateKey, nonce uint64) []byte {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(300_000), nil), *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		return buf.Bytes()
+	}
+	newPool := func(cfg txpoolcfg.Config, height uint64) (*TxPool, *GrpcServer) {
+		block := &remoteproto.StateChangeBatch{
+			PendingBlockBaseFee: 200_000,
+			BlockGasLimit:       1_000_000,
+			ChangeBatch:         []*remoteproto.StateChange{{BlockHeight: height, BlockTime: height}},
+		}
+		account := accounts3.Account{Balance: *uint256.NewInt(common.Ether), Incarnation: 1}
+		for _, key := range []*ecdsa.PrivateKey{keyA, keyB} {
+			block.ChangeBatch[0].Changes = append(block.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+				Action:  remoteproto.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(crypto.PubkeyToAddress(key.PublicKey)),
+				Data:    accounts3.SerialiseV3(&account),
+			})
+		}
+		pool := newJournalTestPool(t, cfg)
+		require.NoError(t, pool.start(ctx))
+		require.NoError(t, pool.OnNewBlock(ctx, block, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+		return pool, NewGrpcServer(ctx, pool, pool.poolDB, nil, chainID, log.New())
+	}
+	yield := func(pool *TxPool) (rlps [][]byte) {
+		var yielded TxnsRlp
+		_, count, err := pool.YieldBest(ctx, 10, &yielded, 0, 1_000_000, 0, mapset.NewThreadUnsafeSet[[32]byte](), math.MaxInt)
+		require.NoError(t, err)
+		return yielded.Txns[:count]
+	}
+	cfg := txpoolcfg.DefaultConfig
+	cfg.Ordering = txpoolcfg.OrderingFCFS
+
+	// arrive not in order of export - which is by senders and nonces: a0, b0, b1
+	arrived := [][]byte{rlpTxn(keyB, 0), rlpTxn(keyA, 0), rlpTxn(keyB, 1)}
+	primary, primaryServer := newPool(cfg, 1)
+	for _, rlp := range arrived {
+		reply, err := primaryServer.Add(ctx, &txpoolproto.AddRequest{RlpTxs: [][]byte{rlp}})
+		require.NoError(t, err)
+		require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS}, reply.Imported)
+	}
+	require.Equal(t, arrived, yield(primary))
+	export := func(pool *TxPool) (exported []*txpoolproto.ExportedTxn) {
+		tx, err := pool.poolDB.BeginRo(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		require.NoError(t, pool.Export(tx, func(txn txpoolcfg.ExportedTxn) error {
+			exported = append(exported, txn.ToProto())
+			return nil
+		}))
+		return exported
+	}
+	exported := export(primary)
+	require.Equal(t, [][]byte{arrived[1], arrived[0], arrived[2]}, [][]byte{exported[0].RlpTx, exported[1].RlpTx, exported[2].RlpTx})
+
+	// standby is one block ahead: imported txns keep arrival block of primary
+	standby, standbyServer := newPool(cfg, 2)
+	reply, err := standbyServer.Import(ctx, &txpoolproto.ImportRequest{Txs: exported, TrustedSenders: true})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_SUCCESS, txpoolproto.ImportResult_SUCCESS, txpoolproto.ImportResult_SUCCESS}, reply.Imported)
+	require.Equal(t, arrived, yield(standby))
+	reimported := export(standby)
+	require.Len(t, reimported, len(exported))
+	for i := range exported {
+		require.Equal(t, exported[i].RlpTx, reimported[i].RlpTx)
+		require.Equal(t, exported[i].Sender, reimported[i].Sender)
+		require.Equal(t, uint64(1), reimported[i].ArrivalBlock)
+	}
+
+	// without trusted senders: senders are recovered, exported ones must not be needed
+	noSenders := make([]*txpoolproto.ExportedTxn, len(exported))
+	for i, txn := range exported {
+		noSenders[i] = &txpoolproto.ExportedTxn{RlpTx: txn.RlpTx, IsLocal: txn.IsLocal, Arrival: txn.Arrival}
+	}
+	recovered, recoveredServer := newPool(cfg, 1)
+	_, err = recoveredServer.Import(ctx, &txpoolproto.ImportRequest{Txs: noSenders})
+	require.NoError(t, err)
+	require.Equal(t, arrived, yield(recovered))
+
+	// remote txns are not accepted with --txpool.nogossip: reported, not silently dropped
+	noGossip := cfg
+	noGossip.NoGossip = true
+	exported[0].IsLocal = false
+	_, noGossipServer := newPool(noGossip, 1)
+	reply, err = noGossipServer.Import(ctx, &txpoolproto.ImportRequest{Txs: exported, TrustedSenders: true})
+	require.NoError(t, err)
+	require.Equal(t, []txpoolproto.ImportResult{txpoolproto.ImportResult_INVALID, txpoolproto.ImportResult_SUCCESS, txpoolproto.ImportResult_SUCCESS}, reply.Imported)
+	require.Equal(t, txpoolcfg.RemoteTxnsDisabled.String(), reply.Errors[0])
+}
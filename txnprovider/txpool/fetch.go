@@ -382,7 +382,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentryproto.Inbou
 			return nil
 		}
 
-		f.pool.AddRemoteTxns(ctx, txns)
+		f.pool.AddRemoteTxns(withSourcePeer(ctx, req.PeerId), txns)
 	default:
 		defer f.logger.Trace("[txpool] dropped p2p message", "id", req.Id)
 	}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/common/jsonl"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
+)
+
+// journalFlushEvery - journal entries are flushed to file not later than this: crash of node loses only last second of traffic
+const journalFlushEvery = time.Second
+
+// JournalEntry - one batch of txns received by pool (see AddRemoteTxns, AddLocalTxns), or one new block seen by pool (see OnNewBlock).
+// Journal is a jsonl file of entries in order of arrival.
+type JournalEntry struct {
+	Time         time.Time       `json:"time"`
+	Local        bool            `json:"local,omitempty"`
+	Peer         hexutil.Bytes   `json:"peer,omitempty"`         // remote txns: id of peer which sent them, if known
+	Txns         []hexutil.Bytes `json:"txns,omitempty"`         // RLP of txns, blob txns wrapped with blobs if they were received so
+	StateChanges hexutil.Bytes   `json:"stateChanges,omitempty"` // new block: protobuf-encoded remoteproto.StateChangeBatch
+}
+
+// IsBlock - true if entry is a new block, not a batch of txns
+func (e *JournalEntry) IsBlock() bool {
+	return len(e.StateChanges) > 0
+}
+
+func (e *JournalEntry) StateChangeBatch() (*remoteproto.StateChangeBatch, error) {
+	batch := &remoteproto.StateChangeBatch{}
+	if err := proto.Unmarshal(e.StateChanges, batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+type sourcePeerKey struct{}
+
+// withSourcePeer - marks ctx of AddRemoteTxns with peer which sent txns: for journal
+func withSourcePeer(ctx context.Context, peerID PeerID) context.Context {
+	return context.WithValue(ctx, sourcePeerKey{}, peerID)
+}
+
+// Journal - writes txpool traffic to file, for later replay (see ReplayJournal)
+type Journal struct {
+	w      *jsonl.Writer
+	logger log.Logger
+}
+
+func NewJournal(path string, logger log.Logger) (*Journal, error) {
+	w, err := jsonl.NewWriter(path, journalFlushEvery, logger)
+	if err != nil {
+		return nil, fmt.Errorf("txpool journal: %w", err)
+	}
+	return &Journal{w: w, logger: logger}, nil
+}
+
+// recordTxns - never fails: journal problems are logged and must not affect pool
+func (j *Journal) recordTxns(ctx context.Context, txns TxnSlots, isLocal bool) {
+	entry := &JournalEntry{Time: time.Now(), Local: isLocal, Txns: make([]hexutil.Bytes, len(txns.Txns))}
+	if peerID, ok := ctx.Value(sourcePeerKey{}).(PeerID); ok && peerID != nil {
+		entry.Peer = gointerfaces.ConvertH512ToBytes(peerID)
+	}
+	for i, txn := range txns.Txns {
+		entry.Txns[i] = txn.Rlp
+	}
+	j.write(entry)
+}
+
+func (j *Journal) recordBlock(stateChanges *remoteproto.StateChangeBatch) {
+	raw, err := proto.Marshal(stateChanges)
+	if err != nil {
+		j.logger.Warn("[txpool] journal: can't marshal state changes", "err", err)
+		return
+	}
+	j.write(&JournalEntry{Time: time.Now(), StateChanges: raw})
+}
+
+func (j *Journal) write(entry *JournalEntry) {
+	if err := j.w.Write(entry); err != nil && !errors.Is(err, os.ErrClosed) {
+		j.logger.Warn("[txpool] journal: write failed", "err", err)
+	}
+}
+
+func (j *Journal) Close() error {
+	return j.w.Close()
+}
+
+type JournalReader = jsonl.Reader[JournalEntry]
+
+// NewJournalReader - JournalReader.Next returns io.EOF at the end of journal, io.ErrUnexpectedEOF if journal was cut (for example by crash of node)
+func NewJournalReader(path string) (*JournalReader, error) {
+	r, err := jsonl.NewReader[JournalEntry](path)
+	if err != nil {
+		return nil, fmt.Errorf("txpool journal: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/kv"
+	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// ReplayReport - how pool handled replayed journal
+type ReplayReport struct {
+	Batches     int            `json:"batches"`
+	Blocks      int            `json:"blocks"`
+	Txns        int            `json:"txns"`
+	ParseErrors int            `json:"parse_errors"`
+	Decisions   map[string]int `json:"decisions"`    // txns by sub-pool they were placed to, or by discard reason
+	AddLatency  LatencyStats   `json:"add_latency"`  // of adding batch of txns to pool
+	BestLatency LatencyStats   `json:"best_latency"` // of providing best txns for block building, after each block
+	Pending     int            `json:"pending"`      // size of sub-pools at the end of replay
+	BaseFee     int            `json:"base_fee"`
+	Queued      int            `json:"queued"`
+	Truncated   bool           `json:"truncated"` // journal was cut, for example by crash of node
+}
+
+type LatencyStats struct {
+	Count int     `json:"count"`
+	MinMs float64 `json:"min_ms"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P99Ms float64 `json:"p99_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+func newLatencyStats(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	slices.Sort(samples)
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	var total time.Duration
+	for _, d := range samples {
+		total += d
+	}
+	return LatencyStats{
+		Count: len(samples),
+		MinMs: ms(samples[0]),
+		AvgMs: ms(total / time.Duration(len(samples))),
+		P50Ms: ms(samples[len(samples)/2]),
+		P99Ms: ms(samples[len(samples)*99/100]),
+		MaxMs: ms(samples[len(samples)-1]),
+	}
+}
+
+// ReplayJournal - re-injects txns and blocks of journal into pool, at original speed multiplied by speed (0 - as fast as possible).
+// Pool must not be running (see Run): remote txns are processed by replay in batches, as Run does every cfg.ProcessRemoteTxnsEvery of journal time.
+// Chain state of pool is a starting point: replayed blocks change it by their recorded state changes.
+func ReplayJournal(ctx context.Context, pool *TxPool, journal *JournalReader, speed float64, logger log.Logger) (*ReplayReport, error) {
+	if err := pool.start(ctx); err != nil {
+		return nil, err
+	}
+	coreDB, _ := pool.chainDB()
+	var stateVersion uint64
+	if err := coreDB.ViewTemporal(ctx, func(tx kv.TemporalTx) (err error) {
+		stateVersion, err = tx.ReadSequence(string(kv.PlainStateVersion))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r := &journalReplay{
+		pool:     pool,
+		report:   &ReplayReport{Decisions: map[string]int{}},
+		parseCtx: NewTxnParseContext(pool.chainID).ChainIDRequired(),
+		logger:   logger,
+	}
+	var firstTime, lastProcessTime time.Time
+	replayStart := time.Now()
+	for {
+		entry, err := journal.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			r.report.Truncated = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if firstTime.IsZero() {
+			firstTime, lastProcessTime = entry.Time, entry.Time
+		}
+		if speed > 0 {
+			at := replayStart.Add(time.Duration(float64(entry.Time.Sub(firstTime)) / speed))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Until(at)):
+			}
+		}
+
+		// remote txns received before other entry are processed before it - as pool does periodically
+		if !entry.IsBlock() && !entry.Local && entry.Time.Sub(lastProcessTime) < pool.cfg.ProcessRemoteTxnsEvery {
+			r.addRemote(ctx, entry)
+			continue
+		}
+		if err := r.processRemote(ctx); err != nil {
+			return nil, err
+		}
+		lastProcessTime = entry.Time
+		switch {
+		case entry.IsBlock():
+			if err := r.onBlock(ctx, entry, stateVersion); err != nil {
+				return nil, err
+			}
+		case entry.Local:
+			if err := r.addLocal(ctx, entry); err != nil {
+				return nil, err
+			}
+		default:
+			r.addRemote(ctx, entry)
+		}
+	}
+	if err := r.processRemote(ctx); err != nil {
+		return nil, err
+	}
+
+	r.report.AddLatency = newLatencyStats(r.addLatency)
+	r.report.BestLatency = newLatencyStats(r.bestLatency)
+	r.report.Pending, r.report.BaseFee, r.report.Queued = pool.CountContent()
+	return r.report, nil
+}
+
+type journalReplay struct {
+	pool                    *TxPool
+	report                  *ReplayReport
+	parseCtx                *TxnParseContext
+	unprocessedRemote       []common.Hash
+	addLatency, bestLatency []time.Duration
+	logger                  log.Logger
+}
+
+func (r *journalReplay) parse(entry *JournalEntry) TxnSlots {
+	var txns TxnSlots
+	for _, rlpTxn := range entry.Txns {
+		j := len(txns.Txns)
+		txns.Resize(uint(j + 1))
+		txns.Txns[j] = &TxnSlot{}
+		txns.IsLocal[j] = entry.Local
+		if _, err := r.parseCtx.ParseTransaction(rlpTxn, 0, txns.Txns[j], txns.Senders.At(j), false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+			r.logger.Debug("[txpool] replay: can't parse txn", "err", err)
+			r.report.ParseErrors++
+			txns.Resize(uint(j))
+		}
+	}
+	r.report.Batches++
+	r.report.Txns += len(entry.Txns)
+	return txns
+}
+
+func (r *journalReplay) addRemote(ctx context.Context, entry *JournalEntry) {
+	txns := r.parse(entry)
+	r.pool.AddRemoteTxns(ctx, txns)
+	for _, txn := range txns.Txns {
+		r.unprocessedRemote = append(r.unprocessedRemote, txn.IDHash)
+	}
+}
+
+func (r *journalReplay) processRemote(ctx context.Context) error {
+	if len(r.unprocessedRemote) == 0 {
+		return nil
+	}
+	start := time.Now()
+	if err := r.pool.processRemoteTxns(ctx); err != nil {
+		return err
+	}
+	r.addLatency = append(r.addLatency, time.Since(start))
+	for _, hash := range r.unprocessedRemote {
+		r.decided(hash, txpoolcfg.Success)
+	}
+	r.unprocessedRemote = r.unprocessedRemote[:0]
+	return nil
+}
+
+func (r *journalReplay) addLocal(ctx context.Context, entry *JournalEntry) error {
+	txns := r.parse(entry)
+	start := time.Now()
+	reasons, err := r.pool.AddLocalTxns(ctx, txns)
+	if err != nil {
+		return err
+	}
+	r.addLatency = append(r.addLatency, time.Since(start))
+	for i, txn := range txns.Txns {
+		r.decided(txn.IDHash, reasons[i])
+	}
+	return nil
+}
+
+// decided - counts decision of pool about txn: sub-pool it was placed to, or discard reason
+func (r *journalReplay) decided(hash common.Hash, reason txpoolcfg.DiscardReason) {
+	if reason != txpoolcfg.Success && reason != txpoolcfg.NotSet {
+		r.report.Decisions[reason.String()]++
+		return
+	}
+	status := r.pool.TxnStatus(hash)
+	switch {
+	case status.SubPool != "":
+		r.report.Decisions[status.SubPool]++
+	case status.DiscardReason != txpoolcfg.NotSet:
+		r.report.Decisions[status.DiscardReason.String()]++
+	default:
+		r.report.Decisions["unknown"]++
+	}
+}
+
+func (r *journalReplay) onBlock(ctx context.Context, entry *JournalEntry, stateVersion uint64) error {
+	batch, err := entry.StateChangeBatch()
+	if err != nil {
+		return err
+	}
+	// recorded state changes must be visible to pool through its state cache, which is keyed by version of replay's chain state
+	batch.StateVersionId = stateVersion
+	if err := r.pool.p2pFetcher.handleStateChangesRequest(ctx, batch); err != nil {
+		return err
+	}
+	r.report.Blocks++
+
+	blockTime := r.pool.lastSeenBlockTime.Load() + 1
+	start := time.Now()
+	if _, err := r.pool.ProvideTxns(ctx,
+		txnprovider.WithBlockTime(blockTime),
+		txnprovider.WithGasTarget(r.pool.blockGasLimit.Load()),
+		txnprovider.WithBlobGasTarget(r.pool.chainConfig.GetMaxBlobGasPerBlock(blockTime)),
+		txnprovider.WithTxnIdsFilter(mapset.NewThreadUnsafeSet[[32]byte]()), // only peek: replay doesn't mine txns
+	); err != nil {
+		return err
+	}
+	r.bestLatency = append(r.bestLatency, time.Since(start))
+	return nil
+}
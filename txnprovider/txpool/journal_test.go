@@ -0,0 +1,133 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/crypto"
+	"github.com/erigontech/erigon/common/log/v3"
+	"github.com/erigontech/erigon/db/datadir"
+	"github.com/erigontech/erigon/db/kv/kvcache"
+	"github.com/erigontech/erigon/db/kv/memdb"
+	"github.com/erigontech/erigon/db/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon/execution/chain"
+	"github.com/erigontech/erigon/execution/types"
+	accounts3 "github.com/erigontech/erigon/execution/types/accounts"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+func newJournalTestPool(t *testing.T, cfg txpoolcfg.Config) *TxPool {
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	cacheCfg := kvcache.DefaultCoherentConfig
+	cacheCfg.WaitForNewBlock = false
+	pool, err := New(context.Background(), make(chan Announcements, 100), memdb.NewTestPoolDB(t), coreDB, cfg, kvcache.New(cacheCfg),
+		chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(t, err)
+	return pool
+}
+
+func TestJournalReplay(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(chain.TestChainConfig.ChainID)
+	parseCtx := NewTxnParseContext(*uint256.MustFromBig(chain.TestChainConfig.ChainID)).ChainIDRequired()
+	newTxns := func(isLocal bool, nonces ...uint64) TxnSlots {
+		var txns TxnSlots
+		for _, nonce := range nonces {
+			txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(300_000), nil), *signer, key)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, txn.MarshalBinary(&buf))
+			slot := &TxnSlot{}
+			_, err = parseCtx.ParseTransaction(buf.Bytes(), 0, slot, sender[:], false, true, nil)
+			require.NoError(t, err)
+			txns.Append(slot, sender[:], isLocal)
+		}
+		return txns
+	}
+
+	block := &remoteproto.StateChangeBatch{
+		PendingBlockBaseFee: 200_000,
+		BlockGasLimit:       1_000_000,
+		ChangeBatch:         []*remoteproto.StateChange{{BlockHeight: 1, BlockTime: 1}},
+	}
+	account := accounts3.Account{Balance: *uint256.NewInt(common.Ether), Incarnation: 1}
+	block.ChangeBatch[0].Changes = append(block.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+		Action:  remoteproto.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(sender),
+		Data:    accounts3.SerialiseV3(&account),
+	})
+	peerID := gointerfaces.ConvertHashToH512([64]byte{7})
+
+	cfg := txpoolcfg.DefaultConfig
+	cfg.JournalPath = filepath.Join(t.TempDir(), "txpool.journal")
+	pool := newJournalTestPool(t, cfg)
+	require.NoError(t, pool.OnNewBlock(ctx, block, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	_, err = pool.AddLocalTxns(ctx, newTxns(true, 0))
+	require.NoError(t, err)
+	pool.AddRemoteTxns(withSourcePeer(ctx, peerID), newTxns(false, 1, 5)) // nonce 5: gap
+	require.NoError(t, pool.journal.Close())
+
+	reader, err := NewJournalReader(cfg.JournalPath)
+	require.NoError(t, err)
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	require.True(t, entry.IsBlock())
+	batch, err := entry.StateChangeBatch()
+	require.NoError(t, err)
+	require.Equal(t, block.PendingBlockBaseFee, batch.PendingBlockBaseFee)
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	require.True(t, entry.Local)
+	require.Len(t, entry.Txns, 1)
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	require.False(t, entry.Local)
+	require.Len(t, entry.Txns, 2)
+	require.Equal(t, gointerfaces.ConvertH512ToBytes(peerID), []byte(entry.Peer))
+	_, err = reader.Next()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, reader.Close())
+
+	reader, err = NewJournalReader(cfg.JournalPath)
+	require.NoError(t, err)
+	defer reader.Close()
+	report, err := ReplayJournal(ctx, newJournalTestPool(t, txpoolcfg.DefaultConfig), reader, 0, log.New())
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Blocks)
+	require.Equal(t, 2, report.Batches)
+	require.Equal(t, 3, report.Txns)
+	require.Zero(t, report.ParseErrors)
+	require.Equal(t, map[string]int{PendingSubPool.String(): 2, QueuedSubPool.String(): 1}, report.Decisions)
+	require.Equal(t, 2, report.Pending)
+	require.Equal(t, 1, report.Queued)
+	require.Equal(t, 2, report.AddLatency.Count)
+	require.Equal(t, 1, report.BestLatency.Count)
+}
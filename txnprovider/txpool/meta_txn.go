@@ -19,7 +19,10 @@ package txpool
 import "github.com/holiman/uint256"
 
 func newMetaTxn(slot *TxnSlot, isLocal bool, timestamp uint64) *metaTxn {
-	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, timestamp: timestamp}
+	if slot.ArrivalBlock != 0 {
+		timestamp = slot.ArrivalBlock
+	}
+	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, timestamp: timestamp, arrival: slot.Arrival}
 	if isLocal {
 		mt.subPool = IsLocal
 	}
@@ -36,6 +39,7 @@ type metaTxn struct {
 	bestIndex                 int
 	worstIndex                int
 	timestamp                 uint64 // when it was added to pool
+	arrival                   uint64 // sequence number of txn arrival to pool: persisted, restored txns keep their numbers
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
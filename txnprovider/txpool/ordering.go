@@ -0,0 +1,91 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// orderingPolicy - order in which txns of Pending sub-pool are offered for block production (see YieldBest and ProvideTxns).
+// It doesn't affect which txns are evicted from the pool: eviction is always by fees.
+// Txns of same sender may be offered out of nonce order: block builder postpones txns with too high nonce.
+type orderingPolicy interface {
+	// better - true if txn a must be offered before txn b
+	better(a, b *metaTxn, pendingBaseFee uint256.Int) bool
+}
+
+func newOrderingPolicy(cfg txpoolcfg.Config, senders *sendersBatch) (orderingPolicy, error) {
+	switch cfg.Ordering {
+	case "", txpoolcfg.OrderingFeePriority:
+		return feePriorityOrdering{}, nil
+	case txpoolcfg.OrderingFCFS:
+		return fcfsOrdering{}, nil
+	case txpoolcfg.OrderingPrioritySenders:
+		if len(cfg.PrioritySenders) == 0 {
+			return nil, errors.New("txpool ordering by priority senders requires non-empty list of senders")
+		}
+		o := prioritySendersOrdering{senders: senders, priority: make(map[common.Address]struct{}, len(cfg.PrioritySenders))}
+		for _, sender := range cfg.PrioritySenders {
+			o.priority[sender] = struct{}{}
+		}
+		return o, nil
+	default:
+		_, err := txpoolcfg.ParseOrdering(string(cfg.Ordering))
+		return nil, err
+	}
+}
+
+// feePriorityOrdering - by effective tip: local txns first, then higher effective tip, then smaller nonce distance
+type feePriorityOrdering struct{}
+
+func (feePriorityOrdering) better(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	return a.better(b, pendingBaseFee)
+}
+
+// fcfsOrdering - first-come-first-served: by arrival to the pool, regardless of fees and locality
+type fcfsOrdering struct{}
+
+func (fcfsOrdering) better(a, b *metaTxn, _ uint256.Int) bool {
+	return a.arrival < b.arrival
+}
+
+// prioritySendersOrdering - txns of allowlisted senders first, then by effective tip
+type prioritySendersOrdering struct {
+	senders  *sendersBatch
+	priority map[common.Address]struct{}
+}
+
+func (o prioritySendersOrdering) isPriority(mt *metaTxn) bool {
+	addr, ok := o.senders.senderID2Addr[mt.TxnSlot.SenderID]
+	if !ok {
+		return false
+	}
+	_, ok = o.priority[addr]
+	return ok
+}
+
+func (o prioritySendersOrdering) better(a, b *metaTxn, pendingBaseFee uint256.Int) bool {
+	if aPriority, bPriority := o.isPriority(a), o.isPriority(b); aPriority != bPriority {
+		return aPriority
+	}
+	return a.better(b, pendingBaseFee)
+}
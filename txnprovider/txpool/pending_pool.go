@@ -35,7 +35,7 @@ type PendingPool struct {
 }
 
 func NewPendingSubPool(t SubPoolType, limit int) *PendingPool {
-	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTxn{}}, worst: &WorstQueue{ms: []*metaTxn{}}}
+	return &PendingPool{limit: limit, t: t, best: &bestSlice{ms: []*metaTxn{}, ordering: feePriorityOrdering{}}, worst: &WorstQueue{ms: []*metaTxn{}}}
 }
 
 func (p *PendingPool) EnforceWorstInvariants() {
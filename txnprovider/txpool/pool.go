@@ -110,9 +110,9 @@ type TxPool struct {
 	//   - batch notifications about new txns (reduced P2P spam to other nodes about txns propagation)
 	//   - and as a result reducing lock contention
 	unprocessedRemoteTxns   *TxnSlots
-	unprocessedRemoteByHash map[string]int                                  // to reject duplicates
-	byHash                  map[string]*metaTxn                             // txn_hash => txn : only those records not committed to db yet
-	discardReasonsLRU       *simplelru.LRU[string, txpoolcfg.DiscardReason] // txn_hash => discard_reason : non-persisted
+	unprocessedRemoteByHash map[string]int                        // to reject duplicates
+	byHash                  map[string]*metaTxn                   // txn_hash => txn : only those records not committed to db yet
+	discardReasonsLRU       *simplelru.LRU[string, discardRecord] // txn_hash => discard_reason : non-persisted
 	pending                 *PendingPool
 	baseFee                 *SubPool
 	queued                  *SubPool
@@ -127,6 +127,8 @@ type TxPool struct {
 	chainID                 uint256.Int
 	chainConfig             *chain.Config
 	lastSeenBlock           atomic.Uint64
+	lastSeenBlockTime       atomic.Uint64
+	arrivalSeq              uint64 // order of txns arrival to the pool, for first-come-first-served ordering
 	lastSeenCond            *sync.Cond
 	lastFinalizedBlock      atomic.Uint64
 	started                 atomic.Bool
@@ -152,6 +154,8 @@ type TxPool struct {
 	newSlotsStreams         *NewSlotsStreams
 	ethBackend              remoteproto.ETHBACKENDClient
 	builderNotifyNewTxns    func()
+	journal                 *Journal    // nil if journal is disabled
+	replicator              *Replicator // nil if replication of local txns is disabled
 	logger                  log.Logger
 	auths                   map[AuthAndNonce]*metaTxn // All authority accounts with a pooled authorization
 	blobHashToTxn           map[common.Hash]struct {
@@ -189,7 +193,7 @@ func New(
 	if err != nil {
 		return nil, err
 	}
-	discardHistory, err := simplelru.NewLRU[string, txpoolcfg.DiscardReason](10_000, nil)
+	discardHistory, err := simplelru.NewLRU[string, discardRecord](10_000, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +251,23 @@ func New(
 		}),
 	}
 
+	ordering, err := newOrderingPolicy(cfg, res.senders)
+	if err != nil {
+		return nil, err
+	}
+	res.pending.best.ordering = ordering
+
+	if cfg.JournalPath != "" {
+		if res.journal, err = NewJournal(cfg.JournalPath, logger); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ReplicateTo != "" {
+		if res.replicator, err = NewReplicator(cfg.ReplicateTo, logger); err != nil {
+			return nil, fmt.Errorf("txpool replication: %w", err)
+		}
+	}
+
 	if chainConfig.ShanghaiTime != nil {
 		if !chainConfig.ShanghaiTime.IsUint64() {
 			return nil, errors.New("shanghaiTime overflow")
@@ -328,6 +349,9 @@ func (p *TxPool) start(ctx context.Context) error {
 
 func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remoteproto.StateChangeBatch, unwindTxns, unwindBlobTxns, minedTxns TxnSlots) error {
 	defer newBlockTimer.ObserveDuration(time.Now())
+	if p.journal != nil {
+		p.journal.recordBlock(stateChanges)
+	}
 
 	sendNewBlockEventToDiagnostics(unwindTxns, unwindBlobTxns, minedTxns, stateChanges.ChangeBatch[len(stateChanges.ChangeBatch)-1].BlockHeight, stateChanges.ChangeBatch[len(stateChanges.ChangeBatch)-1].BlockTime)
 
@@ -341,6 +365,7 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remoteproto.State
 	defer coreTx.Rollback()
 
 	block := stateChanges.ChangeBatch[len(stateChanges.ChangeBatch)-1].BlockHeight
+	blockTime := stateChanges.ChangeBatch[len(stateChanges.ChangeBatch)-1].BlockTime
 	baseFee := stateChanges.PendingBlockBaseFee
 
 	if err = minedTxns.Valid(); err != nil {
@@ -356,6 +381,7 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remoteproto.State
 	defer func() {
 		if err == nil {
 			p.lastSeenBlock.Store(block)
+			p.lastSeenBlockTime.Store(blockTime)
 			p.lastSeenCond.Broadcast()
 		}
 
@@ -470,6 +496,9 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remoteproto.State
 	if err != nil {
 		return err
 	}
+	if err = p.discardUnmetConditionsLocked(cacheView, block+1, blockTime+1, stateChanges.BlockGasLimit); err != nil {
+		return err
+	}
 
 	p.pending.EnforceWorstInvariants()
 	p.baseFee.EnforceInvariants()
@@ -653,7 +682,7 @@ func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes [
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	for hash, txn := range p.byHash {
-		if txn.subPool&IsLocal == 0 {
+		if txn.subPool&IsLocal == 0 || txn.TxnSlot.keptByThisNodeOnly() {
 			continue
 		}
 		types = append(types, txn.TxnSlot.Type)
@@ -714,6 +743,50 @@ func (p *TxPool) IdHashKnown(tx kv.Tx, hash []byte) (bool, error) {
 	return p.idHashKnown(tx, hash, hashS)
 }
 
+type discardRecord struct {
+	reason txpoolcfg.DiscardReason
+	time   time.Time
+}
+
+// TxnStatus - sub-pool of transaction and why it's not in Pending sub-pool. Or why and when it was dropped from the pool.
+func (p *TxPool) TxnStatus(hash common.Hash) txpoolcfg.TxnStatus {
+	hashS := string(hash[:])
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if mt, ok := p.byHash[hashS]; ok {
+		return txpoolcfg.TxnStatus{SubPool: mt.currentSubPool.String(), NotPending: p.notPendingReasons(mt)}
+	}
+	if _, ok := p.unprocessedRemoteByHash[hashS]; ok {
+		return txpoolcfg.TxnStatus{NotPending: []string{txpoolcfg.NotPendingNotProcessed}}
+	}
+	if discarded, ok := p.discardReasonsLRU.Peek(hashS); ok {
+		return txpoolcfg.TxnStatus{DiscardReason: discarded.reason, DiscardedAt: discarded.time}
+	}
+	return txpoolcfg.TxnStatus{}
+}
+
+func (p *TxPool) notPendingReasons(mt *metaTxn) (reasons []string) {
+	if mt.currentSubPool == PendingSubPool {
+		return nil
+	}
+	if mt.subPool&NoNonceGaps == 0 {
+		reasons = append(reasons, txpoolcfg.NotPendingNonceGap)
+	}
+	if mt.subPool&EnoughBalance == 0 {
+		reasons = append(reasons, txpoolcfg.NotPendingBalance)
+	}
+	if mt.subPool&NotTooMuchGas == 0 {
+		reasons = append(reasons, txpoolcfg.NotPendingGasLimit)
+	}
+	if mt.minFeeCap.LtUint64(p.pendingBaseFee.Load()) {
+		reasons = append(reasons, txpoolcfg.NotPendingFeeTooLow)
+	}
+	if mt.TxnSlot.Type == BlobTxnType && mt.TxnSlot.BlobFeeCap.LtUint64(p.pendingBlobFee.Load()) {
+		reasons = append(reasons, txpoolcfg.NotPendingBlobFeeTooLow)
+	}
+	return reasons
+}
+
 func (p *TxPool) FilterKnownIdHashes(tx kv.Tx, hashes Hashes) (unknownHashes Hashes, err error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -769,6 +842,20 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 	return p.isLocalLRU.Contains(hashS)
 }
 
+func (p *TxPool) isConditional(idHash []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	mt, ok := p.byHash[string(idHash)]
+	return ok && mt.TxnSlot.Conditions != nil
+}
+
+func (p *TxPool) isPrivate(idHash []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	mt, ok := p.byHash[string(idHash)]
+	return ok && mt.TxnSlot.Private
+}
+
 func (p *TxPool) AddNewGoodPeer(peerID PeerID) {
 	p.recentlyConnectedPeers.AddPeer(peerID)
 }
@@ -777,7 +864,7 @@ func (p *TxPool) Started() bool {
 	return p.started.Load()
 }
 
-func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, yielded mapset.Set[[32]byte], availableRlpSpace int) (bool, int, error) {
+func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, blockTime, availableGas, availableBlobGas uint64, yielded mapset.Set[[32]byte], availableRlpSpace int) (bool, int, error) {
 	p.lock.Lock()
 	for last := p.lastSeenBlock.Load(); last < onTopOf; last = p.lastSeenBlock.Load() {
 		select {
@@ -813,6 +900,15 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 	isEIP3860 := p.isShanghai() || p.isAgra()
 	isEIP7623 := p.isPrague() || p.isBhilai()
 
+	// number and time of the block being built - for conditional txns. If caller doesn't know them: next after last seen
+	blockNum := onTopOf + 1
+	if onTopOf == 0 {
+		blockNum = p.lastSeenBlock.Load() + 1
+	}
+	if blockTime == 0 {
+		blockTime = p.lastSeenBlockTime.Load() + 1
+	}
+
 	txns.Resize(uint(min(n, len(best.ms))))
 	var toRemove []*metaTxn
 	count := 0
@@ -842,6 +938,11 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 			continue
 		}
 
+		if mt.TxnSlot.Conditions != nil && !mt.TxnSlot.Conditions.InRange(blockNum, blockTime) {
+			// state conditions are re-checked on every new block, block range and time - here
+			continue
+		}
+
 		if int64(mt.TxnSlot.Size) > int64(availableRlpSpace) {
 			p.logger.Debug("[txpool] skipping txn bigger than available rlp space", "size", int64(mt.TxnSlot.Size), "available", int64(availableRlpSpace))
 			continue
@@ -915,11 +1016,12 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 func (p *TxPool) ProvideTxns(ctx context.Context, opts ...txnprovider.ProvideOption) ([]types.Transaction, error) {
 	provideOptions := txnprovider.ApplyProvideOptions(opts...)
 	var txnsRlp TxnsRlp
-	_, _, err := p.YieldBest(
+	_, _, err := p.best(
 		ctx,
 		provideOptions.Amount,
 		&txnsRlp,
 		provideOptions.ParentBlockNum,
+		provideOptions.BlockTime,
 		provideOptions.GasTarget,
 		provideOptions.BlobGasTarget,
 		provideOptions.TxnIdsFilter,
@@ -946,7 +1048,7 @@ func (p *TxPool) ProvideTxns(ctx context.Context, opts ...txnprovider.ProvideOpt
 }
 
 func (p *TxPool) YieldBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte], availableRlpSpace int) (bool, int, error) {
-	return p.best(ctx, n, txns, onTopOf, availableGas, availableBlobGas, toSkip, availableRlpSpace)
+	return p.best(ctx, n, txns, onTopOf, 0 /* blockTime */, availableGas, availableBlobGas, toSkip, availableRlpSpace)
 }
 
 func (p *TxPool) PeekBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, availableRlpSpace int) (bool, error) {
@@ -961,13 +1063,16 @@ func (p *TxPool) CountContent() (int, int, int) {
 	return p.pending.Len(), p.baseFee.Len(), p.queued.Len()
 }
 
-func (p *TxPool) AddRemoteTxns(_ context.Context, newTxns TxnSlots) {
+func (p *TxPool) AddRemoteTxns(ctx context.Context, newTxns TxnSlots) {
 	if p.cfg.NoGossip {
 		// if no gossip, then
 		// disable adding remote transactions
 		// consume remote txn from fetch
 		return
 	}
+	if p.journal != nil {
+		p.journal.recordTxns(ctx, newTxns, false)
+	}
 
 	defer addRemoteTxnsTimer.ObserveDuration(time.Now())
 	p.lock.Lock()
@@ -1091,6 +1196,15 @@ func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.Cache
 		}
 		return txpoolcfg.InsufficientFunds
 	}
+	if txn.Conditions != nil {
+		ok, err := conditionsHold(txn.Conditions, stateCache, p.lastSeenBlock.Load()+1, p.lastSeenBlockTime.Load()+1)
+		if err != nil {
+			p.logger.Warn("[txpool] check txn conditions", "idHash", fmt.Sprintf("%x", txn.IDHash), "err", err)
+		}
+		if !ok {
+			return txpoolcfg.ConditionsNotMet
+		}
+	}
 	if txn.Type == BlobTxnType {
 		return p.validateBlobTxn(txn, isLocal)
 	}
@@ -1409,14 +1523,14 @@ func (p *TxPool) punishSpammer(spammer uint64) {
 	}
 }
 
-func fillDiscardReasons(reasons []txpoolcfg.DiscardReason, newTxns TxnSlots, discardReasonsLRU *simplelru.LRU[string, txpoolcfg.DiscardReason]) []txpoolcfg.DiscardReason {
+func fillDiscardReasons(reasons []txpoolcfg.DiscardReason, newTxns TxnSlots, discardReasonsLRU *simplelru.LRU[string, discardRecord]) []txpoolcfg.DiscardReason {
 	for i := range reasons {
 		if reasons[i] != txpoolcfg.NotSet {
 			continue
 		}
-		reason, ok := discardReasonsLRU.Get(string(newTxns.Txns[i].IDHash[:]))
+		discarded, ok := discardReasonsLRU.Get(string(newTxns.Txns[i].IDHash[:]))
 		if ok {
-			reasons[i] = reason
+			reasons[i] = discarded.reason
 		} else {
 			reasons[i] = txpoolcfg.Success
 		}
@@ -1425,6 +1539,9 @@ func fillDiscardReasons(reasons []txpoolcfg.DiscardReason, newTxns TxnSlots, dis
 }
 
 func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error) {
+	if p.journal != nil {
+		p.journal.recordTxns(ctx, newTxns, true)
+	}
 	coreDb, cache := p.chainDB()
 	coreTx, err := coreDb.BeginTemporalRo(ctx)
 	if err != nil {
@@ -1464,6 +1581,11 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 	p.promoted.AppendOther(announcements)
 
 	reasons = fillDiscardReasons(reasons, newTxns, p.discardReasonsLRU)
+	if p.replicator != nil {
+		if rlpTxns := replicatedTxns(newTxns, reasons); len(rlpTxns) > 0 {
+			p.replicator.replicate(rlpTxns)
+		}
+	}
 	for i, reason := range reasons {
 		if reason == txpoolcfg.Success {
 			txn := newTxns.Txns[i]
@@ -1738,6 +1860,10 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 
 	hashStr := string(mt.TxnSlot.IDHash[:])
 	p.byHash[hashStr] = mt
+	if mt.arrival == 0 {
+		p.arrivalSeq++
+		mt.arrival = p.arrivalSeq
+	}
 
 	if replaced := p.all.replaceOrInsert(mt, p.logger); replaced != nil {
 		if assert.Enable {
@@ -1779,7 +1905,7 @@ func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
 	delete(p.byHash, hashStr)
 	p.deletedTxns = append(p.deletedTxns, mt)
 	p.all.delete(mt, reason, p.logger)
-	p.discardReasonsLRU.Add(hashStr, reason)
+	p.discardReasonsLRU.Add(hashStr, discardRecord{reason: reason, time: time.Now()})
 	if mt.TxnSlot.Type == BlobTxnType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.TxnSlot.BlobHashes)))
@@ -1791,6 +1917,79 @@ func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
 	}
 }
 
+// conditionsHold - checks conditions of eth_sendRawTransactionConditional txn against latest state.
+// Block number and time of the next block are lower bounds: txn with expired range will never be includable.
+func conditionsHold(conditions *txpoolcfg.TxnConditions, stateCache kvcache.CacheView, nextBlockNum, nextBlockTime uint64) (bool, error) {
+	if conditions.Expired(nextBlockNum, nextBlockTime) {
+		return false, nil
+	}
+	for addr, account := range conditions.KnownAccounts {
+		if account.StorageRoot != nil {
+			root, err := stateCache.StorageRoot(addr)
+			if err != nil {
+				return false, err
+			}
+			if root != *account.StorageRoot {
+				return false, nil
+			}
+		}
+		for slot, value := range account.StorageSlots {
+			v, err := stateCache.GetStorage(addr, slot)
+			if err != nil {
+				return false, err
+			}
+			if common.BytesToHash(v) != value {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// discardUnmetConditionsLocked - drops conditional txns which conditions don't hold anymore on top of new block
+func (p *TxPool) discardUnmetConditionsLocked(cacheView kvcache.CacheView, nextBlockNum, nextBlockTime, blockGasLimit uint64) (err error) {
+	var toDiscard []*metaTxn
+	p.all.ascendAll(func(mt *metaTxn) bool {
+		if mt.TxnSlot.Conditions == nil {
+			return true
+		}
+		var ok bool
+		if ok, err = conditionsHold(mt.TxnSlot.Conditions, cacheView, nextBlockNum, nextBlockTime); err != nil {
+			return false
+		}
+		if !ok {
+			toDiscard = append(toDiscard, mt)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	sendersWithGaps := map[uint64]struct{}{}
+	for _, mt := range toDiscard {
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt, "conditionsNotMet", p.logger)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt, "conditionsNotMet", p.logger)
+		case QueuedSubPool:
+			p.queued.Remove(mt, "conditionsNotMet", p.logger)
+		}
+		p.discardLocked(mt, txpoolcfg.ConditionsNotMet) // can't call it while iterating by all
+		sendersWithGaps[mt.TxnSlot.SenderID] = struct{}{}
+	}
+	// txns of same sender with higher nonces now have nonce gap
+	for senderID := range sendersWithGaps {
+		nonce, balance, err := p.senders.info(cacheView, senderID)
+		if err != nil {
+			return err
+		}
+		p.onSenderStateChange(senderID, nonce, balance, blockGasLimit, p.logger)
+	}
+	return nil
+}
+
 func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash) []PoolBlobBundle {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -2198,6 +2397,12 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 func (p *TxPool) Run(ctx context.Context) error {
 	defer p.logger.Info("[txpool] stopped")
 	defer p.poolDB.Close()
+	if p.journal != nil {
+		defer p.journal.Close()
+	}
+	if p.replicator != nil {
+		go p.replicator.Run(ctx)
+	}
 	defer func() {
 		p.lock.Lock()
 		p.lastSeenCond.Broadcast() // to unblock .best() wait on cond
@@ -2310,8 +2515,16 @@ func (p *TxPool) Run(ctx context.Context) error {
 							continue
 						}
 
+						// private txns are not exposed even to subscribers of new txns
+						if p.isPrivate(hash) {
+							continue
+						}
 						// Empty rlp can happen if a transaction we want to broadcast has just been mined, for example
 						slotsRlp = append(slotsRlp, slotRlp)
+						// conditions are enforced only by this node: peers would include the txn unconditionally
+						if p.isConditional(hash) {
+							continue
+						}
 						if p.IsLocal(hash) {
 							localTxnTypes = append(localTxnTypes, t)
 							localTxnSizes = append(localTxnSizes, size)
@@ -2450,6 +2663,9 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 			if err := tx.Delete(kv.PoolTransaction, idHash); err != nil {
 				return err
 			}
+			if err := tx.Delete(kv.PoolTransactionArrival, idHash); err != nil {
+				return err
+			}
 		}
 		p.deletedTxns[i] = nil // for gc
 	}
@@ -2468,7 +2684,8 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 
 	v := make([]byte, 0, 1024)
 	for txHash, metaTx := range p.byHash {
-		if metaTx.TxnSlot.Rlp == nil {
+		// conditions are kept only in memory: conditional txn must not be restored from db as unconditional, nor private txn as public
+		if metaTx.TxnSlot.Rlp == nil || metaTx.TxnSlot.keptByThisNodeOnly() {
 			continue
 		}
 		v = common.EnsureEnoughSize(v, 20+len(metaTx.TxnSlot.Rlp))
@@ -2490,6 +2707,10 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 			if err := tx.Put(kv.PoolTransaction, []byte(txHash), v); err != nil {
 				return err
 			}
+			binary.BigEndian.PutUint64(encID, metaTx.arrival)
+			if err := tx.Put(kv.PoolTransactionArrival, []byte(txHash), encID); err != nil {
+				return err
+			}
 		}
 		metaTx.TxnSlot.Rlp = nil
 	}
@@ -2583,6 +2804,14 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 
 		txn.SenderID, txn.Traced = p.senders.getOrCreateID(addr, p.logger)
 		isLocalTx := p.isLocalLRU.Contains(string(k))
+		arrival, err := tx.GetOne(kv.PoolTransactionArrival, k)
+		if err != nil {
+			return err
+		}
+		if len(arrival) == 8 { // txns stored without arrival get numbers after all restored txns
+			txn.Arrival = binary.BigEndian.Uint64(arrival)
+			p.arrivalSeq = max(p.arrivalSeq, txn.Arrival)
+		}
 
 		if reason := p.validateTx(txn, isLocalTx, cacheView); reason != txpoolcfg.NotSet && reason != txpoolcfg.Success {
 			return nil // TODO: Clarify - if one of the txns has the wrong reason, no pooled txns!
@@ -2698,6 +2927,9 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 	p.lock.Lock()
 
 	p.all.ascendAll(func(mt *metaTxn) bool {
+		if mt.TxnSlot.Private {
+			return true
+		}
 		if sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]; found {
 			txns = append(txns, mt)
 			senders = append(senders, sender)
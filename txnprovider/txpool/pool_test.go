@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"testing"
 
 	goethkzg "github.com/crate-crypto/go-eth-kzg"
+	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/holiman/uint256"
 	"github.com/jinzhu/copier"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/common"
 	"github.com/erigontech/erigon/common/crypto"
 	"github.com/erigontech/erigon/common/crypto/kzg"
+	"github.com/erigontech/erigon/common/empty"
 	"github.com/erigontech/erigon/common/length"
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/db/datadir"
@@ -1618,6 +1621,437 @@ func TestGasLimitChanged(t *testing.T) {
 	}
 }
 
+func TestTxnStatus(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	require.NotNil(pool)
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remoteproto.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remoteproto.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+		Action:  remoteproto.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	err = pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{})
+	require.NoError(err)
+
+	add := func(idHash byte, nonce, feeCap uint64) common.Hash {
+		var txnSlots TxnSlots
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(feeCap),
+			FeeCap: *uint256.NewInt(feeCap),
+			Gas:    100000,
+			Nonce:  nonce,
+		}
+		txnSlot.IDHash[0] = idHash
+		txnSlots.Append(txnSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		for _, reason := range reasons {
+			require.Equal(txpoolcfg.Success, reason, reason.String())
+		}
+		return txnSlot.IDHash
+	}
+
+	pending := add(1, 2, 300000)
+	status := pool.TxnStatus(pending)
+	assert.Equal(PendingSubPool.String(), status.SubPool)
+	assert.Empty(status.NotPending)
+
+	gapped := add(2, 4, 300000)
+	status = pool.TxnStatus(gapped)
+	assert.Equal(QueuedSubPool.String(), status.SubPool)
+	assert.Equal([]string{txpoolcfg.NotPendingNonceGap}, status.NotPending)
+
+	add(3, 2, 330000) // replaces first txn
+	status = pool.TxnStatus(pending)
+	assert.Empty(status.SubPool)
+	assert.Equal(txpoolcfg.ReplacedByHigherTip, status.DiscardReason)
+	assert.False(status.DiscardedAt.IsZero())
+
+	assert.Equal(txpoolcfg.TxnStatus{}, pool.TxnStatus(common.Hash{0xff}))
+}
+
+func TestConditionalTxns(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	require.NotNil(pool)
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change := &remoteproto.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remoteproto.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{}), BlockTime: 100},
+		},
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+		Action:  remoteproto.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	err = pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{})
+	require.NoError(err)
+
+	add := func(idHash byte, nonce uint64, conditions *txpoolcfg.TxnConditions) (common.Hash, txpoolcfg.DiscardReason) {
+		var txnSlots TxnSlots
+		txnSlot := &TxnSlot{
+			Tip:        *uint256.NewInt(300000),
+			FeeCap:     *uint256.NewInt(300000),
+			Gas:        100000,
+			Nonce:      nonce,
+			Rlp:        []byte{idHash},
+			Conditions: conditions,
+		}
+		txnSlot.IDHash[0] = idHash
+		txnSlots.Append(txnSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		require.Len(reasons, 1)
+		return txnSlot.IDHash, reasons[0]
+	}
+	u64 := func(v uint64) *uint64 { return &v }
+	contract := common.Address{0xc}
+
+	// empty state: slot has zero value and account has no storage
+	_, reason := add(1, 2, &txpoolcfg.TxnConditions{KnownAccounts: map[common.Address]txpoolcfg.KnownAccount{
+		contract: {StorageSlots: map[common.Hash]common.Hash{{1}: {2}}},
+	}})
+	assert.Equal(txpoolcfg.ConditionsNotMet, reason, reason.String())
+	_, reason = add(1, 2, &txpoolcfg.TxnConditions{KnownAccounts: map[common.Address]txpoolcfg.KnownAccount{
+		contract: {StorageRoot: &common.Hash{1}},
+	}})
+	assert.Equal(txpoolcfg.ConditionsNotMet, reason, reason.String())
+	_, reason = add(2, 2, &txpoolcfg.TxnConditions{TimestampMax: u64(100)})
+	assert.Equal(txpoolcfg.ConditionsNotMet, reason, reason.String())
+
+	inRange, reason := add(3, 2, &txpoolcfg.TxnConditions{
+		KnownAccounts: map[common.Address]txpoolcfg.KnownAccount{
+			contract: {StorageRoot: &empty.RootHash, StorageSlots: map[common.Hash]common.Hash{{1}: {}}},
+		},
+		BlockNumberMax: u64(1),
+	})
+	assert.Equal(txpoolcfg.Success, reason, reason.String())
+	premature, reason := add(4, 3, &txpoolcfg.TxnConditions{BlockNumberMin: u64(2)})
+	assert.Equal(txpoolcfg.Success, reason, reason.String())
+	assert.Equal(PendingSubPool.String(), pool.TxnStatus(premature).SubPool)
+
+	// only txn which conditions hold for next block is yielded
+	var txns TxnsRlp
+	_, count, err := pool.YieldBest(ctx, 10, &txns, 0, 1000000, 0, mapset.NewThreadUnsafeSet[[32]byte](), math.MaxInt)
+	require.NoError(err)
+	require.Equal(1, count)
+	assert.Equal([]byte{inRange[0]}, txns.Txns[0])
+
+	// conditional txns are not announced to peers
+	_, _, hashes := pool.AppendLocalAnnouncements(nil, nil, nil)
+	assert.Empty(hashes)
+
+	change = &remoteproto.StateChangeBatch{
+		StateVersionId:      1,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remoteproto.StateChange{
+			{BlockHeight: 1, BlockHash: gointerfaces.ConvertHashToH256([32]byte{1}), BlockTime: 112},
+		},
+	}
+	err = pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{})
+	require.NoError(err)
+
+	// block number range of first txn is over: it's dropped, and second txn now has nonce gap
+	status := pool.TxnStatus(inRange)
+	assert.Equal(txpoolcfg.ConditionsNotMet, status.DiscardReason)
+	status = pool.TxnStatus(premature)
+	assert.Equal(QueuedSubPool.String(), status.SubPool)
+	assert.Equal([]string{txpoolcfg.NotPendingNonceGap}, status.NotPending)
+}
+
+func TestOrdering(t *testing.T) {
+	senders := []common.Address{{1}, {2}, {3}}
+	newPool := func(t *testing.T, ordering txpoolcfg.Ordering, prioritySenders ...common.Address) *TxPool {
+		ch := make(chan Announcements, 100)
+		coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+		db := memdb.NewTestPoolDB(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		cfg := txpoolcfg.DefaultConfig
+		cfg.Ordering, cfg.PrioritySenders = ordering, prioritySenders
+		pool, err := New(ctx, ch, db, coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+		require.NoError(t, err)
+		change := &remoteproto.StateChangeBatch{
+			PendingBlockBaseFee: 200000,
+			BlockGasLimit:       1000000,
+			ChangeBatch:         []*remoteproto.StateChange{{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})}},
+		}
+		for _, addr := range senders {
+			acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+			change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+				Action:  remoteproto.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    accounts3.SerialiseV3(&acc),
+			})
+		}
+		require.NoError(t, pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+		// arrive in order of senders, tip grows with arrival
+		for i, addr := range senders {
+			var txnSlots TxnSlots
+			tip := uint64(300000 + i*1000)
+			txnSlot := &TxnSlot{Tip: *uint256.NewInt(tip), FeeCap: *uint256.NewInt(tip), Gas: 100000, Rlp: []byte{byte(i + 1)}}
+			txnSlot.IDHash[0] = byte(i + 1)
+			txnSlots.Append(txnSlot, addr[:], true)
+			reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+			require.NoError(t, err)
+			require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+		}
+		return pool
+	}
+	yield := func(t *testing.T, pool *TxPool) (order []byte) {
+		var txns TxnsRlp
+		_, count, err := pool.YieldBest(context.Background(), 10, &txns, 0, 1000000, 0, mapset.NewThreadUnsafeSet[[32]byte](), math.MaxInt)
+		require.NoError(t, err)
+		for i := 0; i < count; i++ {
+			order = append(order, txns.Txns[i][0])
+		}
+		return order
+	}
+
+	t.Run("fee", func(t *testing.T) {
+		assert.Equal(t, []byte{3, 2, 1}, yield(t, newPool(t, txpoolcfg.OrderingFeePriority)))
+	})
+	t.Run("fcfs", func(t *testing.T) {
+		assert.Equal(t, []byte{1, 2, 3}, yield(t, newPool(t, txpoolcfg.OrderingFCFS)))
+	})
+	t.Run("priority-senders", func(t *testing.T) {
+		assert.Equal(t, []byte{1, 3, 2}, yield(t, newPool(t, txpoolcfg.OrderingPrioritySenders, senders[0])))
+	})
+	t.Run("bad config", func(t *testing.T) {
+		cfg := txpoolcfg.DefaultConfig
+		cfg.Ordering = txpoolcfg.OrderingPrioritySenders
+		_, err := New(context.Background(), nil, nil, nil, cfg, nil, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New())
+		require.Error(t, err)
+		cfg.Ordering = "random"
+		_, err = New(context.Background(), nil, nil, nil, cfg, nil, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New())
+		require.Error(t, err)
+	})
+}
+
+func TestFCFSOrderingRestored(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cacheCfg := kvcache.DefaultCoherentConfig
+	cacheCfg.WaitForNewBlock = false
+	cache := kvcache.New(cacheCfg)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.Ordering = txpoolcfg.OrderingFCFS
+	newPool := func() *TxPool {
+		pool, err := New(ctx, make(chan Announcements, 100), db, coreDB, cfg, cache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+		require.NoError(t, err)
+		return pool
+	}
+	signer := types.LatestSignerForChainID(chain.TestChainConfig.ChainID)
+	parseCtx := NewTxnParseContext(*uint256.MustFromBig(chain.TestChainConfig.ChainID)).ChainIDRequired()
+	change := &remoteproto.StateChangeBatch{
+		PendingBlockBaseFee: 200_000,
+		BlockGasLimit:       1_000_000,
+		ChangeBatch:         []*remoteproto.StateChange{{BlockHeight: 1, BlockTime: 1}},
+	}
+	var txns TxnSlots
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		sender := crypto.PubkeyToAddress(key.PublicKey)
+		acc := accounts3.Account{Balance: *uint256.NewInt(common.Ether), Incarnation: 1}
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+			Action:  remoteproto.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(sender),
+			Data:    accounts3.SerialiseV3(&acc),
+		})
+		txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(300_000), nil), *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		slot := &TxnSlot{}
+		_, err = parseCtx.ParseTransaction(buf.Bytes(), 0, slot, sender[:], false, true, nil)
+		require.NoError(t, err)
+		txns.Append(slot, sender[:], true)
+	}
+	// arrive in reverse order of hashes: pool is restored in order of hashes
+	order := []int{0, 1, 2}
+	slices.SortFunc(order, func(a, b int) int { return -bytes.Compare(txns.Txns[a].IDHash[:], txns.Txns[b].IDHash[:]) })
+	yield := func(pool *TxPool) (hashes []common.Hash) {
+		var yielded TxnsRlp
+		_, count, err := pool.YieldBest(ctx, 10, &yielded, 0, 1_000_000, 0, mapset.NewThreadUnsafeSet[[32]byte](), math.MaxInt)
+		require.NoError(t, err)
+		for i := 0; i < count; i++ {
+			hashes = append(hashes, crypto.Keccak256Hash(yielded.Txns[i]))
+		}
+		return hashes
+	}
+	var expected []common.Hash
+	for _, i := range order {
+		expected = append(expected, txns.Txns[i].IDHash)
+	}
+
+	pool := newPool()
+	require.NoError(t, pool.start(ctx))
+	require.NoError(t, pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	for _, i := range order {
+		var txnSlots TxnSlots
+		txnSlots.Append(txns.Txns[i], txns.Senders.At(i), true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(t, err)
+		require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	}
+	require.Equal(t, expected, yield(pool))
+	_, err := pool.flush(ctx)
+	require.NoError(t, err)
+
+	restored := newPool()
+	require.NoError(t, restored.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	require.NoError(t, restored.start(ctx))
+	require.Len(t, restored.byHash, len(order))
+	require.Equal(t, expected, yield(restored))
+
+	// new txns come after restored ones
+	require.Equal(t, pool.arrivalSeq, restored.arrivalSeq)
+}
+
+func TestPrivateTxns(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	require.NotNil(pool)
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change := &remoteproto.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remoteproto.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{}), BlockTime: 100},
+		},
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remoteproto.AccountChange{
+		Action:  remoteproto.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	err = pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{})
+	require.NoError(err)
+
+	add := func(idHash byte, nonce uint64, private bool, maxBlockNum *uint64) common.Hash {
+		var txnSlots TxnSlots
+		txnSlot := &TxnSlot{
+			Tip:     *uint256.NewInt(300000),
+			FeeCap:  *uint256.NewInt(300000),
+			Gas:     100000,
+			Nonce:   nonce,
+			Rlp:     []byte{idHash},
+			Private: private,
+		}
+		if maxBlockNum != nil {
+			txnSlot.Conditions = &txpoolcfg.TxnConditions{BlockNumberMax: maxBlockNum}
+		}
+		txnSlot.IDHash[0] = idHash
+		txnSlots.Append(txnSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons, reasons[0].String())
+		return txnSlot.IDHash
+	}
+	maxBlockNum := uint64(1)
+	private := add(1, 2, true, nil)
+	expiring := add(2, 3, true, &maxBlockNum)
+	public := add(3, 4, false, nil)
+
+	// all txns are offered to local block builder
+	var txns TxnsRlp
+	_, count, err := pool.YieldBest(ctx, 10, &txns, 0, 1000000, 0, mapset.NewThreadUnsafeSet[[32]byte](), math.MaxInt)
+	require.NoError(err)
+	assert.Equal(3, count)
+
+	// only public txn is announced to peers and shown by txpool content
+	_, _, hashes := pool.AppendLocalAnnouncements(nil, nil, nil)
+	assert.Equal(public[:], []byte(hashes))
+	var content [][]byte
+	require.NoError(db.View(ctx, func(tx kv.Tx) error {
+		pool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType) {
+			content = append(content, rlp)
+		}, tx)
+		return nil
+	}))
+	assert.Equal([][]byte{{public[0]}}, content)
+
+	change = &remoteproto.StateChangeBatch{
+		StateVersionId:      1,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remoteproto.StateChange{
+			{BlockHeight: 1, BlockHash: gointerfaces.ConvertHashToH256([32]byte{1}), BlockTime: 112},
+		},
+	}
+	err = pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{})
+	require.NoError(err)
+
+	// txn wasn't included up to its max block: it's dropped
+	assert.Equal(txpoolcfg.ConditionsNotMet, pool.TxnStatus(expiring).DiscardReason)
+	assert.Equal(PendingSubPool.String(), pool.TxnStatus(private).SubPool)
+}
+
 // sender - immutable structure which stores only nonce and balance of account
 type sender struct {
 	balance uint256.Int
@@ -39,6 +39,7 @@ import (
 	"github.com/erigontech/erigon/execution/rlp"
 	"github.com/erigontech/erigon/execution/types"
 	"github.com/erigontech/erigon/node/gointerfaces/typesproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
 
 const (
@@ -890,6 +891,23 @@ type TxnSlot struct {
 	SenderValidationData, PaymasterData, DeployerData, ExecutionData []byte
 	PostOpGasLimit, ValidationGasLimit, PaymasterValidationGasLimit  uint64
 	NonceKey, BuilderFee                                             uint256.Int
+
+	// eth_sendRawTransactionConditional: txn can be included only while conditions hold. Not persisted
+	Conditions *txpoolcfg.TxnConditions
+	// eth_sendPrivateRawTransaction: txn is offered only to local block builder - never announced to peers,
+	// nor exposed by txpool content and new txns subscriptions. Not persisted
+	Private bool
+	// Arrival - sequence number of arrival to the pool, kept across restarts for first-come-first-served ordering.
+	// 0 - new txn: pool assigns next number
+	Arrival uint64
+	// ArrivalBlock - set by txpool_import only: number of head block when txn arrived to the pool of exporting node.
+	// 0 - current head block
+	ArrivalBlock uint64
+}
+
+// keptByThisNodeOnly - txn must not leave this node: its conditions are enforced only by this node, or it's private
+func (tx *TxnSlot) keptByThisNodeOnly() bool {
+	return tx.Conditions != nil || tx.Private
 }
 
 func (tx *TxnSlot) PrintDebug(prefix string) {
@@ -23,6 +23,7 @@ import "github.com/holiman/uint256"
 type bestSlice struct {
 	ms             []*metaTxn
 	pendingBaseFee uint64
+	ordering       orderingPolicy
 }
 
 func (s *bestSlice) Len() int {
@@ -35,7 +36,7 @@ func (s *bestSlice) Swap(i, j int) {
 }
 
 func (s *bestSlice) Less(i, j int) bool {
-	return s.ms[i].better(s.ms[j], *uint256.NewInt(s.pendingBaseFee))
+	return s.ordering.better(s.ms[i], s.ms[j], *uint256.NewInt(s.pendingBaseFee))
 }
 
 func (s *bestSlice) UnsafeRemove(i *metaTxn) {
@@ -37,6 +37,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/length"
 	"github.com/erigontech/erigon/common/log/v3"
 	"github.com/erigontech/erigon/db/kv"
 	"github.com/erigontech/erigon/node/gointerfaces"
@@ -54,11 +55,15 @@ type txPool interface {
 	PeekBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, availableRlpSpace int) (bool, error)
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error)
+	AddRemoteTxns(ctx context.Context, newTxns TxnSlots)
+	Export(tx kv.Tx, f func(txn txpoolcfg.ExportedTxn) error) error
+	ImportTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error)
 	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
 	GetBlobs(blobhashes []common.Hash) (blobBundles []PoolBlobBundle)
+	TxnStatus(hash common.Hash) txpoolcfg.TxnStatus
 }
 
 var _ txpoolproto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -97,6 +102,21 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpoolproto.StatusRequ
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpoolproto.NonceRequest) (*txpoolproto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) TxnStatus(ctx context.Context, request *txpoolproto.TxnStatusRequest) (*txpoolproto.TxnStatusReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) AddConditional(ctx context.Context, request *txpoolproto.AddConditionalRequest) (*txpoolproto.AddReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) AddPrivate(ctx context.Context, request *txpoolproto.AddPrivateRequest) (*txpoolproto.AddReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) Export(request *txpoolproto.ExportRequest, server txpoolproto.Txpool_ExportServer) error {
+	return ErrPoolDisabled
+}
+func (*GrpcDisabled) Import(ctx context.Context, request *txpoolproto.ImportRequest) (*txpoolproto.AddReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpoolproto.UnimplementedTxpoolServer
@@ -176,54 +196,183 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpoolproto.AddRequest) (*txpo
 	}
 	defer tx.Rollback()
 
+	slots, reply := s.parseLocalTxns(tx, in.RlpTxs)
+
+	discardReasons, err := s.txPool.AddLocalTxns(ctx, slots)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+// AddConditional - adds local transaction which can be included only while conditions hold (eth_sendRawTransactionConditional).
+func (s *GrpcServer) AddConditional(ctx context.Context, in *txpoolproto.AddConditionalRequest) (*txpoolproto.AddReply, error) {
+	if in.Conditions == nil {
+		return nil, errors.New("conditions are not set")
+	}
+	conditions := txpoolcfg.TxnConditionsFromProto(in.Conditions)
+	if err := conditions.Validate(); err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	slots, reply := s.parseLocalTxns(tx, [][]byte{in.RlpTx})
+	for _, txn := range slots.Txns {
+		txn.Conditions = conditions
+	}
+
+	discardReasons, err := s.txPool.AddLocalTxns(ctx, slots)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+// AddPrivate - adds local transaction which is offered only to local block builder, until block maxBlockNum if it's set (eth_sendPrivateRawTransaction).
+func (s *GrpcServer) AddPrivate(ctx context.Context, in *txpoolproto.AddPrivateRequest) (*txpoolproto.AddReply, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	slots, reply := s.parseLocalTxns(tx, [][]byte{in.RlpTx})
+	for _, txn := range slots.Txns {
+		txn.Private = true
+		if in.MaxBlockNumber != nil {
+			txn.Conditions = &txpoolcfg.TxnConditions{BlockNumberMax: in.MaxBlockNumber}
+		}
+	}
+
+	discardReasons, err := s.txPool.AddLocalTxns(ctx, slots)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+// Export - streams each txn of pool (txpool_export)
+func (s *GrpcServer) Export(_ *txpoolproto.ExportRequest, stream txpoolproto.Txpool_ExportServer) error {
+	tx, err := s.db.BeginRo(stream.Context())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return s.txPool.Export(tx, func(txn txpoolcfg.ExportedTxn) error {
+		return stream.Send(txn.ToProto())
+	})
+}
+
+// Import - adds txns exported by other node (txpool_import): local ones as local, remote ones as if received from peers.
+// Txns keep arrival order and arrival block of exporting pool, reply has final result of each txn.
+// With trusted senders, senders of export are not recovered from signatures: import only exports of own nodes so.
+func (s *GrpcServer) Import(ctx context.Context, in *txpoolproto.ImportRequest) (*txpoolproto.AddReply, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	var slots TxnSlots
-	parseCtx := NewTxnParseContext(s.chainID).ChainIDRequired()
-	parseCtx.ValidateRLP(s.txPool.ValidateSerializedTxn)
+	parseCtx := s.newParseContext()
+	parseCtx.WithSender(!in.TrustedSenders)
+
+	reply := &txpoolproto.AddReply{Imported: make([]txpoolproto.ImportResult, len(in.Txs)), Errors: make([]string, len(in.Txs))}
+	for i, exported := range in.Txs {
+		txn := &TxnSlot{}
+		sender := make([]byte, length.Addr)
+		if err := s.parseTxn(tx, parseCtx, exported.RlpTx, txn, sender); err != nil {
+			reply.Imported[i], reply.Errors[i] = mapParseErrorToProto(err)
+			continue
+		}
+		if in.TrustedSenders {
+			if exported.Sender == nil {
+				reply.Imported[i], reply.Errors[i] = txpoolproto.ImportResult_INVALID, txpoolcfg.InvalidSender.String()
+				continue
+			}
+			addr := gointerfaces.ConvertH160toAddress(exported.Sender)
+			copy(sender, addr[:])
+		}
+		txn.Arrival, txn.ArrivalBlock = exported.Arrival, exported.ArrivalBlock
+		slots.Append(txn, sender, exported.IsLocal)
+	}
+
+	discardReasons, err := s.txPool.ImportTxns(ctx, slots)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+// parseLocalTxns - returns successfully parsed txns, and reply with errors of txns which failed to parse
+func (s *GrpcServer) parseLocalTxns(tx kv.Tx, rlpTxs [][]byte) (TxnSlots, *txpoolproto.AddReply) {
+	return s.parseTxns(tx, rlpTxs, true)
+}
 
-	reply := &txpoolproto.AddReply{Imported: make([]txpoolproto.ImportResult, len(in.RlpTxs)), Errors: make([]string, len(in.RlpTxs))}
+func (s *GrpcServer) parseTxns(tx kv.Tx, rlpTxs [][]byte, isLocal bool) (TxnSlots, *txpoolproto.AddReply) {
+	var slots TxnSlots
+	parseCtx := s.newParseContext()
 
-	for i := 0; i < len(in.RlpTxs); i++ {
+	reply := &txpoolproto.AddReply{Imported: make([]txpoolproto.ImportResult, len(rlpTxs)), Errors: make([]string, len(rlpTxs))}
+
+	for i := 0; i < len(rlpTxs); i++ {
 		j := len(slots.Txns) // some incoming txns may be rejected, so - need second index
 		slots.Resize(uint(j + 1))
 		slots.Txns[j] = &TxnSlot{}
-		slots.IsLocal[j] = true
-		if _, err := parseCtx.ParseTransaction(in.RlpTxs[i], 0, slots.Txns[j], slots.Senders.At(j), false /* hasEnvelope */, true /* wrappedWithBlobs */, func(hash []byte) error {
-			if known, _ := s.txPool.IdHashKnown(tx, hash); known {
-				return ErrAlreadyKnown
-			}
-			return nil
-		}); err != nil {
-			slots.Resize(uint(j))                // remove erroneous transaction
-			if errors.Is(err, ErrAlreadyKnown) { // Noop, but need to handle to not count these
-				reply.Errors[i] = txpoolcfg.AlreadyKnown.String()
-				reply.Imported[i] = txpoolproto.ImportResult_ALREADY_EXISTS
-			} else if errors.Is(err, ErrRlpTooBig) { // Noop, but need to handle to not count these
-				reply.Errors[i] = txpoolcfg.RLPTooLong.String()
-				reply.Imported[i] = txpoolproto.ImportResult_INVALID
-			} else {
-				reply.Errors[i] = err.Error()
-				reply.Imported[i] = txpoolproto.ImportResult_INTERNAL_ERROR
-			}
+		slots.IsLocal[j] = isLocal
+		if err := s.parseTxn(tx, parseCtx, rlpTxs[i], slots.Txns[j], slots.Senders.At(j)); err != nil {
+			slots.Resize(uint(j)) // remove erroneous transaction
+			reply.Imported[i], reply.Errors[i] = mapParseErrorToProto(err)
 		}
 	}
+	return slots, reply
+}
 
-	discardReasons, err := s.txPool.AddLocalTxns(ctx, slots)
-	if err != nil {
-		return nil, err
+func (s *GrpcServer) newParseContext() *TxnParseContext {
+	parseCtx := NewTxnParseContext(s.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(s.txPool.ValidateSerializedTxn)
+	return parseCtx
+}
+
+func (s *GrpcServer) parseTxn(tx kv.Tx, parseCtx *TxnParseContext, rlpTx []byte, txn *TxnSlot, sender []byte) error {
+	_, err := parseCtx.ParseTransaction(rlpTx, 0, txn, sender, false /* hasEnvelope */, true /* wrappedWithBlobs */, func(hash []byte) error {
+		if known, _ := s.txPool.IdHashKnown(tx, hash); known {
+			return ErrAlreadyKnown
+		}
+		return nil
+	})
+	return err
+}
+
+func mapParseErrorToProto(err error) (txpoolproto.ImportResult, string) {
+	if errors.Is(err, ErrAlreadyKnown) { // Noop, but need to handle to not count these
+		return txpoolproto.ImportResult_ALREADY_EXISTS, txpoolcfg.AlreadyKnown.String()
+	} else if errors.Is(err, ErrRlpTooBig) { // Noop, but need to handle to not count these
+		return txpoolproto.ImportResult_INVALID, txpoolcfg.RLPTooLong.String()
 	}
+	return txpoolproto.ImportResult_INTERNAL_ERROR, err.Error()
+}
 
+// fillAddReply - sets results of txns which were parsed and passed to the pool
+func fillAddReply(reply *txpoolproto.AddReply, discardReasons []txpoolcfg.DiscardReason) {
 	j := 0
 	for i := range reply.Imported {
 		if reply.Imported[i] != txpoolproto.ImportResult_SUCCESS {
-			j++
-			continue
+			continue // failed to parse: not passed to the pool
 		}
 
 		reply.Imported[i] = mapDiscardReasonToProto(discardReasons[j])
 		reply.Errors[i] = discardReasons[j].String()
 		j++
 	}
-	return reply, nil
 }
 
 func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpoolproto.GetBlobsRequest) (*txpoolproto.GetBlobsReply, error) {
@@ -263,7 +412,7 @@ func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpoolproto.ImportR
 	case txpoolcfg.InvalidSender, txpoolcfg.NegativeValue, txpoolcfg.OversizedData, txpoolcfg.InitCodeTooLarge,
 		txpoolcfg.RLPTooLong, txpoolcfg.InvalidCreateTxn, txpoolcfg.NoBlobs, txpoolcfg.TooManyBlobs,
 		txpoolcfg.TypeNotActivated, txpoolcfg.UnequalBlobTxExt, txpoolcfg.BlobHashCheckFail,
-		txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.NoAuthorizations:
+		txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.NoAuthorizations, txpoolcfg.ConditionsNotMet, txpoolcfg.RemoteTxnsDisabled:
 		// TODO(EIP-7702) TypeNotActivated may be transient (e.g. a set code transaction is submitted 1 sec prior to the Pectra activation)
 		return txpoolproto.ImportResult_INVALID
 	default:
@@ -327,6 +476,16 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpoolproto.NonceRequest) (*
 	}, nil
 }
 
+// TxnStatus - where transaction is in the pool or why it was dropped.
+func (s *GrpcServer) TxnStatus(_ context.Context, in *txpoolproto.TxnStatusRequest) (*txpoolproto.TxnStatusReply, error) {
+	status := s.txPool.TxnStatus(gointerfaces.ConvertH256ToHash(in.Hash))
+	reply := &txpoolproto.TxnStatusReply{SubPool: status.SubPool, NotPending: status.NotPending, DiscardReason: uint32(status.DiscardReason)}
+	if status.DiscardReason != txpoolcfg.NotSet {
+		reply.DiscardedAt = uint64(status.DiscardedAt.UnixMilli())
+	}
+	return reply, nil
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpoolproto.Txpool_OnAddServer
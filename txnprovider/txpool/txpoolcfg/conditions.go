@@ -0,0 +1,139 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpoolcfg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+)
+
+// MaxConditionsCost - limit on amount of known accounts and storage slots of one conditional transaction:
+// they are re-checked on every block
+const MaxConditionsCost = 1000
+
+var (
+	ErrConditionsTooCostly     = fmt.Errorf("conditions cost exceeds limit of %d", MaxConditionsCost)
+	ErrConditionsBadBlockRange = errors.New("blockNumberMin is greater than blockNumberMax")
+	ErrConditionsBadTimeRange  = errors.New("timestampMin is greater than timestampMax")
+)
+
+// TxnConditions - conditions of transaction submitted by eth_sendRawTransactionConditional.
+// Transaction can be included only into block on top of which all conditions hold.
+type TxnConditions struct {
+	KnownAccounts  map[common.Address]KnownAccount
+	BlockNumberMin *uint64
+	BlockNumberMax *uint64
+	TimestampMin   *uint64
+	TimestampMax   *uint64
+}
+
+// KnownAccount - expected storage of account: either storage root or values of some storage slots
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// Cost - amount of state reads needed to check conditions
+func (c *TxnConditions) Cost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		}
+		cost += len(account.StorageSlots)
+	}
+	return cost
+}
+
+func (c *TxnConditions) Validate() error {
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && *c.BlockNumberMin > *c.BlockNumberMax {
+		return ErrConditionsBadBlockRange
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return ErrConditionsBadTimeRange
+	}
+	if c.Cost() > MaxConditionsCost {
+		return ErrConditionsTooCostly
+	}
+	return nil
+}
+
+// Expired - block number or timestamp conditions can't hold for given block and any block after it
+func (c *TxnConditions) Expired(blockNum, blockTime uint64) bool {
+	return (c.BlockNumberMax != nil && blockNum > *c.BlockNumberMax) ||
+		(c.TimestampMax != nil && blockTime > *c.TimestampMax)
+}
+
+// InRange - block number and timestamp conditions hold for given block
+func (c *TxnConditions) InRange(blockNum, blockTime uint64) bool {
+	if c.Expired(blockNum, blockTime) {
+		return false
+	}
+	return (c.BlockNumberMin == nil || blockNum >= *c.BlockNumberMin) &&
+		(c.TimestampMin == nil || blockTime >= *c.TimestampMin)
+}
+
+func (c *TxnConditions) ToProto() *txpoolproto.TxnConditions {
+	res := &txpoolproto.TxnConditions{
+		BlockNumberMin: c.BlockNumberMin,
+		BlockNumberMax: c.BlockNumberMax,
+		TimestampMin:   c.TimestampMin,
+		TimestampMax:   c.TimestampMax,
+	}
+	for addr, account := range c.KnownAccounts {
+		knownAccount := &txpoolproto.KnownAccount{Address: gointerfaces.ConvertAddressToH160(addr)}
+		if account.StorageRoot != nil {
+			knownAccount.StorageRoot = gointerfaces.ConvertHashToH256(*account.StorageRoot)
+		}
+		for key, value := range account.StorageSlots {
+			knownAccount.StorageSlots = append(knownAccount.StorageSlots, &txpoolproto.StorageSlot{Key: gointerfaces.ConvertHashToH256(key), Value: gointerfaces.ConvertHashToH256(value)})
+		}
+		res.KnownAccounts = append(res.KnownAccounts, knownAccount)
+	}
+	return res
+}
+
+func TxnConditionsFromProto(in *txpoolproto.TxnConditions) *TxnConditions {
+	c := &TxnConditions{
+		BlockNumberMin: in.BlockNumberMin,
+		BlockNumberMax: in.BlockNumberMax,
+		TimestampMin:   in.TimestampMin,
+		TimestampMax:   in.TimestampMax,
+	}
+	if len(in.KnownAccounts) > 0 {
+		c.KnownAccounts = make(map[common.Address]KnownAccount, len(in.KnownAccounts))
+	}
+	for _, knownAccount := range in.KnownAccounts {
+		var account KnownAccount
+		if knownAccount.StorageRoot != nil {
+			root := common.Hash(gointerfaces.ConvertH256ToHash(knownAccount.StorageRoot))
+			account.StorageRoot = &root
+		}
+		if len(knownAccount.StorageSlots) > 0 {
+			account.StorageSlots = make(map[common.Hash]common.Hash, len(knownAccount.StorageSlots))
+		}
+		for _, slot := range knownAccount.StorageSlots {
+			account.StorageSlots[gointerfaces.ConvertH256ToHash(slot.Key)] = gointerfaces.ConvertH256ToHash(slot.Value)
+		}
+		c.KnownAccounts[gointerfaces.ConvertH160toAddress(knownAccount.Address)] = account
+	}
+	return c
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpoolcfg
+
+import (
+	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/common/hexutil"
+	"github.com/erigontech/erigon/node/gointerfaces"
+	"github.com/erigontech/erigon/node/gointerfaces/txpoolproto"
+)
+
+// ExportedTxn - txn of pool, as exported by txpool_export: to be imported by txpool_import into pool of other node (for example hot standby)
+type ExportedTxn struct {
+	Rlp          hexutil.Bytes  `json:"rlp"` // blob txns are wrapped with their sidecars
+	Sender       common.Address `json:"sender"`
+	Local        bool           `json:"local"`
+	ArrivalBlock hexutil.Uint64 `json:"arrivalBlock"` // pool measures arrival time in blocks: number of head block when txn was added
+	Arrival      hexutil.Uint64 `json:"arrival"`      // sequence number of arrival to the pool: first-come-first-served order of txns
+}
+
+func (txn ExportedTxn) ToProto() *txpoolproto.ExportedTxn {
+	return &txpoolproto.ExportedTxn{
+		RlpTx:        txn.Rlp,
+		Sender:       gointerfaces.ConvertAddressToH160(txn.Sender),
+		IsLocal:      txn.Local,
+		ArrivalBlock: uint64(txn.ArrivalBlock),
+		Arrival:      uint64(txn.Arrival),
+	}
+}
+
+func ExportedTxnFromProto(in *txpoolproto.ExportedTxn) ExportedTxn {
+	return ExportedTxn{
+		Rlp:          in.RlpTx,
+		Sender:       gointerfaces.ConvertH160toAddress(in.Sender),
+		Local:        in.IsLocal,
+		ArrivalBlock: hexutil.Uint64(in.ArrivalBlock),
+		Arrival:      hexutil.Uint64(in.Arrival),
+	}
+}
@@ -58,6 +58,16 @@ type Config struct {
 
 	// Account Abstraction
 	AllowAA bool
+
+	// Order in which pending txns are offered for block production
+	Ordering        Ordering
+	PrioritySenders []common.Address // for OrderingPrioritySenders
+
+	// File to journal incoming txns and new blocks to, for replay by `txnbench replay`. Empty - disabled
+	JournalPath string
+
+	// Private api address (host:port) of txpool of other node, to mirror local txns to - for failover to that node. Empty - disabled
+	ReplicateTo string
 }
 
 var DefaultConfig = Config{
@@ -79,6 +89,26 @@ var DefaultConfig = Config{
 
 	NoGossip:     false,
 	MdbxWriteMap: false,
+
+	Ordering: OrderingFeePriority,
+}
+
+// Ordering - order in which txns of Pending sub-pool are offered for block production
+type Ordering string
+
+const (
+	OrderingFeePriority     Ordering = "fee"              // by effective tip
+	OrderingFCFS            Ordering = "fcfs"             // first-come-first-served: by arrival to the pool
+	OrderingPrioritySenders Ordering = "priority-senders" // txns of PrioritySenders first, then by effective tip
+)
+
+func ParseOrdering(s string) (Ordering, error) {
+	switch o := Ordering(s); o {
+	case OrderingFeePriority, OrderingFCFS, OrderingPrioritySenders:
+		return o, nil
+	default:
+		return "", fmt.Errorf("unknown txpool ordering %q, expected one of: %s, %s, %s", s, OrderingFeePriority, OrderingFCFS, OrderingPrioritySenders)
+	}
 }
 
 type DiscardReason uint8
@@ -121,6 +151,8 @@ const (
 	ErrAuthorityReserved DiscardReason = 34 // EIP-7702 transaction with authority already reserved
 	InvalidAA            DiscardReason = 35 // Invalid RIP-7560 transaction
 	ErrGetCode           DiscardReason = 36 // Error getting code during AA validation
+	ConditionsNotMet     DiscardReason = 37 // Conditions of eth_sendRawTransactionConditional transaction don't hold anymore
+	RemoteTxnsDisabled   DiscardReason = 38 // Remote transactions are not accepted: --txpool.nogossip
 )
 
 func (r DiscardReason) String() string {
@@ -199,7 +231,29 @@ func (r DiscardReason) String() string {
 		return "RIP-7560 transaction failed validation"
 	case ErrGetCode:
 		return "error getting account code during RIP-7560 validation"
+	case ConditionsNotMet:
+		return "transaction conditions not met"
+	case RemoteTxnsDisabled:
+		return "remote transactions are not accepted (--txpool.nogossip)"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}
 }
+
+// Reasons why transaction is not in Pending sub-pool
+const (
+	NotPendingNonceGap      = "nonce gap"
+	NotPendingBalance       = "insufficient balance"
+	NotPendingGasLimit      = "gas limit exceeds block gas limit"
+	NotPendingFeeTooLow     = "fee cap below pending base fee"
+	NotPendingBlobFeeTooLow = "blob fee cap below pending blob base fee"
+	NotPendingNotProcessed  = "not processed yet"
+)
+
+// TxnStatus - where transaction is in the pool (or why it was dropped from the pool)
+type TxnStatus struct {
+	SubPool       string        // "Pending", "BaseFee", "Queued". Empty if txn is not in pool
+	NotPending    []string      // why txn is not in Pending sub-pool
+	DiscardReason DiscardReason // NotSet if txn was not dropped. Dropped txns are remembered in LRU - forgotten eventually
+	DiscardedAt   time.Time
+}